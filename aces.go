@@ -0,0 +1,91 @@
+package colorspace
+
+import "github.com/soypat/geometry/ms3"
+
+var (
+	// AP0 and AP1 primaries of SMPTE ST 2065-1 and Academy S-2014-004. The ACES white point
+	// is close to but not exactly CIE D60.
+	acesWhite = CIExy{X: 0.32168, Y: 0.33767}
+	acesAP0   = Chromaticities{Red: CIExy{X: 0.7347, Y: 0.2653}, Green: CIExy{X: 0, Y: 1}, Blue: CIExy{X: 0.0001, Y: -0.077}, White: acesWhite}
+	acesAP1   = Chromaticities{Red: CIExy{X: 0.713, Y: 0.293}, Green: CIExy{X: 0.165, Y: 0.830}, Blue: CIExy{X: 0.128, Y: 0.044}, White: acesWhite}
+
+	// Conversions to XYZ adapted to D65 with the Bradford transform.
+	ap0ToXYZ = acesAP0.matrix()
+	xyzToAP0 = ap0ToXYZ.Inverse()
+	ap1ToXYZ = acesAP1.matrix()
+	xyzToAP1 = ap1ToXYZ.Inverse()
+)
+
+// ACES2065 is a linear ACES2065-1 color with the AP0 primaries, which enclose the whole spectral locus.
+// It is the scene-referred interchange and archival encoding of the Academy Color Encoding System used
+// in VFX and film pipelines. Values are relative scene luminance: 1 is a perfect diffuse reflector
+// and highlights are usually greater than 1.
+type ACES2065 struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+// ACEScg is a linear ACES color with the AP1 primaries, slightly wider than Rec.2020, used as the
+// working space for rendering and compositing. Like [ACES2065] values are scene-referred and unbounded.
+// To grade in a perceptual space convert through [CIEXYZ], i.e: c.CIEXYZ().OKLAB().
+type ACEScg struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+func (c ACES2065) vec() ms3.Vec      { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c ACEScg) vec() ms3.Vec        { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c ACES2065) Array() [3]float32 { return c.vec().Array() }
+func (c ACEScg) Array() [3]float32   { return c.vec().Array() }
+
+// CIEXYZ converts the ACES2065-1 color to CIE XYZ adapted from the ACES white point to D65.
+func (c ACES2065) CIEXYZ() CIEXYZ {
+	v := ms3.MulMatVec(ap0ToXYZ, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// CIEXYZ converts the ACEScg color to CIE XYZ adapted from the ACES white point to D65.
+func (c ACEScg) CIEXYZ() CIEXYZ {
+	v := ms3.MulMatVec(ap1ToXYZ, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// ACES2065 converts D65 relative XYZ to ACES2065-1 adapting it to the ACES white point.
+func (c CIEXYZ) ACES2065() ACES2065 {
+	v := ms3.MulMatVec(xyzToAP0, c.vec())
+	return ACES2065{R: v.X, G: v.Y, B: v.Z}
+}
+
+// ACEScg converts D65 relative XYZ to ACEScg adapting it to the ACES white point.
+func (c CIEXYZ) ACEScg() ACEScg {
+	v := ms3.MulMatVec(xyzToAP1, c.vec())
+	return ACEScg{R: v.X, G: v.Y, B: v.Z}
+}
+
+// ACEScg converts the ACES2065-1 color to ACEScg. AP0 colors outside the AP1 gamut have negative components.
+func (c ACES2065) ACEScg() ACEScg { return c.CIEXYZ().ACEScg() }
+
+// ACES2065 converts the ACEScg color to ACES2065-1.
+func (c ACEScg) ACES2065() ACES2065 { return c.CIEXYZ().ACES2065() }
+
+// ACES2065 converts the linear sRGB color to ACES2065-1.
+func (c LSRGB) ACES2065() ACES2065 { return c.CIEXYZ().ACES2065() }
+
+// ACEScg converts the linear sRGB color to ACEScg.
+func (c LSRGB) ACEScg() ACEScg { return c.CIEXYZ().ACEScg() }
+
+// LSRGB converts the ACES2065-1 color to linear sRGB. Components are unbounded: saturated colors and
+// highlights must be tone mapped or gamut mapped before encoding for display.
+func (c ACES2065) LSRGB() LSRGB { return c.CIEXYZ().LSRGB() }
+
+// LSRGB converts the ACEScg color to linear sRGB. Components are unbounded: saturated colors and
+// highlights must be tone mapped or gamut mapped before encoding for display.
+func (c ACEScg) LSRGB() LSRGB { return c.CIEXYZ().LSRGB() }
+
+// SRGB converts the ACEScg color to sRGB clipping out of range components. No tone mapping is applied.
+func (c ACEScg) SRGB() SRGB { return c.LSRGB().ClipToGamut().SRGB() }
+
+// SRGB converts the ACES2065-1 color to sRGB clipping out of range components. No tone mapping is applied.
+func (c ACES2065) SRGB() SRGB { return c.LSRGB().ClipToGamut().SRGB() }
@@ -0,0 +1,38 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+func TestACES(t *testing.T) {
+	// AP0 and AP1 to XYZ matrices relative to the ACES white as published by the Academy.
+	ap0 := ms3.NewMat3([]float32{0.9525523959, 0, 0.0000936786, 0.3439664498, 0.7281660966, -0.0721325464, 0, 0, 1.0088251844})
+	ap1 := ms3.NewMat3([]float32{0.6624541811, 0.1340042065, 0.1561876870, 0.2722287168, 0.6740817658, 0.0536895174, -0.0055746495, 0.0040607335, 1.0103391003})
+	if m := primariesMatrix(acesAP0.Red, acesAP0.Green, acesAP0.Blue, acesAP0.White); !ms3.EqualMat3(m, ap0, 1e-4) {
+		t.Errorf("AP0 matrix %v, want %v", m, ap0)
+	}
+	if m := primariesMatrix(acesAP1.Red, acesAP1.Green, acesAP1.Blue, acesAP1.White); !ms3.EqualMat3(m, ap1, 1e-4) {
+		t.Errorf("AP1 matrix %v, want %v", m, ap1)
+	}
+	// ACES white maps to sRGB white and neutrals stay neutral.
+	for _, v := range []float32{0.18, 1, 16} {
+		got := ACEScg{R: v, G: v, B: v}.LSRGB()
+		if sqdist(got.vec(), ms3.Vec{X: v, Y: v, Z: v}) > 1e-6*v*v {
+			t.Errorf("ACEScg gray %v in linear sRGB = %+v", v, got)
+		}
+	}
+	// Published sRGB to ACEScg conversion of linear sRGB red.
+	red := LSRGB{R: 1}.ACEScg()
+	if want := (ACEScg{R: 0.6131, G: 0.0702, B: 0.0206}); sqdist(red.vec(), want.vec()) > 1e-5 {
+		t.Errorf("sRGB red in ACEScg = %+v, want %+v", red, want)
+	}
+	c := ACES2065{R: 0.3, G: 2.5, B: 0.05}
+	if back := c.ACEScg().ACES2065(); sqdist(back.vec(), c.vec()) > 1e-8 {
+		t.Errorf("AP0/AP1 round trip of %+v gave %+v", c, back)
+	}
+	if s := (ACEScg{R: 4, G: 0.1, B: -0.2}).SRGB(); !s.LSRGB().InGamut() {
+		t.Errorf("SRGB conversion out of gamut: %+v", s)
+	}
+}
@@ -0,0 +1,124 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/soypat/geometry/ms1"
+)
+
+// SRGBA is a gamma-encoded sRGB color with straight (non-premultiplied) alpha in [0,1].
+// The alpha carrying types embed their opaque counterpart and name the alpha field Alpha
+// since OKLab already uses A for an axis.
+type SRGBA struct {
+	SRGB
+	Alpha float32
+}
+
+// OKLABA is an [OKLAB] color with straight alpha in [0,1].
+type OKLABA struct {
+	OKLAB
+	Alpha float32
+}
+
+// OKLCHA is an [OKLCH] color with straight alpha in [0,1].
+type OKLCHA struct {
+	OKLCH
+	Alpha float32
+}
+
+// ColorToSRGBA converts c to [SRGBA], un-premultiplying its color channels. Unlike [ColorToSRGB]
+// the alpha channel is preserved. Fully transparent colors have black color channels.
+func ColorToSRGBA(c color.Color) SRGBA {
+	s, a := unpremultiplied(c)
+	return SRGBA{SRGB: s, Alpha: a}
+}
+
+// RGBA implements [color.Color] returning alpha premultiplied channels. Out of gamut values are clipped.
+func (c SRGBA) RGBA() (r, g, b, a uint32) {
+	s := c.SRGB.ClipToGamut()
+	alpha := clamp01(c.Alpha)
+	return uint32(s.R*alpha*0xffff + 0.5), uint32(s.G*alpha*0xffff + 0.5), uint32(s.B*alpha*0xffff + 0.5), uint32(alpha*0xffff + 0.5)
+}
+
+// OKLABA converts c to OKLab keeping alpha.
+func (c SRGBA) OKLABA() OKLABA {
+	return OKLABA{OKLAB: c.LSRGB().CIEXYZ().OKLAB(), Alpha: c.Alpha}
+}
+
+// OKLCHA converts c to OKLCH keeping alpha.
+func (c SRGBA) OKLCHA() OKLCHA {
+	return c.OKLABA().OKLCHA()
+}
+
+// OKLCHA converts c to its cylindrical representation keeping alpha.
+func (c OKLABA) OKLCHA() OKLCHA {
+	return OKLCHA{OKLCH: c.OKLCH(), Alpha: c.Alpha}
+}
+
+// SRGBA converts c to sRGB keeping alpha. Out of gamut colors are gamut mapped.
+func (c OKLABA) SRGBA() SRGBA {
+	return c.OKLCHA().SRGBA()
+}
+
+// OKLABA converts c to its Cartesian representation keeping alpha.
+func (c OKLCHA) OKLABA() OKLABA {
+	return OKLABA{OKLAB: c.OKLAB(), Alpha: c.Alpha}
+}
+
+// SRGBA converts c to sRGB keeping alpha. Out of gamut colors are gamut mapped.
+func (c OKLCHA) SRGBA() SRGBA {
+	return SRGBA{SRGB: oklchToSRGB(c.OKLCH), Alpha: c.Alpha}
+}
+
+// Lerp interpolates colors and alpha with premultiplied alpha as specified by CSS Color 4,
+// so a fully transparent endpoint does not bleed its color into the result.
+func (from SRGBA) Lerp(to SRGBA, v float32) SRGBA {
+	a := ms1.Interp(from.Alpha, to.Alpha, v)
+	if a <= 0 {
+		return SRGBA{}
+	}
+	pre := func(c SRGBA) SRGB {
+		return SRGB{R: c.R * c.Alpha, G: c.G * c.Alpha, B: c.B * c.Alpha}
+	}
+	s := pre(from).Lerp(pre(to), v)
+	return SRGBA{SRGB: SRGB{R: s.R / a, G: s.G / a, B: s.B / a}, Alpha: a}
+}
+
+// Lerp interpolates colors and alpha with premultiplied alpha. See [SRGBA.Lerp].
+func (from OKLABA) Lerp(to OKLABA, v float32) OKLABA {
+	a := ms1.Interp(from.Alpha, to.Alpha, v)
+	if a <= 0 {
+		return OKLABA{}
+	}
+	pre := func(c OKLABA) OKLAB {
+		return OKLAB{L: c.L * c.Alpha, A: c.A * c.Alpha, B: c.B * c.Alpha}
+	}
+	lab := pre(from).Lerp(pre(to), v)
+	return OKLABA{OKLAB: OKLAB{L: lab.L / a, A: lab.A / a, B: lab.B / a}, Alpha: a}
+}
+
+// Lerp interpolates colors and alpha with premultiplied alpha. Hue is not premultiplied
+// and the hue of a fully transparent endpoint is ignored. See [SRGBA.Lerp].
+func (from OKLCHA) Lerp(to OKLCHA, v float32) OKLCHA {
+	a := ms1.Interp(from.Alpha, to.Alpha, v)
+	if a <= 0 {
+		return OKLCHA{}
+	}
+	pre := func(c OKLCHA) OKLCH {
+		return OKLCH{L: c.L * c.Alpha, C: c.C * c.Alpha, H: c.H}
+	}
+	lch := pre(from).Lerp(pre(to), v)
+	return OKLCHA{OKLCH: OKLCH{L: lch.L / a, C: lch.C / a, H: lch.H}, Alpha: a}
+}
+
+// LerpOKLABA interpolates in OKLab with premultiplied alpha, preserving transparency
+// unlike [LerpOKLAB] which returns opaque colors.
+func LerpOKLABA(c1, c2 color.Color, v float32) color.Color {
+	return ColorToSRGBA(c1).OKLABA().Lerp(ColorToSRGBA(c2).OKLABA(), v).SRGBA()
+}
+
+// LerpOKLCHA interpolates in OKLCH with premultiplied alpha, preserving transparency
+// unlike [LerpOKLCH] which returns opaque colors.
+func LerpOKLCHA(c1, c2 color.Color, v float32) color.Color {
+	return ColorToSRGBA(c1).OKLCHA().Lerp(ColorToSRGBA(c2).OKLCHA(), v).SRGBA()
+}
@@ -0,0 +1,46 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestAlphaTypes(t *testing.T) {
+	// Premultiplied input is un-premultiplied.
+	c := ColorToSRGBA(color.RGBA{R: 0x80, A: 0x80})
+	if math32.Abs(c.R-1) > 1e-6 || math32.Abs(c.Alpha-0x80/255.) > 1e-6 {
+		t.Errorf("unexpected SRGBA %+v", c)
+	}
+	r, g, b, a := c.RGBA()
+	if r != 0x8080 || g != 0 || b != 0 || a != 0x8080 {
+		t.Errorf("RGBA not premultiplied: %x %x %x %x", r, g, b, a)
+	}
+	rt := c.OKLCHA().OKLABA().SRGBA()
+	if sqdist(rt.vec(), c.vec()) > 1e-8 || rt.Alpha != c.Alpha {
+		t.Errorf("round trip %+v != %+v", rt, c)
+	}
+
+	// Interpolating towards transparent keeps the color, only alpha changes.
+	red := SRGBA{SRGB: SRGB{R: 1}, Alpha: 1}
+	clear := SRGBA{SRGB: SRGB{B: 1}, Alpha: 0}
+	mid := red.Lerp(clear, 0.5)
+	if mid.SRGB != red.SRGB || mid.Alpha != 0.5 {
+		t.Errorf("sRGB transparent interpolation bled color: %+v", mid)
+	}
+	midLab := red.OKLABA().Lerp(clear.OKLABA(), 0.5).SRGBA()
+	if sqdist(midLab.vec(), red.vec()) > 1e-6 {
+		t.Errorf("OKLab transparent interpolation bled color: %+v", midLab)
+	}
+	midLCh := red.OKLCHA().Lerp(clear.OKLCHA(), 0.5)
+	if want := red.OKLCHA(); math32.Abs(midLCh.H-want.H) > 1e-3 || math32.Abs(midLCh.C-want.C) > 1e-4 {
+		t.Errorf("OKLCH transparent interpolation changed hue or chroma: %+v", midLCh)
+	}
+	if got := LerpOKLCHA(color.Transparent, color.Transparent, 0.5); got != (SRGBA{}) {
+		t.Errorf("transparent lerp got %+v", got)
+	}
+	if _, _, _, a := LerpOKLABA(red, color.Transparent, 0.25).RGBA(); a != 0xbfff && a != 0xc000 {
+		t.Errorf("unexpected alpha %x", a)
+	}
+}
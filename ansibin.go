@@ -0,0 +1,58 @@
+package colorspace
+
+// ANSIBin is a nominal CCT chromaticity quadrangle of ANSI C78.377 for solid state lighting.
+// A white light source whose chromaticity falls within the quadrangle may be marketed with the nominal CCT.
+type ANSIBin struct {
+	// NominalCCT is the nominal correlated color temperature in kelvin, i.e: 2700.
+	NominalCCT float32
+	// Center is the chromaticity of the bin center.
+	Center CIExy
+	// Corners are the quadrangle vertices in counter clockwise order in the xy diagram.
+	Corners [4]CIExy
+}
+
+// ansiBins are the 7 step quadrangles of ANSI C78.377-2008.
+var ansiBins = [...]ANSIBin{
+	{NominalCCT: 2700, Center: CIExy{X: 0.4578, Y: 0.4101}, Corners: [4]CIExy{{X: 0.4813, Y: 0.4319}, {X: 0.4562, Y: 0.4260}, {X: 0.4373, Y: 0.3893}, {X: 0.4593, Y: 0.3944}}},
+	{NominalCCT: 3000, Center: CIExy{X: 0.4338, Y: 0.4030}, Corners: [4]CIExy{{X: 0.4562, Y: 0.4260}, {X: 0.4299, Y: 0.4165}, {X: 0.4147, Y: 0.3814}, {X: 0.4373, Y: 0.3893}}},
+	{NominalCCT: 3500, Center: CIExy{X: 0.4073, Y: 0.3917}, Corners: [4]CIExy{{X: 0.4299, Y: 0.4165}, {X: 0.3996, Y: 0.4015}, {X: 0.3889, Y: 0.3690}, {X: 0.4147, Y: 0.3814}}},
+	{NominalCCT: 4000, Center: CIExy{X: 0.3818, Y: 0.3797}, Corners: [4]CIExy{{X: 0.4006, Y: 0.4044}, {X: 0.3736, Y: 0.3874}, {X: 0.3670, Y: 0.3578}, {X: 0.3898, Y: 0.3716}}},
+	{NominalCCT: 4500, Center: CIExy{X: 0.3611, Y: 0.3658}, Corners: [4]CIExy{{X: 0.3736, Y: 0.3874}, {X: 0.3548, Y: 0.3736}, {X: 0.3512, Y: 0.3465}, {X: 0.3670, Y: 0.3578}}},
+	{NominalCCT: 5000, Center: CIExy{X: 0.3447, Y: 0.3553}, Corners: [4]CIExy{{X: 0.3551, Y: 0.3760}, {X: 0.3376, Y: 0.3616}, {X: 0.3366, Y: 0.3369}, {X: 0.3515, Y: 0.3487}}},
+	{NominalCCT: 5700, Center: CIExy{X: 0.3287, Y: 0.3417}, Corners: [4]CIExy{{X: 0.3376, Y: 0.3616}, {X: 0.3207, Y: 0.3462}, {X: 0.3222, Y: 0.3243}, {X: 0.3366, Y: 0.3369}}},
+	{NominalCCT: 6500, Center: CIExy{X: 0.3123, Y: 0.3282}, Corners: [4]CIExy{{X: 0.3205, Y: 0.3481}, {X: 0.3028, Y: 0.3304}, {X: 0.3068, Y: 0.3113}, {X: 0.3221, Y: 0.3261}}},
+}
+
+// ANSIBins returns the ANSI C78.377 nominal CCT quadrangles in ascending CCT.
+func ANSIBins() []ANSIBin {
+	return append([]ANSIBin{}, ansiBins[:]...)
+}
+
+// Contains reports whether chromaticity c lies within the bin quadrangle. Points on the boundary are contained.
+func (b ANSIBin) Contains(c CIExy) bool {
+	for i, p := range b.Corners {
+		q := b.Corners[(i+1)%len(b.Corners)]
+		// Corners are counter clockwise so c must lie left of every edge.
+		if (q.X-p.X)*(c.Y-p.Y)-(q.Y-p.Y)*(c.X-p.X) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ClassifyANSIBin returns the ANSI C78.377 bin the measured white point chromaticity c falls in.
+// If c lies outside all quadrangles ok is false and the bin with the closest center in u′v′ is returned.
+func ClassifyANSIBin(c CIExy) (bin ANSIBin, ok bool) {
+	uv := c.UVPrime()
+	best := float32(-1)
+	for _, b := range ansiBins {
+		if b.Contains(c) {
+			return b, true
+		}
+		if d := SDCM(uv, b.Center.UVPrime()); best < 0 || d < best {
+			best = d
+			bin = b
+		}
+	}
+	return bin, false
+}
@@ -0,0 +1,21 @@
+package colorspace
+
+import "testing"
+
+func TestClassifyANSIBin(t *testing.T) {
+	for _, b := range ANSIBins() {
+		got, ok := ClassifyANSIBin(b.Center)
+		if !ok || got.NominalCCT != b.NominalCCT {
+			t.Errorf("center of %vK bin classified as %vK (ok=%v)", b.NominalCCT, got.NominalCCT, ok)
+		}
+	}
+	// D65 white lies within the 6500K quadrangle.
+	if got, ok := ClassifyANSIBin(IlluminantD65(1).CIExy()); !ok || got.NominalCCT != 6500 {
+		t.Errorf("D65 classified as %vK (ok=%v)", got.NominalCCT, ok)
+	}
+	// Greenish white is out of every bin but closest to 4000K.
+	got, ok := ClassifyANSIBin(CIExy{X: 0.38, Y: 0.42})
+	if ok || got.NominalCCT != 4000 {
+		t.Errorf("greenish white classified as %vK (ok=%v)", got.NominalCCT, ok)
+	}
+}
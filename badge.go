@@ -0,0 +1,79 @@
+package colorspace
+
+import (
+	"hash/fnv"
+	"image/color"
+
+	"github.com/soypat/geometry/ms1"
+)
+
+const (
+	// Chroma bounds of badge backgrounds. Chroma below is muddy and above is garish.
+	badgeMinChroma = 0.03
+	badgeMaxChroma = 0.16
+	// Highest contrast guaranteed by BadgeColors.
+	badgeMaxContrast = 12
+)
+
+// BadgeColors returns a background and foreground color pair suitable for a label or badge
+// derived from the hue of the seed color. The background keeps the seed's hue with chroma bounded
+// to a pleasant range and the foreground is a light or dark tint of the same hue.
+// The pair is guaranteed to have a [ContrastRatio] of at least minContrast,
+// which is clamped to a maximum of 12. The background lightness is modified only if needed to meet the contrast.
+func BadgeColors(seed color.Color, minContrast float32) (bg, fg SRGB) {
+	return badgeColors(colorToOKLCH(seed), minContrast)
+}
+
+// BadgeColorsFromString is like [BadgeColors] but derives the seed color from a hash of s
+// so that the same string always yields the same badge colors, i.e: for labels of issues or users.
+func BadgeColorsFromString(s string, minContrast float32) (bg, fg SRGB) {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	sum := h.Sum32()
+	seed := OKLCH{
+		H: float32(sum%3600) / 10,
+		C: 0.08 + 0.06*float32((sum>>12)&0xff)/0xff,
+		L: 0.55 + 0.3*float32(sum>>20)/0xfff,
+	}
+	return badgeColors(seed, minContrast)
+}
+
+func badgeColors(seed OKLCH, minContrast float32) (bg, fg SRGB) {
+	// Small margin so contrast is met after quantization to integer RGBA.
+	minContrast = ms1.Clamp(minContrast, 1, badgeMaxContrast) + 0.01
+	base := OKLCH{L: ms1.Clamp(seed.L, 0.3, 0.9), C: seed.C, H: seed.H}
+	if base.C > epsUnit {
+		base.C = ms1.Clamp(base.C, badgeMinChroma, badgeMaxChroma)
+	}
+	light := oklchToSRGB(OKLCH{L: 0.985, C: 0.01, H: base.H})
+	dark := oklchToSRGB(OKLCH{L: 0.22, C: 0.02, H: base.H})
+	ylight := light.RelativeLuminance()
+	ydark := dark.RelativeLuminance()
+
+	bg = oklchToSRGB(base)
+	ybg := bg.RelativeLuminance()
+	fg, yfg := dark, ydark
+	extremeL := float32(1) // Lightness to move background towards to increase contrast.
+	if contrastRatio(ybg, ylight) > contrastRatio(ybg, ydark) {
+		fg, yfg = light, ylight
+		extremeL = 0
+	}
+	if contrastRatio(ybg, yfg) >= minContrast {
+		return bg, fg
+	}
+	// Bisect for the background lightness closest to the base that meets contrast.
+	lo, hi := base.L, extremeL
+	current := base
+	bg = oklchToSRGB(OKLCH{L: extremeL, C: 0, H: base.H})
+	for i := 0; i < 24; i++ {
+		current.L = 0.5 * (lo + hi)
+		candidate := oklchToSRGB(current)
+		if contrastRatio(candidate.RelativeLuminance(), yfg) >= minContrast {
+			bg = candidate
+			hi = current.L
+		} else {
+			lo = current.L
+		}
+	}
+	return bg, fg
+}
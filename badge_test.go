@@ -0,0 +1,20 @@
+package colorspace
+
+import "testing"
+
+func TestBadgeColors(t *testing.T) {
+	for _, minContrast := range []float32{3, 4.5, 7, 12} {
+		for _, c := range jet {
+			bg, fg := BadgeColors(c, minContrast)
+			if got := ContrastRatio(bg, fg); got < minContrast {
+				t.Errorf("badge for %v contrast %v below minimum %v", c, got, minContrast)
+			}
+		}
+		for _, s := range []string{"bug", "enhancement", "help wanted", ""} {
+			bg, fg := BadgeColorsFromString(s, minContrast)
+			if got := ContrastRatio(bg, fg); got < minContrast {
+				t.Errorf("badge for %q contrast %v below minimum %v", s, got, minContrast)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package colorspace
+
+import (
+	"image"
+	"sort"
+)
+
+// BandingReport describes the smoothness of a rendered gradient. See [AnalyzeBanding].
+type BandingReport struct {
+	// MaxStep and MedianStep are the largest and median CIEDE2000 ΔE between non-identical adjacent pixels.
+	MaxStep, MedianStep float32
+	// Spikes is the number of adjacent pixel steps with a ΔE above the threshold.
+	Spikes int
+	// MaxRun is the length in pixels of the longest run of identical adjacent pixels, i.e: the widest band.
+	MaxRun int
+	// Severity is MaxStep divided by the threshold. Values above 1 indicate visible banding.
+	Severity float32
+}
+
+// AnalyzeBanding inspects an 8-bit rendered gradient image for banding by measuring the
+// [CIELAB.DeltaE2000] between adjacent pixels along the dominant axis of the gradient, which is horizontal
+// or vertical. CIELAB is used since the cube root of OKLab exaggerates steps near black.
+// A smooth gradient has steps below threshold everywhere; a threshold of 1 flags steps that are
+// visible on close inspection. Identical neighbors are not steps so noise from dithering is only
+// penalized if it exceeds threshold. Alpha is discarded.
+func AnalyzeBanding(img image.Image, threshold float32) BandingReport {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	labs := make([]CIELAB, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c, _ := unpremultiplied(img.At(bounds.Min.X+x, bounds.Min.Y+y))
+			labs[y*w+x] = colorToCIELAB(c)
+		}
+	}
+	rows, rowTotal := analyzeBandingAxis(labs, h, w, func(line, i int) int { return line*w + i }, threshold)
+	cols, colTotal := analyzeBandingAxis(labs, w, h, func(line, i int) int { return i*w + line }, threshold)
+	if colTotal > rowTotal {
+		return cols
+	}
+	return rows
+}
+
+// analyzeBandingAxis measures steps along lines of n pixels. idx maps line and position to the labs index.
+// It returns the report and the total variation along the axis.
+func analyzeBandingAxis(labs []CIELAB, lines, n int, idx func(line, i int) int, threshold float32) (BandingReport, float32) {
+	var report BandingReport
+	var steps []float32
+	var total float32
+	if n > 0 {
+		report.MaxRun = 1
+	}
+	for line := 0; line < lines; line++ {
+		run := 1
+		for i := 1; i < n; i++ {
+			d := labs[idx(line, i-1)].DeltaE2000(labs[idx(line, i)])
+			if d == 0 {
+				run++
+				if run > report.MaxRun {
+					report.MaxRun = run
+				}
+				continue
+			}
+			run = 1
+			total += d
+			steps = append(steps, d)
+			if d > threshold {
+				report.Spikes++
+			}
+		}
+	}
+	if len(steps) == 0 {
+		return report, 0
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+	report.MaxStep = steps[len(steps)-1]
+	report.MedianStep = steps[len(steps)/2]
+	if threshold > 0 {
+		report.Severity = report.MaxStep / threshold
+	}
+	return report, total
+}
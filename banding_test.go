@@ -0,0 +1,40 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestAnalyzeBanding(t *testing.T) {
+	const w, h = 256, 4
+	render := func(levels int) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for x := 0; x < w; x++ {
+			v := uint8(x / (w / levels) * 255 / (levels - 1))
+			for y := 0; y < h; y++ {
+				img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+			}
+		}
+		return img
+	}
+	smooth := AnalyzeBanding(render(256), 1)
+	if smooth.Spikes != 0 || smooth.Severity > 1 || smooth.MaxRun != 1 {
+		t.Errorf("smooth 8-bit ramp reported banding: %+v", smooth)
+	}
+	banded := AnalyzeBanding(render(8), 1)
+	if banded.Spikes != 7*h || banded.Severity <= 1 || banded.MaxRun != w/8 {
+		t.Errorf("8 level ramp not detected as banded: %+v", banded)
+	}
+	// Transposed image is analyzed along its vertical axis.
+	vertical := image.NewRGBA(image.Rect(0, 0, h, w))
+	src := render(8)
+	for y := 0; y < w; y++ {
+		for x := 0; x < h; x++ {
+			vertical.Set(x, y, src.At(y, x))
+		}
+	}
+	if got := AnalyzeBanding(vertical, 1); got != banded {
+		t.Errorf("vertical gradient report %+v differs from horizontal %+v", got, banded)
+	}
+}
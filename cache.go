@@ -0,0 +1,104 @@
+package colorspace
+
+import (
+	"container/list"
+	"image"
+	"sync"
+)
+
+// ConversionCache memoizes an expensive color conversion, i.e: one ending in [OKLCH.GamutMappedLSRGB],
+// in a least recently used cache of bounded size. Inputs are quantized to 16 bits per channel
+// to form the cache key; inputs outside [0,1], i.e: extended range sRGB, bypass the cache. Converting images dominated by a few thousand unique colors, such as
+// screenshots and charts, becomes a matter of map lookups. It is safe for concurrent use.
+type ConversionCache struct {
+	convert  func(SRGB) SRGB
+	capacity int
+
+	mu      sync.Mutex
+	entries map[uint64]*list.Element
+	lru     list.List // Front is most recently used.
+	hits    uint64
+	misses  uint64
+}
+
+type cacheEntry struct {
+	key uint64
+	out SRGB
+}
+
+// NewConversionCache returns a cache wrapping convert which holds at most capacity results.
+func NewConversionCache(capacity int, convert func(SRGB) SRGB) *ConversionCache {
+	if capacity <= 0 {
+		panic("cache capacity must be positive")
+	}
+	return &ConversionCache{
+		convert:  convert,
+		capacity: capacity,
+		entries:  make(map[uint64]*list.Element, capacity),
+	}
+}
+
+// Convert returns the conversion of c, computing it only if its quantized value is not cached.
+func (cc *ConversionCache) Convert(c SRGB) SRGB {
+	key, ok := conversionKey(c)
+	if !ok {
+		return cc.convert(c)
+	}
+	cc.mu.Lock()
+	if elem, ok := cc.entries[key]; ok {
+		cc.lru.MoveToFront(elem)
+		cc.hits++
+		out := elem.Value.(*cacheEntry).out
+		cc.mu.Unlock()
+		return out
+	}
+	cc.misses++
+	cc.mu.Unlock()
+
+	// Convert without holding the lock so slow conversions do not serialize callers.
+	out := cc.convert(c)
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if elem, ok := cc.entries[key]; ok {
+		// Another goroutine stored the result in the meantime.
+		cc.lru.MoveToFront(elem)
+		return out
+	}
+	if cc.lru.Len() >= cc.capacity {
+		oldest := cc.lru.Back()
+		cc.lru.Remove(oldest)
+		delete(cc.entries, oldest.Value.(*cacheEntry).key)
+	}
+	cc.entries[key] = cc.lru.PushFront(&cacheEntry{key: key, out: out})
+	return out
+}
+
+// MapImage returns a copy of img with the cached conversion applied to every pixel. Alpha is preserved.
+func (cc *ConversionCache) MapImage(img image.Image) *image.RGBA64 {
+	return mapImageSRGB(img, cc.Convert)
+}
+
+// Len returns the number of cached results.
+func (cc *ConversionCache) Len() int {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.lru.Len()
+}
+
+// Stats returns the number of cache hits and misses since creation, useful to tune capacity.
+func (cc *ConversionCache) Stats() (hits, misses uint64) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.hits, cc.misses
+}
+
+// conversionKey returns the quantized cache key of c. ok is false if a channel is outside [0,1]
+// since quantizing would collide it with in range colors.
+func conversionKey(c SRGB) (key uint64, ok bool) {
+	if c != c.ClipToGamut() {
+		return 0, false
+	}
+	q := func(v float32) uint64 { return uint64(v*0xffff + 0.5) }
+	return q(c.R)<<32 | q(c.G)<<16 | q(c.B), true
+}
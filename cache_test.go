@@ -0,0 +1,57 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestConversionCache(t *testing.T) {
+	calls := 0
+	invert := func(c SRGB) SRGB {
+		calls++
+		return SRGB{R: 1 - c.R, G: 1 - c.G, B: 1 - c.B}
+	}
+	cc := NewConversionCache(2, invert)
+	a, b, c := SRGB{R: 1}, SRGB{G: 1}, SRGB{B: 1}
+	if got := cc.Convert(a); got != (SRGB{G: 1, B: 1}) {
+		t.Errorf("unexpected conversion %+v", got)
+	}
+	cc.Convert(a)
+	cc.Convert(b)
+	if calls != 2 {
+		t.Errorf("expected 2 conversions, got %d", calls)
+	}
+	cc.Convert(a) // a is now most recently used.
+	cc.Convert(c) // Evicts b.
+	if cc.Len() != 2 {
+		t.Errorf("cache exceeded capacity: %d", cc.Len())
+	}
+	calls = 0
+	cc.Convert(a)
+	cc.Convert(b)
+	if calls != 1 {
+		t.Errorf("expected only evicted color to be recomputed, got %d conversions", calls)
+	}
+	if hits, misses := cc.Stats(); hits != 3 || misses != 4 {
+		t.Errorf("unexpected stats hits=%d misses=%d", hits, misses)
+	}
+	// Extended range inputs do not collide with their clipped counterparts.
+	if got := cc.Convert(SRGB{R: 1.5}); got != (SRGB{R: -0.5, G: 1, B: 1}) {
+		t.Errorf("extended range conversion %+v", got)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	calls = 0
+	out := NewConversionCache(16, invert).MapImage(img)
+	if calls != 2 {
+		t.Errorf("expected one conversion per unique color, got %d", calls)
+	}
+	if r, g, b, _ := out.At(0, 0).RGBA(); r != 0 || g != 0xffff || b != 0xffff {
+		t.Errorf("unexpected pixel %v", out.At(0, 0))
+	}
+}
@@ -0,0 +1,68 @@
+package colorspace
+
+import (
+	"image/color"
+	"sort"
+)
+
+// DeltaEReport contains per-patch and aggregate CIEDE2000 color differences between
+// measured colors and their reference targets, as used to verify camera and display calibration.
+type DeltaEReport struct {
+	// Patches contains the CIEDE2000 difference of each patch.
+	Patches []float32
+	// Mean is the mean difference over all patches.
+	Mean float32
+	// Median is the median difference over all patches.
+	Median float32
+	// Max is the largest patch difference and MaxIndex is the index of said patch.
+	Max      float32
+	MaxIndex int
+}
+
+// CompareCIELAB compares measured CIELAB colors against reference CIELAB values of the same
+// patches using [CIELAB.DeltaE2000]. Both slices must be of the same length and should be relative
+// to the same white point (D50 for most published charts).
+func CompareCIELAB(measured, reference []CIELAB) DeltaEReport {
+	if len(measured) != len(reference) {
+		panic("measured and reference patch count mismatch")
+	}
+	report := DeltaEReport{Patches: make([]float32, len(measured))}
+	if len(measured) == 0 {
+		return report
+	}
+	var sum float32
+	for i := range measured {
+		d := reference[i].DeltaE2000(measured[i])
+		report.Patches[i] = d
+		sum += d
+		if d > report.Max {
+			report.Max = d
+			report.MaxIndex = i
+		}
+	}
+	report.Mean = sum / float32(len(measured))
+	sorted := append([]float32{}, report.Patches...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		report.Median = sorted[n/2]
+	} else {
+		report.Median = 0.5 * (sorted[n/2-1] + sorted[n/2])
+	}
+	return report
+}
+
+// ComparePalettes is like [CompareCIELAB] but accepts sRGB encoded colors
+// which are converted to CIELAB relative to D50.
+func ComparePalettes(measured, reference color.Palette) DeltaEReport {
+	if len(measured) != len(reference) {
+		panic("measured and reference patch count mismatch")
+	}
+	m := make([]CIELAB, len(measured))
+	r := make([]CIELAB, len(reference))
+	for i := range measured {
+		m[i] = colorToCIELAB(measured[i])
+		r[i] = colorToCIELAB(reference[i])
+	}
+	return CompareCIELAB(m, r)
+}
@@ -0,0 +1,22 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestComparePalettes(t *testing.T) {
+	measured := make(color.Palette, len(jet))
+	copy(measured, jet)
+	measured[3] = color.RGBA64{R: 0x4000, G: 0x8000, B: 0x4000, A: 0xffff}
+	report := ComparePalettes(measured, jet)
+	if report.MaxIndex != 3 || report.Max <= 1 {
+		t.Errorf("expected patch 3 to have largest difference, got %+v", report)
+	}
+	if report.Median != 0 {
+		t.Errorf("expected zero median difference, got %v", report.Median)
+	}
+	if report.Mean <= 0 || report.Mean >= report.Max {
+		t.Errorf("unexpected mean difference %v", report.Mean)
+	}
+}
@@ -0,0 +1,227 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	cam16XYZToRGB = ms3.NewMat3([]float32{
+		0.401288, 0.650173, -0.051461,
+		-0.250268, 1.204414, 0.045854,
+		-0.002079, 0.048952, 0.953127,
+	})
+	cam16RGBToXYZ = cam16XYZToRGB.Inverse()
+)
+
+// Surround is the relative luminance of the surround of the viewing field in a color appearance model.
+type Surround uint8
+
+const (
+	// SurroundAverage is the surround of surface colors and monitors in a lit office.
+	SurroundAverage Surround = iota
+	// SurroundDim is the surround of television viewing in a dimly lit room.
+	SurroundDim
+	// SurroundDark is the surround of projection in a dark room.
+	SurroundDark
+)
+
+// ViewingConditions describes the environment in which colors are seen for a color appearance model.
+type ViewingConditions struct {
+	// White is the adopted white point, normalized to Y=1.
+	White CIEXYZ
+	// AdaptingLuminance is the luminance of the adapting field in cd/m², usually 20% of the luminance of white.
+	AdaptingLuminance float32
+	// BackgroundLuminance is the relative luminance of the background in [0,1], usually 0.2.
+	BackgroundLuminance float32
+	// Surround is the luminance of the surround relative to the background.
+	Surround Surround
+	// DiscountIlluminant sets full adaptation to the white point as for surface colors when the illuminant
+	// is readily identified. When false the degree of adaptation is computed from AdaptingLuminance.
+	DiscountIlluminant bool
+}
+
+// CAM16Model is the CAM16 color appearance model of Li et al. 2017 for a set of viewing conditions.
+// Create it with [NewCAM16Model]. It is safe for concurrent use.
+type CAM16Model struct {
+	vc ViewingConditions
+	// Precomputed viewing condition parameters.
+	dRGB       ms3.Vec // Degree of adaptation per channel.
+	fl, flRoot float32 // Luminance level adaptation factor and its fourth root.
+	n, z       float32 // Background induction factor and base exponent.
+	nbb        float32 // Chromatic and background induction factor.
+	c, nc      float32 // Surround impact and chromatic induction factor.
+	aw         float32 // Achromatic response of white.
+}
+
+// CAM16Default is the CAM16 model for sRGB content seen on a monitor: D65 white, an average surround,
+// an adapting luminance of 11.72 cd/m² and a background of middle gray (L*=50), as used by Material Design.
+var CAM16Default = NewCAM16Model(ViewingConditions{
+	White:               IlluminantD65(1),
+	AdaptingLuminance:   200 / math32.Pi * 0.18418652,
+	BackgroundLuminance: 0.18418652,
+})
+
+// NewCAM16Model returns the CAM16 model for the viewing conditions vc.
+func NewCAM16Model(vc ViewingConditions) *CAM16Model {
+	m := &CAM16Model{vc: vc}
+	var f float32
+	switch vc.Surround {
+	case SurroundDim:
+		f, m.c, m.nc = 0.9, 0.59, 0.9
+	case SurroundDark:
+		f, m.c, m.nc = 0.8, 0.525, 0.8
+	default:
+		f, m.c, m.nc = 1, 0.69, 1
+	}
+	la := vc.AdaptingLuminance
+	d := float32(1)
+	if !vc.DiscountIlluminant {
+		d = ms1.Clamp(f*(1-math32.Exp((-la-42)/92)/3.6), 0, 1)
+	}
+	white := ms3.Scale(100, vc.White.vec())
+	rgbw := ms3.MulMatVec(cam16XYZToRGB, white)
+	m.dRGB = ms3.Vec{
+		X: d*white.Y/rgbw.X + 1 - d,
+		Y: d*white.Y/rgbw.Y + 1 - d,
+		Z: d*white.Y/rgbw.Z + 1 - d,
+	}
+	k := 1 / (5*la + 1)
+	k4 := k * k * k * k
+	m.fl = k4*la + 0.1*(1-k4)*(1-k4)*math32.Cbrt(5*la)
+	m.flRoot = math32.Sqrt(math32.Sqrt(m.fl))
+	m.n = vc.BackgroundLuminance / vc.White.Y
+	m.z = 1.48 + math32.Sqrt(m.n)
+	m.nbb = 0.725 / math32.Pow(m.n, 0.2)
+	rgbaw := m.compress(ms3.MulElem(m.dRGB, rgbw))
+	m.aw = (2*rgbaw.X + rgbaw.Y + rgbaw.Z/20) * m.nbb
+	return m
+}
+
+// ViewingConditions returns the viewing conditions of the model.
+func (m *CAM16Model) ViewingConditions() ViewingConditions { return m.vc }
+
+// CAM16 is a color appearance described by the CAM16 model under some viewing conditions.
+// See [CAM16Model].
+type CAM16 struct {
+	J float32 // Lightness in [0,100].
+	C float32 // Chroma.
+	H float32 // Hue angle in degrees in [0,360).
+	M float32 // Colorfulness.
+}
+
+// CAM16UCS is the uniform color space derived from [CAM16] in which Euclidean distance is a color difference
+// that outperforms CIEDE2000 on most data sets. J is the CAM16-UCS lightness J′ and A, B the a′ b′
+// opponent coordinates scaled from colorfulness.
+type CAM16UCS struct {
+	J, A, B float32
+}
+
+func (c CAM16UCS) vec() ms3.Vec      { return ms3.Vec{X: c.J, Y: c.A, Z: c.B} }
+func (c CAM16UCS) Array() [3]float32 { return c.vec().Array() }
+
+// CAM16 returns the appearance of the D65 relative color c. The color is seen relative to the
+// model's white: XYZ values are not chromatically adapted beforehand.
+func (m *CAM16Model) CAM16(c CIEXYZ) CAM16 {
+	rgb := ms3.MulMatVec(cam16XYZToRGB, ms3.Scale(100, c.vec()))
+	ra := m.compress(ms3.MulElem(m.dRGB, rgb))
+	a := ra.X - 12*ra.Y/11 + ra.Z/11
+	b := (ra.X + ra.Y - 2*ra.Z) / 9
+	h := hueAngle(b, a)
+	achromatic := (2*ra.X + ra.Y + ra.Z/20) * m.nbb
+	j := 100 * math32.Pow(math32.Max(achromatic/m.aw, 0), m.c*m.z)
+	t := 50000. / 13 * m.nc * m.nbb * cam16Eccentricity(h) * math32.Hypot(a, b) / (ra.X + ra.Y + 21*ra.Z/20 + 0.305)
+	chroma := math32.Pow(math32.Max(t, 0), 0.9) * math32.Sqrt(j/100) * math32.Pow(1.64-math32.Pow(0.29, m.n), 0.73)
+	return CAM16{J: j, C: chroma, H: h, M: chroma * m.flRoot}
+}
+
+// CIEXYZ returns the D65 relative color with appearance c. The colorfulness M is ignored.
+func (m *CAM16Model) CIEXYZ(c CAM16) CIEXYZ {
+	if c.J <= 0 {
+		return CIEXYZ{}
+	}
+	t := math32.Pow(c.C/(math32.Sqrt(c.J/100)*math32.Pow(1.64-math32.Pow(0.29, m.n), 0.73)), 1/0.9)
+	p2 := m.aw * math32.Pow(c.J/100, 1/(m.c*m.z)) / m.nbb
+	sin, cos := math32.Sincos(c.H * math32.Pi / 180)
+	// Magnitude of the opponent coordinates solving the chroma equation for the achromatic response p2.
+	gamma := t * (p2 + 0.305) / (50000./13*m.nc*m.nbb*cam16Eccentricity(c.H) + t*(671*cos+6588*sin)/1403)
+	a, b := gamma*cos, gamma*sin
+	ra := ms3.Vec{
+		X: (460*p2 + 451*a + 288*b) / 1403,
+		Y: (460*p2 - 891*a - 261*b) / 1403,
+		Z: (460*p2 - 220*a - 6300*b) / 1403,
+	}
+	rgb := ms3.DivElem(m.decompress(ra), m.dRGB)
+	xyz := ms3.Scale(0.01, ms3.MulMatVec(cam16RGBToXYZ, rgb))
+	return CIEXYZ{X: xyz.X, Y: xyz.Y, Z: xyz.Z}
+}
+
+// UCS returns the CAM16-UCS coordinates of the appearance.
+func (c CAM16) UCS() CAM16UCS {
+	mp := math32.Log1p(0.0228*c.M) / 0.0228
+	sin, cos := math32.Sincos(c.H * math32.Pi / 180)
+	return CAM16UCS{J: 1.7 * c.J / (1 + 0.007*c.J), A: mp * cos, B: mp * sin}
+}
+
+// CAM16FromUCS returns the appearance of the CAM16-UCS color u under the model's viewing conditions.
+func (m *CAM16Model) CAM16FromUCS(u CAM16UCS) CAM16 {
+	mp := math32.Hypot(u.A, u.B)
+	colorfulness := math32.Expm1(0.0228*mp) / 0.0228
+	return CAM16{
+		J: u.J / (1.7 - 0.007*u.J),
+		C: colorfulness / m.flRoot,
+		H: hueAngle(u.B, u.A),
+		M: colorfulness,
+	}
+}
+
+// DeltaE returns the CAM16-UCS color difference, the Euclidean distance between the colors.
+func (u CAM16UCS) DeltaE(other CAM16UCS) float32 {
+	return ms3.Norm(ms3.Sub(u.vec(), other.vec()))
+}
+
+// Lerp interpolates linearly between two CAM16-UCS colors.
+func (from CAM16UCS) Lerp(to CAM16UCS, v float32) CAM16UCS {
+	return CAM16UCS{
+		J: ms1.Interp(from.J, to.J, v),
+		A: ms1.Interp(from.A, to.A, v),
+		B: ms1.Interp(from.B, to.B, v),
+	}
+}
+
+// LerpCAM16UCS interpolates in CAM16-UCS under [CAM16Default] viewing conditions.
+func LerpCAM16UCS(c1, c2 color.Color, v float32) color.Color {
+	m := CAM16Default
+	u1 := m.CAM16(ColorToSRGB(c1).LSRGB().CIEXYZ()).UCS()
+	u2 := m.CAM16(ColorToSRGB(c2).LSRGB().CIEXYZ()).UCS()
+	return m.CIEXYZ(m.CAM16FromUCS(u1.Lerp(u2, v))).LSRGB().ClipToGamut().SRGB()
+}
+
+// compress applies the post-adaptation non-linear response compression to the adapted cone responses.
+// The 0.1 noise term of CIECAM02 is omitted and accounted for by the 0.305 offset of the chroma equation
+// as in the CSS and Material Design implementations.
+func (m *CAM16Model) compress(rgb ms3.Vec) ms3.Vec {
+	f := func(v float32) float32 {
+		p := math32.Pow(m.fl*math32.Abs(v)/100, 0.42)
+		return math32.Copysign(400*p/(p+27.13), v)
+	}
+	return ms3.Vec{X: f(rgb.X), Y: f(rgb.Y), Z: f(rgb.Z)}
+}
+
+// decompress is the inverse of compress.
+func (m *CAM16Model) decompress(rgba ms3.Vec) ms3.Vec {
+	f := func(v float32) float32 {
+		abs := math32.Abs(v)
+		base := math32.Max(0, 27.13*abs/(400-abs))
+		return math32.Copysign(100/m.fl*math32.Pow(base, 1/0.42), v)
+	}
+	return ms3.Vec{X: f(rgba.X), Y: f(rgba.Y), Z: f(rgba.Z)}
+}
+
+// cam16Eccentricity returns the eccentricity factor e_t of the hue angle in degrees.
+func cam16Eccentricity(h float32) float32 {
+	return 0.25 * (math32.Cos(h*math32.Pi/180+2) + 3.8)
+}
@@ -0,0 +1,54 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCAM16(t *testing.T) {
+	m := CAM16Default
+	// Reference values from Material Design's CAM16 implementation.
+	tests := []struct {
+		c        SRGB
+		j, c2, h float32
+	}{
+		{c: SRGB{R: 1}, j: 46.445, c2: 113.357, h: 27.408},
+		{c: SRGB{G: 1}, j: 79.331, c2: 108.410, h: 142.139},
+		{c: SRGB{B: 1}, j: 25.465, c2: 87.230, h: 282.788},
+		{c: SRGB{R: 1, G: 1, B: 1}, j: 100, c2: 2.869, h: 209.492},
+	}
+	for _, test := range tests {
+		xyz := test.c.LSRGB().CIEXYZ()
+		got := m.CAM16(xyz)
+		if math32.Abs(got.J-test.j) > 0.05 || math32.Abs(got.C-test.c2) > 0.1 || math32.Abs(got.H-test.h) > 0.1 {
+			t.Errorf("CAM16 of %+v = %+v, want J=%v C=%v h=%v", test.c, got, test.j, test.c2, test.h)
+		}
+		if back := m.CIEXYZ(got); sqdist(back.vec(), xyz.vec()) > 1e-8 {
+			t.Errorf("CAM16 round trip of %+v gave %+v", xyz, back)
+		}
+		ucs := got.UCS()
+		if back := m.CIEXYZ(m.CAM16FromUCS(ucs)); sqdist(back.vec(), xyz.vec()) > 1e-8 {
+			t.Errorf("CAM16-UCS round trip of %+v gave %+v", xyz, back)
+		}
+	}
+	// Darker surrounds make colors appear lighter relative to white.
+	dark := NewCAM16Model(ViewingConditions{White: IlluminantD65(1), AdaptingLuminance: 11.72, BackgroundLuminance: 0.18418652, Surround: SurroundDark})
+	gray := SRGB{R: 0.4, G: 0.4, B: 0.4}.LSRGB().CIEXYZ()
+	if jd, ja := dark.CAM16(gray).J, m.CAM16(gray).J; jd <= ja {
+		t.Errorf("dark surround J %v, average %v", jd, ja)
+	}
+	// Discounting the illuminant fully adapts to white.
+	full := NewCAM16Model(ViewingConditions{White: IlluminantD65(1), AdaptingLuminance: 64, BackgroundLuminance: 0.2, DiscountIlluminant: true})
+	if w := full.CAM16(IlluminantD65(1)); w.C > 1e-3 {
+		t.Errorf("adapted white has chroma %v", w.C)
+	}
+	red, orange := m.CAM16(SRGB{R: 1}.LSRGB().CIEXYZ()).UCS(), m.CAM16(SRGB{R: 1, G: 0.5}.LSRGB().CIEXYZ()).UCS()
+	if d := red.DeltaE(orange); d < 5 || d != orange.DeltaE(red) {
+		t.Errorf("CAM16-UCS DeltaE red to orange %v", d)
+	}
+	mid := ColorToSRGB(LerpCAM16UCS(SRGB{R: 1}, SRGB{B: 1}, 0.5))
+	if mid.R < 0.3 || mid.B < 0.3 {
+		t.Errorf("red-blue CAM16-UCS midpoint %+v", mid)
+	}
+}
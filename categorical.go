@@ -0,0 +1,84 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// ExtendPalette returns n new colors extending the categorical palette so charts can show more series than
+// it was designed for while keeping its look. New colors match the palette's style: their OKLCH lightness
+// and chroma lie within one standard deviation of the palette's mean lightness and chroma.
+// Among the in-gamut colors of that style they are chosen greedily to maximize the smallest OKLAB distance
+// to the existing and previously added colors. An empty palette is extended with medium lightness and chroma colors.
+// It returns nil if n <= 0 and fewer than n colors once the in-gamut candidates of the palette's style run out.
+func ExtendPalette(palette []color.Color, n int) []SRGB {
+	if n <= 0 {
+		return nil
+	}
+	const (
+		lightnessSteps = 5
+		chromaSteps    = 4
+		hueSteps       = 72
+	)
+	existing := make([]OKLAB, len(palette))
+	var meanL, meanC, varL, varC float32
+	for i, c := range palette {
+		existing[i] = colorToOKLAB(c)
+		lch := existing[i].OKLCH()
+		meanL += lch.L
+		meanC += lch.C
+		varL += lch.L * lch.L
+		varC += lch.C * lch.C
+	}
+	if k := float32(len(palette)); k > 0 {
+		meanL /= k
+		meanC /= k
+		varL = varL/k - meanL*meanL
+		varC = varC/k - meanC*meanC
+	} else {
+		meanL, meanC = 0.68, 0.14
+	}
+	// Keep a minimum spread so single color and uniform palettes still have candidates.
+	stdL := math32.Max(math32.Sqrt(math32.Max(varL, 0)), 0.03)
+	stdC := math32.Max(math32.Sqrt(math32.Max(varC, 0)), 0.02)
+	var candidates []OKLAB
+	for i := 0; i < lightnessSteps; i++ {
+		l := meanL + stdL*(2*float32(i)/(lightnessSteps-1)-1)
+		for j := 0; j < chromaSteps; j++ {
+			c := math32.Max(meanC+stdC*(2*float32(j)/(chromaSteps-1)-1), 0)
+			for k := 0; k < hueSteps; k++ {
+				lab := OKLCH{L: l, C: c, H: 360 * float32(k) / hueSteps}.OKLAB()
+				if lab.CIEXYZ().LSRGB().InGamut() {
+					candidates = append(candidates, lab)
+				}
+			}
+		}
+	}
+	// minDist[i] is the distance from candidate i to the nearest chosen or existing color.
+	minDist := make([]float32, len(candidates))
+	for i, cand := range candidates {
+		minDist[i] = math32.Inf(1)
+		for _, e := range existing {
+			minDist[i] = math32.Min(minDist[i], cand.DeltaE(e))
+		}
+	}
+	var result []SRGB
+	for len(result) < n && len(candidates) > 0 {
+		best := 0
+		for i, d := range minDist {
+			if d > minDist[best] {
+				best = i
+			}
+		}
+		if minDist[best] == 0 {
+			break // Every candidate has been chosen or duplicates an existing color.
+		}
+		chosen := candidates[best]
+		result = append(result, chosen.CIEXYZ().LSRGB().ClipToGamut().SRGB())
+		for i, cand := range candidates {
+			minDist[i] = math32.Min(minDist[i], cand.DeltaE(chosen))
+		}
+	}
+	return result
+}
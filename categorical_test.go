@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestExtendPalette(t *testing.T) {
+	// Tableau 10 first 8 colors.
+	hex := []uint32{0x4e79a7, 0xf28e2b, 0xe15759, 0x76b7b2, 0x59a14f, 0xedc948, 0xb07aa1, 0xff9da7}
+	palette := make([]color.Color, len(hex))
+	var minL, maxL float32 = 1, 0
+	for i, h := range hex {
+		c := SRGB{R: float32(h>>16) / 255, G: float32(h>>8&0xff) / 255, B: float32(h&0xff) / 255}
+		palette[i] = c
+		l := colorToOKLCH(c).L
+		if l < minL {
+			minL = l
+		}
+		if l > maxL {
+			maxL = l
+		}
+	}
+	added := ExtendPalette(palette, 6)
+	if len(added) != 6 {
+		t.Fatalf("got %d colors, want 6", len(added))
+	}
+	all := append([]color.Color{}, palette...)
+	for _, c := range added {
+		lab := colorToOKLAB(c)
+		if l := lab.OKLCH().L; l < minL-0.05 || l > maxL+0.05 {
+			t.Errorf("added color %+v lightness %v outside palette range [%v,%v]", c, l, minL, maxL)
+		}
+		for _, other := range all {
+			if d := lab.DeltaE(colorToOKLAB(other)); d < 0.05 {
+				t.Errorf("added color %+v too close to %v: %v", c, other, d)
+			}
+		}
+		all = append(all, c)
+	}
+	if got := ExtendPalette(nil, 3); len(got) != 3 {
+		t.Errorf("extending empty palette gave %d colors", len(got))
+	}
+	for _, n := range []int{0, -1} {
+		if got := ExtendPalette(palette, n); got != nil {
+			t.Errorf("ExtendPalette(palette, %d) = %v, want nil", n, got)
+		}
+	}
+	// Candidates run out before a huge n is reached.
+	got := ExtendPalette(palette, math.MaxInt)
+	if len(got) == 0 || len(got) == math.MaxInt {
+		t.Errorf("ExtendPalette(palette, math.MaxInt) gave %d colors", len(got))
+	}
+}
@@ -0,0 +1,106 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// CIExy holds the CIE 1931 (x, y) chromaticity coordinates of a color.
+type CIExy struct {
+	X, Y float32
+}
+
+// CIExy returns the CIE 1931 (x, y) chromaticity coordinates of c.
+// Black has undefined chromaticity and returns the zero value.
+func (c CIEXYZ) CIExy() CIExy {
+	sum := c.X + c.Y + c.Z
+	if sum == 0 {
+		return CIExy{}
+	}
+	return CIExy{X: c.X / sum, Y: c.Y / sum}
+}
+
+// CIEXYZ returns the color of chromaticity c with luminance Y equal to ynormal.
+func (c CIExy) CIEXYZ(ynormal float32) CIEXYZ {
+	return Illuminant(ynormal, c.X, c.Y)
+}
+
+// UVPrime converts CIE 1931 (x, y) to CIE 1976 (u′, v′) coordinates.
+func (c CIExy) UVPrime() UVPrime {
+	denom := -2*c.X + 12*c.Y + 3
+	return UVPrime{U: 4 * c.X / denom, V: 9 * c.Y / denom}
+}
+
+// CIExy converts CIE 1976 (u′, v′) to CIE 1931 (x, y) coordinates.
+func (c UVPrime) CIExy() CIExy {
+	denom := 6*c.U - 16*c.V + 12
+	return CIExy{X: 9 * c.U / denom, Y: 4 * c.V / denom}
+}
+
+// CIE1960UCS holds the CIE 1960 uniform chromaticity scale (u, v) coordinates of a color.
+// Though superseded by [UVPrime] it is still used to compute correlated color temperature
+// and the distance from the Planckian locus (Duv).
+type CIE1960UCS struct {
+	U, V float32
+}
+
+// UVPrime holds the CIE 1976 uniform chromaticity scale (u′, v′) coordinates of a color.
+// It is the chromaticity diagram used by [CIELUV] and most display and LED specifications.
+type UVPrime struct {
+	U, V float32
+}
+
+// CIE1960UCS returns the CIE 1960 (u, v) chromaticity coordinates of c.
+// Black has undefined chromaticity and returns the zero value.
+func (c CIEXYZ) CIE1960UCS() CIE1960UCS {
+	return c.UVPrime().CIE1960UCS()
+}
+
+// UVPrime returns the CIE 1976 (u′, v′) chromaticity coordinates of c.
+// Black has undefined chromaticity and returns the zero value.
+func (c CIEXYZ) UVPrime() UVPrime {
+	denom := c.X + 15*c.Y + 3*c.Z
+	if denom == 0 {
+		return UVPrime{}
+	}
+	return UVPrime{U: 4 * c.X / denom, V: 9 * c.Y / denom}
+}
+
+// CIEXYZ returns the color of chromaticity c with luminance Y equal to ynormal.
+// A v′ of zero has no defined color and returns the zero value.
+func (c UVPrime) CIEXYZ(ynormal float32) CIEXYZ {
+	if c.V == 0 {
+		return CIEXYZ{}
+	}
+	return CIEXYZ{
+		X: ynormal * 9 * c.U / (4 * c.V),
+		Y: ynormal,
+		Z: ynormal * (12 - 3*c.U - 20*c.V) / (4 * c.V),
+	}
+}
+
+// DeltaUV returns the chromaticity difference Δu′v′: the Euclidean distance in the CIE 1976 UCS diagram.
+// It is the standard measure of color uniformity of displays and LEDs, i.e: 0.004 is a barely noticeable difference.
+func (reference UVPrime) DeltaUV(sample UVPrime) float32 {
+	du, dv := reference.U-sample.U, reference.V-sample.V
+	return math32.Sqrt(du*du + dv*dv)
+}
+
+// MaxDeltaUV returns the largest Δu′v′ between any two chromaticities, the usual measure of color
+// uniformity across the measured points of a display or a batch of LEDs. See [UVPrime.DeltaUV].
+func MaxDeltaUV(samples []UVPrime) float32 {
+	var worst float32
+	for i := range samples {
+		for j := i + 1; j < len(samples); j++ {
+			worst = math32.Max(worst, samples[i].DeltaUV(samples[j]))
+		}
+	}
+	return worst
+}
+
+// UVPrime converts CIE 1960 (u, v) to CIE 1976 (u′, v′) coordinates.
+func (c CIE1960UCS) UVPrime() UVPrime {
+	return UVPrime{U: c.U, V: 1.5 * c.V}
+}
+
+// CIE1960UCS converts CIE 1976 (u′, v′) to CIE 1960 (u, v) coordinates.
+func (c UVPrime) CIE1960UCS() CIE1960UCS {
+	return CIE1960UCS{U: c.U, V: c.V / 1.5}
+}
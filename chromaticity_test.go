@@ -0,0 +1,47 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestUVPrime(t *testing.T) {
+	for _, test := range []struct {
+		xyz    CIEXYZ
+		up, vp float32
+		u, v   float32
+	}{
+		{xyz: IlluminantD65(1), up: 0.1978, vp: 0.4683, u: 0.1978, v: 0.3122},
+		{xyz: Illuminant(1, 0.44757, 0.40745), up: 0.2560, vp: 0.5243, u: 0.2560, v: 0.3495},
+	} {
+		uvp := test.xyz.UVPrime()
+		uv := test.xyz.CIE1960UCS()
+		if math32.Abs(uvp.U-test.up) > 1e-4 || math32.Abs(uvp.V-test.vp) > 1e-4 {
+			t.Errorf("%+v: got u'v' %+v, want (%v, %v)", test.xyz, uvp, test.up, test.vp)
+		}
+		if math32.Abs(uv.U-test.u) > 1e-4 || math32.Abs(uv.V-test.v) > 1e-4 {
+			t.Errorf("%+v: got uv %+v, want (%v, %v)", test.xyz, uv, test.u, test.v)
+		}
+		if xyz := uvp.CIEXYZ(test.xyz.Y); sqdist(xyz.vec(), test.xyz.vec()) > 1e-10 {
+			t.Errorf("u'v' to XYZ mismatch: %+v != %+v", xyz, test.xyz)
+		}
+		if back := uv.UVPrime(); back != uvp {
+			t.Errorf("uv to u'v' mismatch: %+v != %+v", back, uvp)
+		}
+	}
+	if got := (CIEXYZ{}).UVPrime(); got != (UVPrime{}) {
+		t.Errorf("black should have zero chromaticity, got %+v", got)
+	}
+}
+
+func TestMaxDeltaUV(t *testing.T) {
+	samples := []UVPrime{{U: 0.1978, V: 0.4683}, {U: 0.1998, V: 0.4683}, {U: 0.1978, V: 0.4713}}
+	want := math32.Hypot(0.002, 0.003)
+	if got := MaxDeltaUV(samples); math32.Abs(got-want) > 1e-6 {
+		t.Errorf("MaxDeltaUV = %v, want %v", got, want)
+	}
+	if got := MaxDeltaUV(samples[:1]); got != 0 {
+		t.Errorf("MaxDeltaUV of a single sample = %v, want 0", got)
+	}
+}
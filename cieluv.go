@@ -0,0 +1,305 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	d65 = IlluminantD65(1).vec()
+	// u'v' chromaticity of the D65 reference white.
+	d65u, d65v = 4 * d65.X / (d65.X + 15*d65.Y + 3*d65.Z), 9 * d65.Y / (d65.X + 15*d65.Y + 3*d65.Z)
+	// Rows of xyzToLinSRGB. Used to find the sRGB gamut boundary in CIELUV.
+	xyzToLinSRGBRows = [3]ms3.Vec{
+		{X: 12831. / 3959, Y: -329. / 214, Z: -1974. / 3959},
+		{X: -851781. / 878810, Y: 1648619. / 878810, Z: 36519. / 878810},
+		{X: 705. / 12673, Y: -2585. / 12673, Z: 705. / 667},
+	}
+)
+
+// CIELUV is the CIE 1976 L*, u*, v* color space. It was adopted alongside [CIELAB] as a
+// simple-to-compute transformation of [CIEXYZ] attempting perceptual uniformity. It is
+// widely used in lighting and display applications where additive mixtures of light are common.
+// Values are relative to the D65 reference white.
+type CIELUV struct {
+	L float32 // Perceptual lightness in [0,100].
+	U float32 // u* axis (unbounded). Varies red/green.
+	V float32 // v* axis (unbounded). Varies yellow/blue.
+}
+
+// CIELCHuv is the cylindrical representation of [CIELUV] color space, also known as HCL.
+type CIELCHuv struct {
+	L float32 // Perceptual lightness. Same as for [CIELUV].
+	C float32 // Chroma.
+	H float32 // Hue in degrees.
+}
+
+// HSLuv is a human-friendly alternative to [HSL] built on [CIELCHuv]. Saturation is
+// expressed as a percentage of the maximum chroma available in the sRGB gamut for the given
+// lightness and hue so that every HSLuv color with S in [0,100] is representable in sRGB.
+type HSLuv struct {
+	H float32 // Hue in degrees. Same as for [CIELCHuv].
+	S float32 // Saturation in [0,100].
+	L float32 // Lightness in [0,100]. Same as for [CIELUV].
+}
+
+// HPLuv is the pastel variant of [HSLuv]. Saturation is expressed as a percentage of the maximum chroma
+// available in the sRGB gamut for the given lightness at any hue, so colors of equal saturation and lightness
+// have equal chroma regardless of hue. The trade-off is that only pastel colors can be represented.
+type HPLuv struct {
+	H float32 // Hue in degrees. Same as for [CIELCHuv].
+	P float32 // Saturation in [0,100] relative to the chroma of the least saturated hue.
+	L float32 // Lightness in [0,100]. Same as for [CIELUV].
+}
+
+func (c CIELUV) vec() ms3.Vec        { return ms3.Vec{X: c.L, Y: c.U, Z: c.V} }
+func (c CIELCHuv) vec() ms3.Vec      { return ms3.Vec{X: c.L, Y: c.C, Z: c.H} }
+func (c HSLuv) vec() ms3.Vec         { return ms3.Vec{X: c.H, Y: c.S, Z: c.L} }
+func (c HPLuv) vec() ms3.Vec         { return ms3.Vec{X: c.H, Y: c.P, Z: c.L} }
+func (c CIELUV) Array() [3]float32   { return c.vec().Array() }
+func (c CIELCHuv) Array() [3]float32 { return c.vec().Array() }
+func (c HSLuv) Array() [3]float32    { return c.vec().Array() }
+func (c HPLuv) Array() [3]float32    { return c.vec().Array() }
+
+// LerpCIELUV interpolates in CIELUV. Useful for blending colors specified for lighting and displays.
+// Result is gamut mapped by reducing chroma at constant lightness and hue.
+func LerpCIELUV(c1, c2 color.Color, v float32) color.Color {
+	o1 := ColorToSRGB(c1).LSRGB().CIEXYZ().CIELUV()
+	o2 := ColorToSRGB(c2).LSRGB().CIEXYZ().CIELUV()
+	mapped := o1.Lerp(o2, v).CIELCHuv().GamutMappedLSRGB()
+	return mapped.CIELUV().CIEXYZ().LSRGB().ClipToGamut().SRGB()
+}
+
+// LerpCIELCHuv interpolates in CIELCHuv (lightness, chroma, hue) along the shortest hue arc.
+// Result is gamut mapped by reducing chroma at constant lightness and hue.
+func LerpCIELCHuv(c1, c2 color.Color, v float32) color.Color {
+	o1 := ColorToSRGB(c1).LSRGB().CIEXYZ().CIELUV().CIELCHuv()
+	o2 := ColorToSRGB(c2).LSRGB().CIEXYZ().CIELUV().CIELCHuv()
+	mapped := o1.Lerp(o2, v).GamutMappedLSRGB()
+	return mapped.CIELUV().CIEXYZ().LSRGB().ClipToGamut().SRGB()
+}
+
+// LerpHSLuv interpolates in HSLuv (hue, saturation, lightness).
+// Interpolates hue angles correctly and always yields colors inside the sRGB gamut.
+// Best for readable UI palettes where HSL interpolation yields uneven lightness.
+func LerpHSLuv(c1, c2 color.Color, v float32) color.Color {
+	o1 := ColorToSRGB(c1).LSRGB().CIEXYZ().CIELUV().CIELCHuv().HSLuv()
+	o2 := ColorToSRGB(c2).LSRGB().CIEXYZ().CIELUV().CIELCHuv().HSLuv()
+	return o1.Lerp(o2, v).CIELCHuv().CIELUV().CIEXYZ().LSRGB().ClipToGamut().SRGB()
+}
+
+// LerpHPLuv interpolates in HPLuv (hue, saturation, lightness). Like [LerpHSLuv] results are always
+// inside the sRGB gamut, with chroma independent of hue.
+func LerpHPLuv(c1, c2 color.Color, v float32) color.Color {
+	o1 := ColorToSRGB(c1).HPLuv()
+	o2 := ColorToSRGB(c2).HPLuv()
+	return o1.Lerp(o2, v).SRGB()
+}
+
+// HSLuv converts the sRGB color to HSLuv.
+func (c SRGB) HSLuv() HSLuv { return c.LSRGB().CIEXYZ().CIELUV().CIELCHuv().HSLuv() }
+
+// SRGB converts the HSLuv color to sRGB. Colors with saturation in [0,100] are always inside the sRGB gamut.
+func (c HSLuv) SRGB() SRGB { return c.CIELCHuv().CIELUV().CIEXYZ().LSRGB().ClipToGamut().SRGB() }
+
+// HPLuv converts the sRGB color to HPLuv. Saturated colors have P greater than 100.
+func (c SRGB) HPLuv() HPLuv { return c.LSRGB().CIEXYZ().CIELUV().CIELCHuv().HPLuv() }
+
+// SRGB converts the HPLuv color to sRGB. Colors with saturation in [0,100] are always inside the sRGB gamut.
+func (c HPLuv) SRGB() SRGB { return c.CIELCHuv().CIELUV().CIEXYZ().LSRGB().ClipToGamut().SRGB() }
+
+// CIELUV converts XYZ relative to the D65 white point to CIELUV.
+func (c CIEXYZ) CIELUV() CIELUV {
+	L := lstarFromY(c.Y / d65.Y)
+	denom := c.X + 15*c.Y + 3*c.Z
+	if denom == 0 || L == 0 {
+		return CIELUV{L: L}
+	}
+	u := 4 * c.X / denom
+	v := 9 * c.Y / denom
+	return CIELUV{
+		L: L,
+		U: 13 * L * (u - d65u),
+		V: 13 * L * (v - d65v),
+	}
+}
+
+// CIEXYZ converts CIELUV to XYZ relative to the D65 white point.
+func (c CIELUV) CIEXYZ() CIEXYZ {
+	if c.L <= 0 {
+		return CIEXYZ{}
+	}
+	y := yFromLstar(c.L) * d65.Y
+	u := c.U/(13*c.L) + d65u
+	v := c.V/(13*c.L) + d65v
+	return CIEXYZ{
+		X: y * 9 * u / (4 * v),
+		Y: y,
+		Z: y * (12 - 3*u - 20*v) / (4 * v),
+	}
+}
+
+// CIELCHuv converts CIELUV to its cylindrical representation. Achromatic colors have hue 0.
+func (c CIELUV) CIELCHuv() CIELCHuv {
+	const eps = 0.0015
+	chroma := math32.Sqrt(c.U*c.U + c.V*c.V)
+	hue := math32.Atan2(c.V, c.U) * 180 / math32.Pi
+	if hue < 0 {
+		hue += 360
+	}
+	if chroma <= eps {
+		hue = undefinedHue
+	}
+	return CIELCHuv{
+		L: c.L,
+		C: chroma,
+		H: hue,
+	}
+}
+
+// CIELUV converts CIELCHuv to its rectangular [CIELUV] representation.
+func (c CIELCHuv) CIELUV() CIELUV {
+	sin, cos := math32.Sincos(c.H * math32.Pi / 180)
+	return CIELUV{
+		L: c.L,
+		U: c.C * cos,
+		V: c.C * sin,
+	}
+}
+
+// HSLuv converts to HSLuv by expressing chroma as a percentage of the
+// maximum chroma representable in sRGB for the color's lightness and hue.
+func (c CIELCHuv) HSLuv() HSLuv {
+	if c.L > 100-epsUnit {
+		return HSLuv{H: c.H, S: 0, L: 100}
+	} else if c.L < epsUnit {
+		return HSLuv{H: c.H, S: 0, L: 0}
+	}
+	max := maxChromaLuv(c.L, c.H)
+	return HSLuv{H: c.H, S: 100 * c.C / max, L: c.L}
+}
+
+// CIELCHuv converts HSLuv to its [CIELCHuv] representation.
+func (c HSLuv) CIELCHuv() CIELCHuv {
+	if c.L > 100-epsUnit {
+		return CIELCHuv{L: 100, C: 0, H: c.H}
+	} else if c.L < epsUnit {
+		return CIELCHuv{L: 0, C: 0, H: c.H}
+	}
+	max := maxChromaLuv(c.L, c.H)
+	return CIELCHuv{L: c.L, C: max * c.S / 100, H: c.H}
+}
+
+// HPLuv converts to HPLuv by expressing chroma as a percentage of the
+// maximum chroma representable in sRGB for the color's lightness at any hue.
+func (c CIELCHuv) HPLuv() HPLuv {
+	if c.L > 100-epsUnit {
+		return HPLuv{H: c.H, P: 0, L: 100}
+	} else if c.L < epsUnit {
+		return HPLuv{H: c.H, P: 0, L: 0}
+	}
+	return HPLuv{H: c.H, P: 100 * c.C / maxSafeChromaLuv(c.L), L: c.L}
+}
+
+// CIELCHuv converts HPLuv to its [CIELCHuv] representation.
+func (c HPLuv) CIELCHuv() CIELCHuv {
+	if c.L > 100-epsUnit {
+		return CIELCHuv{L: 100, C: 0, H: c.H}
+	} else if c.L < epsUnit {
+		return CIELCHuv{L: 0, C: 0, H: c.H}
+	}
+	return CIELCHuv{L: c.L, C: maxSafeChromaLuv(c.L) * c.P / 100, H: c.H}
+}
+
+// GamutMappedLSRGB maps the CIELCHuv color into the sRGB gamut.
+//
+// Unlike OKLCH, the sRGB gamut boundary is exactly solvable in CIELUV so
+// chroma is clamped to the maximum representable for the color's lightness and hue.
+func (c CIELCHuv) GamutMappedLSRGB() CIELCHuv {
+	if c.L < 0 || c.L > 100 {
+		return CIELCHuv{L: ms1.Clamp(c.L, 0, 100), C: 0, H: 0}
+	}
+	max := maxChromaLuv(c.L, c.H)
+	if c.C > max {
+		c.C = max
+	}
+	return c
+}
+
+// boundsLuv returns the six lines (slope, intercept) in the u*v* plane
+// delimiting the sRGB gamut at lightness L.
+func boundsLuv(L float32) (bounds [6][2]float32) {
+	sub2 := yFromLstar(L)
+	for i, row := range xyzToLinSRGBRows {
+		m1, m2, m3 := row.X, row.Y, row.Z
+		for t := 0; t < 2; t++ {
+			ft := float32(t)
+			top1 := (284517*m1 - 94839*m3) * sub2
+			top2 := (838422*m3+769860*m2+731718*m1)*L*sub2 - 769860*ft*L
+			bottom := (632260*m3-126452*m2)*sub2 + 126452*ft
+			bounds[2*i+t] = [2]float32{top1 / bottom, top2 / bottom}
+		}
+	}
+	return bounds
+}
+
+// maxChromaLuv returns the maximum CIELUV chroma representable in sRGB for a given lightness and hue.
+func maxChromaLuv(L, H float32) float32 {
+	sin, cos := math32.Sincos(H * math32.Pi / 180)
+	min := float32(math32.MaxFloat32)
+	for _, line := range boundsLuv(L) {
+		length := line[1] / (sin - line[0]*cos)
+		if length >= 0 && length < min {
+			min = length
+		}
+	}
+	return min
+}
+
+// maxSafeChromaLuv returns the maximum CIELUV chroma representable in sRGB for a given lightness at all hues:
+// the distance from the achromatic axis to the closest gamut boundary line.
+func maxSafeChromaLuv(L float32) float32 {
+	min := float32(math32.MaxFloat32)
+	for _, line := range boundsLuv(L) {
+		m, b := line[0], line[1]
+		if d := math32.Abs(b) / math32.Sqrt(m*m+1); d < min {
+			min = d
+		}
+	}
+	return min
+}
+
+// DeltaE returns the CIE 1976 color difference ΔE*uv: the Euclidean distance in CIELUV.
+func (reference CIELUV) DeltaE(sample CIELUV) float32 {
+	e := ms3.Sub(reference.vec(), sample.vec())
+	return math32.Sqrt(ms3.Dot(e, e))
+}
+
+// Lerp linearly interpolates between two CIELUV colors.
+func (from CIELUV) Lerp(to CIELUV, v float32) CIELUV {
+	return CIELUV{
+		L: ms1.Interp(from.L, to.L, v),
+		U: ms1.Interp(from.U, to.U, v),
+		V: ms1.Interp(from.V, to.V, v),
+	}
+}
+
+// Lerp interpolates between two CIELCHuv colors along the shortest hue arc.
+func (from CIELCHuv) Lerp(to CIELCHuv, v float32) CIELCHuv {
+	return CIELCHuv(CIELCH(from).Lerp(CIELCH(to), v))
+}
+
+// Lerp interpolates between two HSLuv colors along the shortest hue arc.
+func (from HSLuv) Lerp(to HSLuv, v float32) HSLuv {
+	lch := CIELCH{L: from.L, C: from.S, H: from.H}.Lerp(CIELCH{L: to.L, C: to.S, H: to.H}, v)
+	return HSLuv{H: lch.H, S: lch.C, L: lch.L}
+}
+
+// Lerp interpolates between two HPLuv colors along the shortest hue arc.
+func (from HPLuv) Lerp(to HPLuv, v float32) HPLuv {
+	lch := CIELCH{L: from.L, C: from.P, H: from.H}.Lerp(CIELCH{L: to.L, C: to.P, H: to.H}, v)
+	return HPLuv{H: lch.H, P: lch.C, L: lch.L}
+}
@@ -0,0 +1,81 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCIELUV(t *testing.T) {
+	red := SRGB{R: 1, G: 0, B: 0}.LSRGB().CIEXYZ().CIELUV()
+	want := CIELUV{L: 53.2408, U: 175.0151, V: 37.7564}
+	if d := math32.Sqrt(sqdist(red.vec(), want.vec())); d > 0.05 {
+		t.Errorf("luv for red mismatch, want %v, got %v", want, red)
+	}
+	hsluv := red.CIELCHuv().HSLuv()
+	if math32.Abs(hsluv.S-100) > 0.01 {
+		t.Errorf("expected red to be fully saturated in HSLuv, got %v", hsluv)
+	}
+	for _, c := range jet {
+		srgb := ColorToSRGB(c)
+		got := srgb.LSRGB().CIEXYZ().CIELUV().CIELCHuv().HSLuv().CIELCHuv().CIELUV().CIEXYZ().LSRGB().SRGB()
+		if d := sqdist(srgb.vec(), got.vec()); d > 1e-6 {
+			t.Errorf("HSLuv round trip mismatch, want %v, got %v", srgb, got)
+		}
+	}
+}
+
+func TestCIELUVDeltaE(t *testing.T) {
+	a := CIELUV{L: 50, U: 10, V: -20}
+	b := CIELUV{L: 53, U: 14, V: -20}
+	if d := a.DeltaE(b); math32.Abs(d-5) > 1e-5 {
+		t.Errorf("ΔE*uv got %v, want 5", d)
+	}
+	white := IlluminantD65(1).UVPrime()
+	if d := white.DeltaUV(CIExy{X: 0.3457, Y: 0.3585}.UVPrime()); math32.Abs(d-0.0228) > 5e-4 {
+		t.Errorf("Δu′v′ between D65 and D50 got %v, want 0.0228", d)
+	}
+	// LCHuv interpolation between red and green passes through hues between theirs.
+	red, green := SRGB{R: 1}, SRGB{G: 1}
+	lch := func(c color.Color) CIELCHuv { return ColorToSRGB(c).LSRGB().CIEXYZ().CIELUV().CIELCHuv() }
+	mid, h0, h1 := lch(LerpCIELCHuv(red, green, 0.5)), lch(red).H, lch(green).H
+	if mid.H <= h0 || mid.H >= h1 {
+		t.Errorf("LCHuv midpoint hue %v not between %v and %v", mid.H, h0, h1)
+	}
+}
+
+func TestHPLuv(t *testing.T) {
+	for _, c := range jet {
+		srgb := ColorToSRGB(c)
+		if got := srgb.HPLuv().SRGB(); sqdist(srgb.vec(), got.vec()) > 1e-6 {
+			t.Errorf("HPLuv round trip mismatch, want %v, got %v", srgb, got)
+		}
+		if got := srgb.HSLuv().SRGB(); sqdist(srgb.vec(), got.vec()) > 1e-6 {
+			t.Errorf("HSLuv round trip mismatch, want %v, got %v", srgb, got)
+		}
+	}
+	// Full HPLuv saturation is in gamut and has the same chroma for all hues.
+	for _, L := range []float32{10, 50, 90} {
+		var chroma float32
+		for h := float32(0); h < 360; h += 15 {
+			c := HPLuv{H: h, P: 100, L: L}
+			lin := c.CIELCHuv().CIELUV().CIEXYZ().LSRGB()
+			if !lin.ClipToGamut().InGamut() || sqdist(lin.vec(), lin.ClipToGamut().vec()) > 1e-6 {
+				t.Errorf("%+v out of sRGB gamut: %+v", c, lin)
+			}
+			got := c.SRGB().LSRGB().CIEXYZ().CIELUV().CIELCHuv().C
+			if h > 0 && math32.Abs(got-chroma) > 0.05 {
+				t.Errorf("%+v chroma %v differs from hue 0 chroma %v", c, got, chroma)
+			}
+			chroma = got
+		}
+		// HPLuv chroma never exceeds HSLuv chroma for the same saturation.
+		if hs := (HSLuv{H: 40, S: 100, L: L}).CIELCHuv().C; hs < chroma {
+			t.Errorf("HSLuv chroma %v less than HPLuv chroma %v", hs, chroma)
+		}
+	}
+	if got := ColorToSRGB(LerpHPLuv(SRGB{R: 0.8, G: 0.6, B: 0.6}, SRGB{R: 0.6, G: 0.6, B: 0.8}, 0.5)); !got.LSRGB().InGamut() {
+		t.Errorf("HPLuv lerp out of gamut %v", got)
+	}
+}
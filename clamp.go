@@ -0,0 +1,93 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+)
+
+// Clamp restricts c to the valid OKLab domain: L in [0,1]. The unbounded A and B axes are kept.
+func (c OKLAB) Clamp() OKLAB {
+	return OKLAB{L: ms1.Clamp(c.L, 0, 1), A: c.A, B: c.B}
+}
+
+// Clamp restricts c to the valid CIELAB domain: L in [0,100]. The unbounded A and B axes are kept.
+func (c CIELAB) Clamp() CIELAB {
+	return CIELAB{L: ms1.Clamp(c.L, 0, 100), A: c.A, B: c.B}
+}
+
+// Normalize returns the canonical representation of the same color: negative chroma is made positive
+// by rotating the hue 180 degrees, the hue is wrapped to [0,360) and a NaN or infinite hue is set to zero.
+func (c OKLCH) Normalize() OKLCH {
+	c.C, c.H = normalizeChromaHue(c.C, c.H)
+	return c
+}
+
+// Clamp normalizes c and restricts lightness to [0,1]. See [OKLCH.Normalize].
+func (c OKLCH) Clamp() OKLCH {
+	c = c.Normalize()
+	c.L = ms1.Clamp(c.L, 0, 1)
+	return c
+}
+
+// Normalize returns the canonical representation of the same color. See [OKLCH.Normalize].
+func (c CIELCH) Normalize() CIELCH {
+	c.C, c.H = normalizeChromaHue(c.C, c.H)
+	return c
+}
+
+// Clamp normalizes c and restricts lightness to [0,100]. See [CIELCH.Normalize].
+func (c CIELCH) Clamp() CIELCH {
+	c = c.Normalize()
+	c.L = ms1.Clamp(c.L, 0, 100)
+	return c
+}
+
+// Normalize wraps the hue of c to [0,360). A NaN or infinite hue is set to zero.
+func (c HSV) Normalize() HSV {
+	c.H = normalizeHue(c.H)
+	return c
+}
+
+// Clamp normalizes c and restricts saturation and value to [0,1].
+func (c HSV) Clamp() HSV {
+	return HSV{H: normalizeHue(c.H), S: clamp01(c.S), V: clamp01(c.V)}
+}
+
+// Normalize wraps the hue of c to [0,360). A NaN or infinite hue is set to zero.
+func (c HSL) Normalize() HSL {
+	c.H = normalizeHue(c.H)
+	return c
+}
+
+// Clamp normalizes c and restricts saturation and lightness to [0,1].
+func (c HSL) Clamp() HSL {
+	return HSL{H: normalizeHue(c.H), S: clamp01(c.S), L: clamp01(c.L)}
+}
+
+func normalizeChromaHue(chroma, hue float32) (float32, float32) {
+	if chroma < 0 {
+		chroma = -chroma
+		hue += 180
+	}
+	return chroma, normalizeHue(hue)
+}
+
+func normalizeHue(h float32) float32 {
+	if math32.IsNaN(h) || math32.IsInf(h, 0) {
+		return undefinedHue
+	}
+	// Avoid wrapHue's loop for far out of range values.
+	h = math32.Mod(h, 360)
+	return wrapHue(h)
+}
+
+// hueDelta returns the signed shortest angle in degrees from hue h1 to h2.
+func hueDelta(h1, h2 float32) float32 {
+	d := math32.Mod(h2-h1, 360)
+	if d > 180 {
+		d -= 360
+	} else if d < -180 {
+		d += 360
+	}
+	return d
+}
@@ -0,0 +1,32 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestClampNormalize(t *testing.T) {
+	lch := OKLCH{L: 1.2, C: -0.1, H: 400}.Clamp()
+	if lch != (OKLCH{L: 1, C: 0.1, H: 220}) {
+		t.Errorf("unexpected clamped OKLCH %+v", lch)
+	}
+	// Normalization must not change the color.
+	orig := OKLCH{L: 0.6, C: -0.08, H: -30}
+	a, b := orig.OKLAB(), orig.Normalize().OKLAB()
+	if math32.Abs(a.A-b.A) > 1e-6 || math32.Abs(a.B-b.B) > 1e-6 {
+		t.Errorf("normalization changed color: %+v != %+v", a, b)
+	}
+	if got := (CIELCH{L: -5, C: 20, H: math32.NaN()}).Clamp(); got != (CIELCH{L: 0, C: 20, H: 0}) {
+		t.Errorf("unexpected clamped CIELCH %+v", got)
+	}
+	if got := (CIELAB{L: 120, A: -200, B: 3}).Clamp(); got != (CIELAB{L: 100, A: -200, B: 3}) {
+		t.Errorf("unexpected clamped CIELAB %+v", got)
+	}
+	if got := (HSV{H: -90, S: 2, V: -1}).Clamp(); got != (HSV{H: 270, S: 1, V: 0}) {
+		t.Errorf("unexpected clamped HSV %+v", got)
+	}
+	if got := (HSL{H: 1e6, S: 0.5, L: 0.5}).Normalize(); got.H < 0 || got.H >= 360 {
+		t.Errorf("hue not wrapped: %v", got.H)
+	}
+}
@@ -0,0 +1,81 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+)
+
+// CMYK is a subtractive cyan, magenta, yellow and black ink coverage in [0,1] per channel.
+// Conversions use the naive device model where each ink multiplies the reflected sRGB light,
+// which ignores the dot gain and ink overlap of real presses. Use it to approximate
+// separations; for proofing use an ICC profile of the press.
+type CMYK struct {
+	C, M, Y, K float32
+}
+
+// Array returns the C, M, Y, K channels.
+func (c CMYK) Array() [4]float32 { return [4]float32{c.C, c.M, c.Y, c.K} }
+
+// TotalInk returns the sum of the channels, also known as total area coverage. 4 is 400%.
+func (c CMYK) TotalInk() float32 { return c.C + c.M + c.Y + c.K }
+
+// CMYK converts the sRGB color to CMYK with full gray component replacement: black ink replaces
+// all of the gray component so at least one of C, M or Y is zero. See [Separation] for finer control.
+func (c SRGB) CMYK() CMYK {
+	return Separation{BlackAmount: 1, UnderColorRemoval: 1}.CMYK(c)
+}
+
+// SRGB converts the CMYK color to sRGB using the naive multiplicative model R=(1-C)(1-K).
+func (c CMYK) SRGB() SRGB {
+	k := 1 - clamp01(c.K)
+	return SRGB{
+		R: (1 - clamp01(c.C)) * k,
+		G: (1 - clamp01(c.M)) * k,
+		B: (1 - clamp01(c.Y)) * k,
+	}
+}
+
+// Separation configures how RGB colors are separated into CMYK inks. The gray component of a color is
+// the ink common to cyan, magenta and yellow, which can be printed with black ink instead to save ink and
+// improve neutral stability. The zero value prints without black ink.
+type Separation struct {
+	// BlackStart in [0,1) is the gray component at which black generation begins. Light colors
+	// below it are printed with cyan, magenta and yellow only, avoiding visible black dots in highlights.
+	BlackStart float32
+	// BlackAmount in [0,1] is the black ink generated for a full gray component. Black increases
+	// linearly from BlackStart up to BlackAmount. 1 is full black generation.
+	BlackAmount float32
+	// UnderColorRemoval in [0,1] is the fraction of cyan, magenta and yellow compensating for the generated
+	// black that is removed. At 1 the separation reproduces the input color in the naive model;
+	// lower values print more ink for denser shadows.
+	UnderColorRemoval float32
+	// TotalInk is the maximum sum of the channels, i.e: 3 for 300% coverage. When exceeded cyan, magenta
+	// and yellow are scaled down to fit. Zero means no limit.
+	TotalInk float32
+}
+
+// CMYK separates the sRGB color c into inks.
+func (s Separation) CMYK(c SRGB) CMYK {
+	c = c.ClipToGamut()
+	cmy := [3]float32{1 - c.R, 1 - c.G, 1 - c.B}
+	gray := math32.Min(cmy[0], math32.Min(cmy[1], cmy[2]))
+	var k float32
+	if start := clamp01(s.BlackStart); gray > start && start < 1 {
+		k = clamp01(s.BlackAmount) * (gray - start) / (1 - start)
+	}
+	ucr := clamp01(s.UnderColorRemoval)
+	for i, v := range cmy {
+		exact := float32(0) // Pure black.
+		if k < 1 {
+			exact = (v - k) / (1 - k)
+		}
+		cmy[i] = clamp01(v + ucr*(exact-v))
+	}
+	out := CMYK{C: cmy[0], M: cmy[1], Y: cmy[2], K: k}
+	if sum := cmy[0] + cmy[1] + cmy[2]; s.TotalInk > 0 && sum > 0 && sum+k > s.TotalInk {
+		f := math32.Max(s.TotalInk-k, 0) / sum
+		out.C *= f
+		out.M *= f
+		out.Y *= f
+	}
+	return out
+}
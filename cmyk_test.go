@@ -0,0 +1,67 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCMYK(t *testing.T) {
+	tests := []struct {
+		c    SRGB
+		want CMYK
+	}{
+		{c: SRGB{R: 1}, want: CMYK{M: 1, Y: 1}},
+		{c: SRGB{G: 1, B: 1}, want: CMYK{C: 1}},
+		{c: SRGB{R: 1, G: 1, B: 1}, want: CMYK{}},
+		{c: SRGB{}, want: CMYK{K: 1}},
+		{c: SRGB{R: 0.5, G: 0.25, B: 0.5}, want: CMYK{C: 0, M: 0.5, Y: 0, K: 0.5}},
+	}
+	for _, test := range tests {
+		got := test.c.CMYK()
+		for i, v := range got.Array() {
+			if math32.Abs(v-test.want.Array()[i]) > 1e-6 {
+				t.Errorf("%+v.CMYK() = %+v, want %+v", test.c, got, test.want)
+				break
+			}
+		}
+		if back := got.SRGB(); sqdist(back.vec(), test.c.vec()) > 1e-10 {
+			t.Errorf("CMYK round trip of %+v gave %+v", test.c, back)
+		}
+	}
+}
+
+func TestSeparation(t *testing.T) {
+	c := SRGB{R: 0.2, G: 0.3, B: 0.25}
+	// Full under color removal reproduces the color for any black generation.
+	for _, s := range []Separation{
+		{UnderColorRemoval: 1},
+		{BlackStart: 0.3, BlackAmount: 0.8, UnderColorRemoval: 1},
+		{BlackAmount: 1, UnderColorRemoval: 1},
+	} {
+		if got := s.CMYK(c).SRGB(); sqdist(got.vec(), c.vec()) > 1e-10 {
+			t.Errorf("%+v separation of %+v reproduced as %+v", s, c, got)
+		}
+	}
+	// The zero value prints no black.
+	if got := (Separation{}).CMYK(c); got.K != 0 || math32.Abs(got.C-0.8) > 1e-6 {
+		t.Errorf("zero Separation gave %+v", got)
+	}
+	// Highlights below BlackStart get no black.
+	light := SRGB{R: 0.9, G: 0.95, B: 0.85}
+	if got := (Separation{BlackStart: 0.2, BlackAmount: 1, UnderColorRemoval: 1}).CMYK(light); got.K != 0 {
+		t.Errorf("highlight got black %v", got.K)
+	}
+	// Less under color removal prints more ink.
+	s := Separation{BlackAmount: 0.9, UnderColorRemoval: 0.5}
+	full := s
+	full.UnderColorRemoval = 1
+	if s.CMYK(c).TotalInk() <= full.CMYK(c).TotalInk() {
+		t.Errorf("partial UCR did not increase ink")
+	}
+	// Total ink limit.
+	s.TotalInk = 2.8
+	if got := s.CMYK(SRGB{R: 0.02, G: 0.03, B: 0.01}); got.TotalInk() > 2.8+1e-5 {
+		t.Errorf("total ink %v exceeds limit", got.TotalInk())
+	}
+}
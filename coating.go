@@ -0,0 +1,40 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// CoatingContrastRatio returns the contrast ratio (hiding power) of a paint or coating film
+// in [0,1] given its color measured over the black and white areas of a hiding chart (ISO 6504-3).
+// It is Y over black divided by Y over white, often quoted as a percentage. A film that
+// completely hides the substrate has a contrast ratio of 1.
+func CoatingContrastRatio(overBlack, overWhite CIEXYZ) float32 {
+	if overWhite.Y <= 0 {
+		return 0
+	}
+	return math32.Min(overBlack.Y/overWhite.Y, 1)
+}
+
+// CoatingOpacity returns the opacity of a film in [0,1], defined as its reflectance over black
+// divided by the reflectance of an infinitely thick layer of the same film (R0/R∞, TAPPI T425).
+// R∞ is estimated with the Kubelka formula from the film measured over black and over white and the
+// luminance factor Y of the white substrate itself, substrateY. All Y values are relative to a perfect
+// reflector with Y=1.
+func CoatingOpacity(overBlack, overWhite CIEXYZ, substrateY float32) float32 {
+	r0, rw, rg := overBlack.Y, overWhite.Y, substrateY
+	if r0 <= 0 || rg <= 0 {
+		return 0
+	}
+	rinf := reflectanceInfinity(r0, rw, rg)
+	if rinf <= 0 {
+		return 0
+	}
+	return math32.Min(r0/rinf, 1)
+}
+
+// reflectanceInfinity returns R∞ of a film of reflectance r0 over black and rw over a substrate of reflectance rg.
+func reflectanceInfinity(r0, rw, rg float32) float32 {
+	a := 0.5 * (rw + (r0-rw+rg)/(r0*rg))
+	if a <= 1 {
+		return 1
+	}
+	return a - math32.Sqrt(a*a-1)
+}
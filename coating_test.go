@@ -0,0 +1,32 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCoatingOpacity(t *testing.T) {
+	const rinf, substrate = 0.6, 0.85
+	// Kubelka-Munk reflectance of a film with scattering power sx over a background rg.
+	film := func(sx, rg float32) CIEXYZ {
+		e := math32.Exp(sx * (1/rinf - rinf))
+		r := ((rg-rinf)/rinf - rinf*(rg-1/rinf)*e) / ((rg - rinf) - (rg-1/rinf)*e)
+		return CIEXYZ{Y: r}
+	}
+	for _, sx := range []float32{0.2, 1, 4} {
+		black, white := film(sx, 0), film(sx, substrate)
+		got := CoatingOpacity(black, white, substrate)
+		want := black.Y / rinf
+		if math32.Abs(got-want) > 1e-3 {
+			t.Errorf("sx=%v: got opacity %v, want %v", sx, got, want)
+		}
+		cr := CoatingContrastRatio(black, white)
+		if cr <= 0 || cr > 1 || cr > got+1e-3 {
+			t.Errorf("sx=%v: unexpected contrast ratio %v for opacity %v", sx, cr, got)
+		}
+	}
+	if cr := CoatingContrastRatio(CIEXYZ{Y: 0.6}, CIEXYZ{Y: 0.6}); cr != 1 {
+		t.Errorf("fully hiding film should have contrast ratio 1, got %v", cr)
+	}
+}
@@ -53,13 +53,16 @@ var (
 		-851781. / 878810, 1648619. / 878810, 36519. / 878810,
 		705. / 12673, -2585. / 12673, 705. / 667,
 	})
-	d65Tod50 = ms3.NewMat3([]float32{1.0479297925449969, 0.022946870601609652, -0.05019226628920524,
-		0.02962780877005599, 0.9904344267538799, -0.017073799063418826,
-		-0.009243040646204504, 0.015055191490298152, 0.7518742814281371})
-	d50Tod65 = ms3.NewMat3([]float32{0.955473421488075, -0.02309845494876471, 0.06325924320057072,
-		-0.0283697093338637, 1.0099953980813041, 0.021041441191917323,
-		0.012314014864481998, -0.020507649298898964, 1.330365926242124})
-	xyzToLMS = ms3.NewMat3([]float32{0.8190224379967030, 0.3619062600528904, -0.1288737815209879,
+	// Bradford cone response matrix used for chromatic adaptation.
+	xyzToBradford = ms3.NewMat3([]float32{
+		0.8951, 0.2664, -0.1614,
+		-0.7502, 1.7135, 0.0367,
+		0.0389, -0.0685, 1.0296,
+	})
+	bradfordToXYZ = xyzToBradford.Inverse()
+	d65Tod50      = bradfordMatrix(d65, d50)
+	d50Tod65      = bradfordMatrix(d50, d65)
+	xyzToLMS      = ms3.NewMat3([]float32{0.8190224379967030, 0.3619062600528904, -0.1288737815209879,
 		0.0329836539323885, 0.9292868615863434, 0.0361446663506424,
 		0.0481771893596242, 0.2642395317527308, 0.6335478284694309})
 	lmsToOKLAB = ms3.NewMat3([]float32{0.2104542683093140, 0.7936177747023054, -0.0040720430116193,
@@ -76,7 +79,7 @@ var (
 // OKLAB is a uniform color space for device independent coloring designed to improve preceptual uniformity,
 // hue and lightness prediction, color blending and usability regarding numerical stability.
 type OKLAB struct {
-	// Preceptual lightness. See [LAB]
+	// Preceptual lightness in [0,1], unlike [CIELAB] which uses [0,100]. See [OKLAB.Lightness].
 	L float32
 	// A and B for opposite channels of the four unique hues. unbounded but in practice ranging from -0.5 to 0.5.
 	// CSS assigns ±100% to ±0.4 for both.
@@ -86,14 +89,14 @@ type OKLAB struct {
 
 // OKLCH is cylindrical representation of [OKLAB] color space.
 type OKLCH struct {
-	L float32 // Perceptual luminosity. Same as for [OKLAB].
+	L float32 // Perceptual luminosity in [0,1]. Same as for [OKLAB].
 	C float32 // Chroma. Defines intensity of hue.
 	H float32 // Hue in degrees.
 }
 
 // CIELCH is the cylindiracl hue color space representation of CIELAB.
 type CIELCH struct {
-	L float32 // Perceptual luminosity. Same as for [CIELAB].
+	L float32 // Perceptual luminosity in [0,100]. Same as for [CIELAB].
 	C float32 // chroma.
 	H float32 // hue.
 }
@@ -103,7 +106,7 @@ type CIELCH struct {
 // Since a* and b* axes are unbounded a correct CIELAB color may not be representable in sRGB gamut.
 type CIELAB struct {
 	// L* (L-star) Perceptual Lightness calcuilated using the cube root of relative luminance with an offset near black.
-	// Defines black at 0 and white at 1.
+	// Defines black at 0 and white at 100, unlike [OKLAB] which uses [0,1]. See [CIELAB.Lightness].
 	L float32
 	// a* axis (unbounded) Varies greenish appearance.
 	A float32
@@ -219,6 +222,7 @@ func LerpOKLCH(c1, c2 color.Color, v float32) color.Color {
 }
 
 // ColorToSRGB converts the color to [SRGB] discarding the opacity/alpha (A) field.
+// Use [ColorToSRGBA] to preserve alpha.
 func ColorToSRGB(c color.Color) SRGB {
 	r, g, b, _ := c.RGBA()
 	return SRGB{
@@ -228,6 +232,26 @@ func ColorToSRGB(c color.Color) SRGB {
 	}
 }
 
+// colorToOKLAB converts the color to [OKLAB] discarding the opacity/alpha (A) field.
+func colorToOKLAB(c color.Color) OKLAB {
+	return ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB()
+}
+
+// colorToOKLCH converts the color to [OKLCH] discarding the opacity/alpha (A) field.
+func colorToOKLCH(c color.Color) OKLCH {
+	return ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB().OKLCH()
+}
+
+// colorToCIELAB converts the color to [CIELAB] relative to the D50 white point discarding the opacity/alpha (A) field.
+func colorToCIELAB(c color.Color) CIELAB {
+	return ColorToSRGB(c).LSRGB().CIEXYZ().d65ToD50().CIELAB()
+}
+
+// oklchToSRGB maps the OKLCH color into the sRGB gamut and converts it to [SRGB].
+func oklchToSRGB(c OKLCH) SRGB {
+	return c.GamutMappedLSRGB().OKLAB().CIEXYZ().LSRGB().ClipToGamut().SRGB()
+}
+
 // transferFunc is the gamma function.
 func transferFunc(v float32) float32 {
 	sign := math32.Copysign(1, v)
@@ -444,6 +468,34 @@ func (c SRGB) RGBA() (r, g, b, a uint32) {
 	return r, g, b, 0xffff
 }
 
+// RGB8ToSRGB converts 8-bit per channel gamma-encoded sRGB values, as found in
+// [color.RGBA] and [color.NRGBA], to [SRGB].
+func RGB8ToSRGB(r, g, b uint8) SRGB {
+	return SRGB{
+		R: float32(r) / 0xff,
+		G: float32(g) / 0xff,
+		B: float32(b) / 0xff,
+	}
+}
+
+// RGB8 returns the color as 8-bit per channel values rounded to nearest.
+// Channels are clamped to [0,1] before conversion so out of gamut values do not overflow.
+func (c SRGB) RGB8() (r, g, b uint8) {
+	c = c.ClipToGamut()
+	// Add 0.5 to round to nearest.
+	r = uint8(c.R*0xff + 0.5)
+	g = uint8(c.G*0xff + 0.5)
+	b = uint8(c.B*0xff + 0.5)
+	return r, g, b
+}
+
+// RGBA8 returns the color as an opaque [color.RGBA]. Since the color is opaque
+// the result may also be used as a [color.NRGBA] by converting the type.
+func (c SRGB) RGBA8() color.RGBA {
+	r, g, b := c.RGB8()
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}
+}
+
 func (c CIEXYZ) OKLAB() OKLAB {
 	lms := ms3.MulMatVec(xyzToLMS, c.vec())
 
@@ -586,28 +638,76 @@ func (c OKLCH) OKLAB() OKLAB {
 	}
 }
 
+// bradfordMatrix returns the XYZ to XYZ Bradford chromatic adaptation matrix from srcWhite to dstWhite.
+func bradfordMatrix(srcWhite, dstWhite ms3.Vec) ms3.Mat3 {
+	src := ms3.MulMatVec(xyzToBradford, srcWhite)
+	dst := ms3.MulMatVec(xyzToBradford, dstWhite)
+	scale := ms3.NewMat3([]float32{
+		dst.X / src.X, 0, 0,
+		0, dst.Y / src.Y, 0,
+		0, 0, dst.Z / src.Z,
+	})
+	return ms3.MulMat3(bradfordToXYZ, ms3.MulMat3(scale, xyzToBradford))
+}
+
+// d65ToD50 chromatically adapts XYZ relative to D65 white to D50 white using the Bradford transform.
+func (c CIEXYZ) d65ToD50() CIEXYZ {
+	v := ms3.MulMatVec(d65Tod50, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// d50ToD65 chromatically adapts XYZ relative to D50 white to D65 white using the Bradford transform.
+func (c CIEXYZ) d50ToD65() CIEXYZ {
+	v := ms3.MulMatVec(d50Tod65, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
 func (c CIEXYZ) CIELAB() CIELAB {
 	// Assuming XYZ is relative to D50, convert to CIE Lab
-	// from CIE standard, which now defines these as a rational fraction
-	const (
-		ε = 216. / 24389 // 6^3/29^3
-		κ = 24389. / 27  // 29^3/3^3
-	)
+	return c.cielab(d50)
+}
+
+// cielab converts XYZ to CIE Lab relative to an arbitrary reference white.
+func (c CIEXYZ) cielab(white ms3.Vec) CIELAB {
 	// compute xyz, which is XYZ scaled relative to reference white
-	xyz := ms3.DivElem(c.vec(), d50)
-	f := func(x float32) float32 {
-		if x > ε {
-			return math32.Cbrt(x)
-		}
-		return (κ*x + 16) / 116
-	}
+	xyz := ms3.DivElem(c.vec(), white)
+	fx, fy, fz := labF(xyz.X), labF(xyz.Y), labF(xyz.Z)
 	return CIELAB{
-		L: 116*f(xyz.Y) - 16,
-		A: 500 * (f(xyz.X) - f(xyz.Y)),
-		B: 200 * (f(xyz.Y) - f(xyz.Z)),
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+// from CIE standard, which now defines these as a rational fraction
+const (
+	labEpsilon = 216. / 24389 // 6^3/29^3
+	labKappa   = 24389. / 27  // 29^3/3^3
+)
+
+// labF is the CIELAB nonlinearity applied to a tristimulus value relative to the reference white.
+func labF(t float32) float32 {
+	if t > labEpsilon {
+		return math32.Cbrt(t)
+	}
+	return (labKappa*t + 16) / 116
+}
+
+// labFInv is the inverse of labF.
+func labFInv(f float32) float32 {
+	const ecbrt = 6. / 29
+	if f > ecbrt {
+		return f * f * f
 	}
+	return (116*f - 16) / labKappa
 }
 
+// lstarFromY returns the CIELAB lightness L* of the relative luminance y.
+func lstarFromY(y float32) float32 { return 116*labF(y) - 16 }
+
+// yFromLstar returns the relative luminance of the CIELAB lightness L*.
+func yFromLstar(l float32) float32 { return labFInv((l + 16) / 116) }
+
 func (c CIELAB) CIELCH() CIELCH {
 	const eps = 0.0015
 	chroma := math32.Sqrt(c.A*c.A + c.B*c.B)
@@ -626,32 +726,15 @@ func (c CIELAB) CIELCH() CIELCH {
 }
 
 func (c CIELAB) CIEXYZ() CIEXYZ {
-	const κ = 24389. / 27  // 29^3/3^3
-	const ε = 216. / 24389 // 6^3/29^3
-	const ecbrt = 6. / 29
-	f1 := (c.L + 16) / 116
-	f0 := c.A/500 + f1
-	f2 := f1 - c.B/200
+	return c.ciexyz(d50)
+}
 
-	var xyz CIEXYZ
-	if f0 > ecbrt {
-		xyz.X = f0 * f0 * f0
-	} else {
-		xyz.X = (116*f0 - 16) / κ
-	}
-	if c.L > κ*ε {
-		ycbrt := (c.L + 16) / 116
-		xyz.Y = ycbrt * ycbrt * ycbrt
-	} else {
-		xyz.Y = (116*c.L - 16) / κ
-	}
-	if f2 > ecbrt {
-		xyz.Z = f2 * f2 * f2
-	} else {
-		xyz.Z = (116*f2 - 16) / κ
-	}
+// ciexyz converts CIE Lab relative to an arbitrary reference white to XYZ.
+func (c CIELAB) ciexyz(white ms3.Vec) CIEXYZ {
+	fy := (c.L + 16) / 116
+	xyz := ms3.Vec{X: labFInv(c.A/500 + fy), Y: yFromLstar(c.L), Z: labFInv(fy - c.B/200)}
 	// Compute XYZ by scaling xyz by reference white
-	v := ms3.MulElem(xyz.vec(), d50)
+	v := ms3.MulElem(xyz, white)
 	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
 }
 
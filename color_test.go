@@ -4,6 +4,10 @@ import (
 	"image/color"
 	"math/rand"
 	"testing"
+
+	"github.com/chewxy/math32"
+
+	"github.com/soypat/geometry/ms3"
 )
 
 func TestBasic(t *testing.T) {
@@ -72,3 +76,35 @@ var jet = color.Palette{
 	color.RGBA64{R: 0x3636, G: 0x1f1f, B: 0x5656, A: 0xffff},
 	color.RGBA64{R: 0x3333, G: 0x1313, B: 0x3938, A: 0xffff},
 }
+
+func sqdist(a, b ms3.Vec) float32 {
+	d := ms3.Sub(a, b)
+	return ms3.Dot(d, d)
+}
+
+func TestRGB8(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		v := uint8(i)
+		r, g, b := RGB8ToSRGB(v, 255-v, v/2).RGB8()
+		if r != v || g != 255-v || b != v/2 {
+			t.Errorf("RGB8 round trip failed for %d: got %d %d %d", v, r, g, b)
+		}
+	}
+	if r, _, _ := (SRGB{R: 1.5}).RGB8(); r != 255 {
+		t.Errorf("expected out of gamut value to clamp to 255, got %d", r)
+	}
+}
+
+func TestCIELABLowLightness(t *testing.T) {
+	// Below L*=8 lightness is linear in Y: Y = L*/κ.
+	const κ = 24389. / 27
+	if got := (CIELAB{L: 5}).CIEXYZ().Y; math32.Abs(got-5/κ) > 1e-7 {
+		t.Errorf("Y of L*=5 = %v, want %v", got, 5/κ)
+	}
+	for _, lab := range []CIELAB{{L: 5}, {L: 2, A: 3, B: -4}, {L: 7.9, A: -1, B: 2}, {L: 0.5}} {
+		got := lab.CIEXYZ().CIELAB()
+		if sqdist(got.vec(), lab.vec()) > 1e-6 {
+			t.Errorf("round trip of %v = %v", lab, got)
+		}
+	}
+}
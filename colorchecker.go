@@ -0,0 +1,61 @@
+package colorspace
+
+import "image/color"
+
+// ColorCheckerPatch is a patch of the 24-patch ColorChecker Classic chart.
+type ColorCheckerPatch struct {
+	Name string
+	// Lab is the reference CIELAB value relative to D50.
+	Lab CIELAB
+}
+
+// SRGB returns the sRGB rendering of the patch, adapting the reference from
+// D50 to D65 with the Bradford transform. Out of gamut channels are clipped.
+func (p ColorCheckerPatch) SRGB() SRGB {
+	return p.Lab.CIEXYZ().d50ToD65().LSRGB().ClipToGamut().SRGB()
+}
+
+// colorChecker24 contains X-Rite's reference values for charts manufactured after November 2014.
+var colorChecker24 = [24]ColorCheckerPatch{
+	{Name: "dark skin", Lab: CIELAB{L: 37.54, A: 14.37, B: 14.92}},
+	{Name: "light skin", Lab: CIELAB{L: 64.66, A: 19.27, B: 17.50}},
+	{Name: "blue sky", Lab: CIELAB{L: 49.32, A: -3.82, B: -22.54}},
+	{Name: "foliage", Lab: CIELAB{L: 43.46, A: -12.74, B: 22.72}},
+	{Name: "blue flower", Lab: CIELAB{L: 54.94, A: 9.61, B: -24.79}},
+	{Name: "bluish green", Lab: CIELAB{L: 70.48, A: -32.26, B: -0.37}},
+	{Name: "orange", Lab: CIELAB{L: 62.73, A: 35.83, B: 56.50}},
+	{Name: "purplish blue", Lab: CIELAB{L: 39.43, A: 10.75, B: -45.17}},
+	{Name: "moderate red", Lab: CIELAB{L: 50.57, A: 48.64, B: 16.67}},
+	{Name: "purple", Lab: CIELAB{L: 30.10, A: 22.54, B: -20.87}},
+	{Name: "yellow green", Lab: CIELAB{L: 71.77, A: -24.13, B: 58.19}},
+	{Name: "orange yellow", Lab: CIELAB{L: 71.51, A: 18.24, B: 67.37}},
+	{Name: "blue", Lab: CIELAB{L: 28.37, A: 15.42, B: -49.80}},
+	{Name: "green", Lab: CIELAB{L: 54.38, A: -39.72, B: 32.27}},
+	{Name: "red", Lab: CIELAB{L: 42.43, A: 51.05, B: 28.62}},
+	{Name: "yellow", Lab: CIELAB{L: 81.80, A: 2.67, B: 80.41}},
+	{Name: "magenta", Lab: CIELAB{L: 50.63, A: 51.28, B: -14.12}},
+	{Name: "cyan", Lab: CIELAB{L: 49.57, A: -29.71, B: -28.32}},
+	{Name: "white 9.5", Lab: CIELAB{L: 95.19, A: -1.03, B: 2.93}},
+	{Name: "neutral 8", Lab: CIELAB{L: 81.29, A: -0.57, B: 0.44}},
+	{Name: "neutral 6.5", Lab: CIELAB{L: 66.89, A: -0.75, B: -0.06}},
+	{Name: "neutral 5", Lab: CIELAB{L: 50.76, A: -0.13, B: 0.14}},
+	{Name: "neutral 3.5", Lab: CIELAB{L: 35.63, A: -0.46, B: -0.48}},
+	{Name: "black 2", Lab: CIELAB{L: 20.64, A: 0.07, B: -0.46}},
+}
+
+// ColorChecker24 returns the reference patches of the 24-patch ColorChecker Classic chart
+// in reading order (left to right, top to bottom) with CIELAB values under D50.
+// Use with [CompareCIELAB] to verify camera or display calibration.
+func ColorChecker24() []ColorCheckerPatch {
+	return append([]ColorCheckerPatch{}, colorChecker24[:]...)
+}
+
+// ColorChecker24Palette returns the sRGB renderings of the ColorChecker Classic patches
+// in reading order. Use with [ComparePalettes].
+func ColorChecker24Palette() color.Palette {
+	p := make(color.Palette, len(colorChecker24))
+	for i, patch := range colorChecker24 {
+		p[i] = patch.SRGB()
+	}
+	return p
+}
@@ -0,0 +1,23 @@
+package colorspace
+
+import "testing"
+
+func TestColorChecker24(t *testing.T) {
+	patches := ColorChecker24()
+	if len(patches) != 24 {
+		t.Fatalf("expected 24 patches, got %d", len(patches))
+	}
+	for _, patch := range patches {
+		srgb := patch.SRGB()
+		if !srgb.LSRGB().InGamut() {
+			t.Errorf("patch %q rendering out of gamut: %v", patch.Name, srgb)
+		}
+		if patch.Name == "cyan" {
+			continue // Slightly outside sRGB gamut.
+		}
+		got := srgb.LSRGB().CIEXYZ().d65ToD50().CIELAB()
+		if d := patch.Lab.DeltaE2000(got); d > 0.1 {
+			t.Errorf("patch %q sRGB rendering round trip differs by %v", patch.Name, d)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// ColorTransferSpace selects the decorrelated color space in which statistical color transfer operates.
+type ColorTransferSpace uint8
+
+const (
+	// ColorTransferOKLAB matches statistics in [OKLAB]. Recommended.
+	ColorTransferOKLAB ColorTransferSpace = iota
+	// ColorTransferLAlphaBeta matches statistics in Ruderman's logarithmic lαβ space as in
+	// Reinhard et al. "Color Transfer between Images" (2001).
+	ColorTransferLAlphaBeta
+	// ColorTransferLSRGB operates on linear sRGB channels. Channels are correlated so results
+	// may shift hues; mostly useful for per channel histogram matching of scientific images.
+	ColorTransferLSRGB
+)
+
+var (
+	// Linear RGB to LMS cone response as given by Reinhard et al.
+	linRGBToLMSReinhard = ms3.NewMat3([]float32{
+		0.3811, 0.5783, 0.0402,
+		0.1967, 0.7244, 0.0782,
+		0.0241, 0.1288, 0.8444,
+	})
+	lmsReinhardToLinRGB = linRGBToLMSReinhard.Inverse()
+	logLMSToLAlphaBeta  = ms3.NewMat3([]float32{
+		1 / math32.Sqrt(3), 1 / math32.Sqrt(3), 1 / math32.Sqrt(3),
+		1 / math32.Sqrt(6), 1 / math32.Sqrt(6), -2 / math32.Sqrt(6),
+		1 / math32.Sqrt2, -1 / math32.Sqrt2, 0,
+	})
+	lAlphaBetaToLogLMS = logLMSToLAlphaBeta.Inverse()
+)
+
+// ColorStats holds the per-channel mean and standard deviation of an image's colors in a [ColorTransferSpace].
+type ColorStats struct {
+	Space  ColorTransferSpace
+	Mean   [3]float32
+	StdDev [3]float32
+}
+
+// ImageColorStats computes the color statistics of img in the given space.
+// Fully transparent pixels are ignored. Statistics of a reference image may be computed
+// once and applied to a batch of images with [ColorStats.Transfer].
+func ImageColorStats(img image.Image, space ColorTransferSpace) ColorStats {
+	stats := ColorStats{Space: space}
+	var sum, sumSq [3]float64
+	var n float64
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			v := space.from(c.LSRGB())
+			for i, vi := range v.Array() {
+				sum[i] += float64(vi)
+				sumSq[i] += float64(vi) * float64(vi)
+			}
+			n++
+		}
+	}
+	if n == 0 {
+		return stats
+	}
+	for i := range sum {
+		mean := sum[i] / n
+		stats.Mean[i] = float32(mean)
+		stats.StdDev[i] = float32(math.Sqrt(math.Max(sumSq[i]/n-mean*mean, 0)))
+	}
+	return stats
+}
+
+// ReinhardTransfer returns a copy of target recolored so that its color statistics (mean and standard
+// deviation per channel) match those of reference, i.e: to match the look of footage or a photo batch.
+// Alpha is preserved and out of gamut results are clipped.
+func ReinhardTransfer(target, reference image.Image, space ColorTransferSpace) *image.RGBA64 {
+	return ImageColorStats(reference, space).Transfer(target)
+}
+
+// Transfer returns a copy of target recolored so that its color statistics match stats. See [ReinhardTransfer].
+func (stats ColorStats) Transfer(target image.Image) *image.RGBA64 {
+	src := ImageColorStats(target, stats.Space)
+	var scale [3]float32
+	for i := range scale {
+		if src.StdDev[i] > 0 {
+			scale[i] = stats.StdDev[i] / src.StdDev[i]
+		}
+	}
+	return mapImageSRGB(target, func(c SRGB) SRGB {
+		v := stats.Space.from(c.LSRGB()).Array()
+		for i := range v {
+			v[i] = (v[i]-src.Mean[i])*scale[i] + stats.Mean[i]
+		}
+		return stats.Space.to(ms3.Vec{X: v[0], Y: v[1], Z: v[2]}).ClipToGamut().SRGB()
+	})
+}
+
+// from converts linear sRGB to the transfer space.
+func (space ColorTransferSpace) from(c LSRGB) ms3.Vec {
+	switch space {
+	case ColorTransferOKLAB:
+		return c.CIEXYZ().OKLAB().vec()
+	case ColorTransferLAlphaBeta:
+		const minLMS = 1e-4 // Avoid log of zero for black.
+		lms := ms3.MulMatVec(linRGBToLMSReinhard, c.vec())
+		lms = ms3.Vec{
+			X: math32.Log10(math32.Max(lms.X, minLMS)),
+			Y: math32.Log10(math32.Max(lms.Y, minLMS)),
+			Z: math32.Log10(math32.Max(lms.Z, minLMS)),
+		}
+		return ms3.MulMatVec(logLMSToLAlphaBeta, lms)
+	case ColorTransferLSRGB:
+		return c.vec()
+	}
+	panic("unknown color transfer space")
+}
+
+// to converts from the transfer space to linear sRGB.
+func (space ColorTransferSpace) to(v ms3.Vec) LSRGB {
+	switch space {
+	case ColorTransferOKLAB:
+		return OKLAB{L: v.X, A: v.Y, B: v.Z}.CIEXYZ().LSRGB()
+	case ColorTransferLAlphaBeta:
+		lms := ms3.MulMatVec(lAlphaBetaToLogLMS, v)
+		lms = ms3.Vec{X: math32.Pow(10, lms.X), Y: math32.Pow(10, lms.Y), Z: math32.Pow(10, lms.Z)}
+		rgb := ms3.MulMatVec(lmsReinhardToLinRGB, lms)
+		return LSRGB{R: rgb.X, G: rgb.Y, B: rgb.Z}
+	case ColorTransferLSRGB:
+		return LSRGB{R: v.X, G: v.Y, B: v.Z}
+	}
+	panic("unknown color transfer space")
+}
+
+// unpremultiplied returns the straight (non-premultiplied) sRGB color of c and its alpha in [0,1].
+func unpremultiplied(c color.Color) (SRGB, float32) {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return SRGB{}, 0
+	}
+	fa := float32(a)
+	return SRGB{R: float32(r) / fa, G: float32(g) / fa, B: float32(b) / fa}, fa / 0xffff
+}
+
+// mapImageSRGB returns a copy of img with fn applied to the straight sRGB color of each pixel, preserving alpha.
+func mapImageSRGB(img image.Image, fn func(SRGB) SRGB) *image.RGBA64 {
+	bounds := img.Bounds()
+	dst := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			c = fn(c).ClipToGamut()
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(c.R*a*0xffff + 0.5),
+				G: uint16(c.G*a*0xffff + 0.5),
+				B: uint16(c.B*a*0xffff + 0.5),
+				A: uint16(a*0xffff + 0.5),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,50 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestReinhardTransfer(t *testing.T) {
+	gradient := func(c1, c2 color.Color) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, 16, 4))
+		for x := 0; x < 16; x++ {
+			c := LerpOKLAB(c1, c2, float32(x)/15)
+			for y := 0; y < 4; y++ {
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+	target := gradient(color.RGBA{R: 40, G: 60, B: 120, A: 255}, color.RGBA{R: 120, G: 160, B: 220, A: 255})
+	reference := gradient(color.RGBA{R: 180, G: 90, B: 40, A: 255}, color.RGBA{R: 250, G: 200, B: 120, A: 255})
+	// Logarithmic lαβ stretches dark colors more and thus suffers more from gamut clipping.
+	tolerance := map[ColorTransferSpace]float32{ColorTransferOKLAB: 0.01, ColorTransferLAlphaBeta: 0.05}
+	for space, tol := range tolerance {
+		want := ImageColorStats(reference, space)
+		got := ImageColorStats(ReinhardTransfer(target, reference, space), space)
+		for i := range want.Mean {
+			if math32.Abs(got.Mean[i]-want.Mean[i]) > tol || math32.Abs(got.StdDev[i]-want.StdDev[i]) > tol {
+				t.Errorf("space %d channel %d: got mean %v std %v, want mean %v std %v",
+					space, i, got.Mean[i], got.StdDev[i], want.Mean[i], want.StdDev[i])
+			}
+		}
+	}
+	// Transferring an image's own statistics leaves it unchanged.
+	same := ReinhardTransfer(target, target, ColorTransferOKLAB)
+	r1, g1, b1, _ := target.At(7, 0).RGBA()
+	r2, g2, b2, _ := same.At(7, 0).RGBA()
+	if absDiffU32(r1, r2) > 0x100 || absDiffU32(g1, g2) > 0x100 || absDiffU32(b1, b2) > 0x100 {
+		t.Errorf("self transfer changed pixel: %v,%v,%v -> %v,%v,%v", r1, g1, b1, r2, g2, b2)
+	}
+}
+
+func absDiffU32(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,111 @@
+package colorspace
+
+import (
+	"image"
+	"sort"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// EstimateIlluminant estimates the color of the scene illuminant of img as a linear sRGB
+// vector normalized to unit length using the shades of gray method of Finlayson and Trezzi (2004):
+// the Minkowski p-norm of each linear channel over all pixels. p=1 is the gray world assumption and
+// larger p approach the white patch (max-RGB) assumption; p=6 performs well in practice.
+// Fully transparent pixels are ignored.
+func EstimateIlluminant(img image.Image, p float32) LSRGB {
+	if p < 1 {
+		panic("Minkowski norm must be at least 1")
+	}
+	var sum [3]float64
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			for i, v := range c.LSRGB().Array() {
+				sum[i] += float64(math32.Pow(v, p))
+			}
+		}
+	}
+	est := LSRGB{
+		R: math32.Pow(float32(sum[0]), 1/p),
+		G: math32.Pow(float32(sum[1]), 1/p),
+		B: math32.Pow(float32(sum[2]), 1/p),
+	}
+	norm := ms3.Norm(est.vec())
+	if norm == 0 {
+		return LSRGB{}
+	}
+	return est.ScaleBrightness(1 / norm)
+}
+
+// AngularError returns the recovery angular error in degrees between an estimated and the
+// ground-truth illuminant color in linear RGB. It is independent of illuminant intensity and is the
+// standard metric for benchmarking automatic white balance (AWB) algorithms.
+func AngularError(estimate, truth LSRGB) float32 {
+	return vecAngle(estimate.vec(), truth.vec())
+}
+
+// ReproductionError returns the reproduction angular error in degrees of Finlayson and Zakizadeh (2014):
+// the angle between a white surface corrected with the estimated illuminant and true white. Unlike
+// [AngularError] it does not depend on the illuminant itself being reddish or bluish.
+// It returns NaN if a channel of estimate is not positive since the correction is then undefined.
+func ReproductionError(estimate, truth LSRGB) float32 {
+	if estimate.R <= 0 || estimate.G <= 0 || estimate.B <= 0 {
+		return math32.NaN()
+	}
+	corrected := ms3.DivElem(truth.vec(), estimate.vec())
+	return vecAngle(corrected, ms3.Vec{X: 1, Y: 1, Z: 1})
+}
+
+func vecAngle(a, b ms3.Vec) float32 {
+	if ms3.Norm(a) == 0 || ms3.Norm(b) == 0 {
+		return 0
+	}
+	// atan2 is better conditioned than acos for the small angles of interest.
+	return math32.Atan2(ms3.Norm(ms3.Cross(a, b)), ms3.Dot(a, b)) * 180 / math32.Pi
+}
+
+// AngularErrorStats summarizes errors over a dataset as customarily reported in color constancy literature.
+type AngularErrorStats struct {
+	Mean, Median, Trimean float32
+	// Best25 and Worst25 are the means of the lowest and highest 25% of errors.
+	Best25, Worst25 float32
+	Max             float32
+}
+
+// SummarizeAngularErrors computes summary statistics of per-image angular or reproduction errors.
+func SummarizeAngularErrors(errs []float32) AngularErrorStats {
+	n := len(errs)
+	if n == 0 {
+		return AngularErrorStats{}
+	}
+	sorted := append([]float32{}, errs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mean := func(s []float32) float32 {
+		if len(s) == 0 {
+			return 0
+		}
+		var sum float32
+		for _, v := range s {
+			sum += v
+		}
+		return sum / float32(len(s))
+	}
+	q := func(p float32) float32 { return quantile(sorted, p) }
+	quarter := n / 4
+	if quarter == 0 {
+		quarter = 1
+	}
+	return AngularErrorStats{
+		Mean:    mean(sorted),
+		Median:  q(0.5),
+		Trimean: 0.25 * (q(0.25) + 2*q(0.5) + q(0.75)),
+		Best25:  mean(sorted[:quarter]),
+		Worst25: mean(sorted[n-quarter:]),
+		Max:     sorted[n-1],
+	}
+}
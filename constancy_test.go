@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestAngularError(t *testing.T) {
+	truth := LSRGB{R: 1, G: 0.8, B: 0.5}
+	if e := AngularError(truth.ScaleBrightness(3), truth); e > 0.01 {
+		t.Errorf("scaled estimate should have zero error, got %v", e)
+	}
+	if e := AngularError(LSRGB{R: 1}, LSRGB{G: 1}); math32.Abs(e-90) > 1e-3 {
+		t.Errorf("orthogonal illuminants got %v, want 90", e)
+	}
+	if e := ReproductionError(truth, truth); e > 0.01 {
+		t.Errorf("exact estimate should have zero reproduction error, got %v", e)
+	}
+	if e := ReproductionError(LSRGB{R: 1, G: 1, B: 1}, truth); e <= 0 {
+		t.Errorf("white estimate of colored illuminant should have error")
+	}
+	if e := ReproductionError(LSRGB{G: 0.5, B: 0.5}, truth); !math32.IsNaN(e) {
+		t.Errorf("estimate with zero channel should give NaN, got %v", e)
+	}
+
+	// Gray world recovers the cast of a uniformly tinted scene.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	cast := SRGB{R: 0.9, G: 0.7, B: 0.5}
+	img.Set(0, 0, cast)
+	img.Set(1, 0, color.RGBA{A: 255})
+	if e := AngularError(EstimateIlluminant(img, 1), cast.LSRGB()); e > 0.5 {
+		t.Errorf("gray world estimate off by %v degrees", e)
+	}
+
+	stats := SummarizeAngularErrors([]float32{1, 2, 3, 4, 5, 6, 7, 8})
+	if stats.Mean != 4.5 || stats.Median != 4.5 || stats.Best25 != 1.5 || stats.Worst25 != 7.5 || stats.Max != 8 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
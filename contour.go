@@ -0,0 +1,80 @@
+package colorspace
+
+import (
+	"errors"
+
+	"github.com/chewxy/math32"
+)
+
+var (
+	errContourBands     = errors.New("cannot separate contour bands by the minimum color difference: use fewer bands or a smaller difference")
+	errContourBandCount = errors.New("number of contour bands must be positive")
+)
+
+// ContourBands returns n flat colors for the bands of a filled contour plot sampled at the band centers of cmap.
+// Adjacent bands are guaranteed to differ by at least minDeltaE ([OKLAB] Euclidean distance) so contour
+// boundaries remain visible where the colormap changes slowly; lightness of bands too similar to their
+// neighbors is pushed apart. If emphasis is positive the lightness of even and odd bands is alternately
+// raised and lowered by emphasis/2 in OKLCH, an effect similar to drawing contour lines.
+// Colors are gamut mapped to sRGB. An error is returned if n <= 0 or the difference cannot be achieved.
+func ContourBands(cmap Colormap, n int, minDeltaE, emphasis float32) ([]SRGB, error) {
+	if n <= 0 {
+		return nil, errContourBandCount
+	}
+	lch := make([]OKLCH, n)
+	for i := range lch {
+		lch[i] = colorToOKLCH(cmap.At((float32(i) + 0.5) / float32(n)))
+		if emphasis > 0 {
+			lch[i].L += emphasis / 2 * alternateSign(i)
+		}
+		lch[i].L = clamp01(lch[i].L)
+	}
+	bands := make([]SRGB, n)
+	labs := make([]OKLAB, n)
+	update := func(i int) {
+		bands[i] = oklchToSRGB(lch[i])
+		labs[i] = bands[i].LSRGB().CIEXYZ().OKLAB()
+	}
+	for i := range bands {
+		update(i)
+	}
+	const maxPasses = 100
+	for pass := 0; pass < maxPasses; pass++ {
+		separated := true
+		for i := 0; i+1 < n; i++ {
+			d := labs[i].DeltaE(labs[i+1])
+			if d >= minDeltaE {
+				continue
+			}
+			separated = false
+			// Move the lighter band up and the darker down. Equal lightness follow the emphasis pattern.
+			step := (minDeltaE-d)/2 + 1e-3
+			up, down := i, i+1
+			if lch[i].L < lch[i+1].L || lch[i].L == lch[i+1].L && alternateSign(i) < 0 {
+				up, down = i+1, i
+			}
+			if lch[up].L+step > 1 {
+				// Darken the other band by what cannot be gained lightening.
+				step += lch[up].L + step - 1
+			} else if lch[down].L-step < 0 {
+				step += step - lch[down].L
+			}
+			lch[up].L = math32.Min(lch[up].L+step, 1)
+			lch[down].L = math32.Max(lch[down].L-step, 0)
+			update(up)
+			update(down)
+		}
+		if separated {
+			return bands, nil
+		}
+	}
+	return bands, errContourBands
+}
+
+// alternateSign returns 1 for even i and -1 for odd i.
+func alternateSign(i int) float32 {
+	if i%2 == 0 {
+		return 1
+	}
+	return -1
+}
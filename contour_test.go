@@ -0,0 +1,43 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestContourBands(t *testing.T) {
+	// A nearly flat colormap would produce indistinguishable bands.
+	flat := NewGradient(LerpOKLAB, color.RGBA{R: 100, G: 120, B: 200, A: 255}, color.RGBA{R: 110, G: 130, B: 200, A: 255})
+	heat, _ := GradientPreset("heat")
+	for _, cmap := range []Colormap{flat, heat} {
+		for _, emphasis := range []float32{0, 0.1} {
+			bands, err := ContourBands(cmap, 8, 0.05, emphasis)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bands) != 8 {
+				t.Fatalf("got %d bands, want 8", len(bands))
+			}
+			for i := 1; i < len(bands); i++ {
+				d := bands[i-1].LSRGB().CIEXYZ().OKLAB().DeltaE(bands[i].LSRGB().CIEXYZ().OKLAB())
+				if d < 0.05 {
+					t.Errorf("emphasis %v: bands %d and %d differ by %v", emphasis, i-1, i, d)
+				}
+			}
+		}
+	}
+	// Emphasis alternates lightness.
+	bands, _ := ContourBands(flat, 4, 0, 0.2)
+	l := func(i int) float32 { return bands[i].LSRGB().CIEXYZ().OKLAB().L }
+	if !(l(0) > l(1) && l(1) < l(2) && l(2) > l(3)) {
+		t.Errorf("lightness does not alternate: %v %v %v %v", l(0), l(1), l(2), l(3))
+	}
+	if _, err := ContourBands(flat, 3, 1.5, 0); err == nil {
+		t.Error("expected error for unachievable color difference")
+	}
+	for _, n := range []int{0, -2} {
+		if _, err := ContourBands(flat, n, 0.05, 0); err == nil {
+			t.Errorf("expected error for %d bands", n)
+		}
+	}
+}
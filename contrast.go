@@ -0,0 +1,74 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// RelativeLuminance returns the relative luminance of the gamma-encoded sRGB color in [0,1]
+// as defined by WCAG 2, which corresponds to the Y tristimulus value of [CIEXYZ].
+func (c SRGB) RelativeLuminance() float32 {
+	return c.LSRGB().CIEXYZ().Y
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio between two colors which ranges from 1 (no contrast)
+// to 21 (black on white). WCAG AA requires at least 4.5 for body text and AAA requires 7.
+// The result does not depend on argument order.
+func ContrastRatio(c1, c2 color.Color) float32 {
+	return contrastRatio(ColorToSRGB(c1).RelativeLuminance(), ColorToSRGB(c2).RelativeLuminance())
+}
+
+// contrastRatio returns the WCAG 2 contrast ratio between two relative luminances.
+func contrastRatio(y1, y2 float32) float32 {
+	if y1 < y2 {
+		y1, y2 = y2, y1
+	}
+	return (y1 + 0.05) / (y2 + 0.05)
+}
+
+// APCAContrast returns the APCA (Accessible Perceptual Contrast Algorithm, version 0.0.98G) lightness
+// contrast Lc of text over background, the candidate contrast method of WCAG 3. Unlike [ContrastRatio]
+// it depends on polarity: it is positive for dark text on a light background and negative for light text
+// on a dark background. Its magnitude ranges from 0 to about 108; 75 is recommended for body text,
+// 60 for content text and 45 for large or bold text.
+func APCAContrast(text, background color.Color) float32 {
+	const (
+		normBG, normText   = 0.56, 0.57
+		revBG, revText     = 0.65, 0.62
+		scale, offset      = 1.14, 0.027
+		minDeltaY, lowClip = 0.0005, 0.1
+	)
+	ytext := apcaLuminance(ColorToSRGB(text))
+	ybg := apcaLuminance(ColorToSRGB(background))
+	if math32.Abs(ybg-ytext) < minDeltaY {
+		return 0
+	}
+	var lc float32
+	if ybg > ytext {
+		lc = (math32.Pow(ybg, normBG) - math32.Pow(ytext, normText)) * scale
+		if lc < lowClip {
+			return 0
+		}
+		lc -= offset
+	} else {
+		lc = (math32.Pow(ybg, revBG) - math32.Pow(ytext, revText)) * scale
+		if lc > -lowClip {
+			return 0
+		}
+		lc += offset
+	}
+	return 100 * lc
+}
+
+// apcaLuminance returns the APCA screen luminance of c, which uses a simple 2.4 exponent
+// and soft clamps luminances near black.
+func apcaLuminance(c SRGB) float32 {
+	const blackThreshold, blackClamp = 0.022, 1.414
+	c = c.ClipToGamut()
+	y := 0.2126729*math32.Pow(c.R, 2.4) + 0.7151522*math32.Pow(c.G, 2.4) + 0.0721750*math32.Pow(c.B, 2.4)
+	if y < blackThreshold {
+		y += math32.Pow(blackThreshold-y, blackClamp)
+	}
+	return y
+}
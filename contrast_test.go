@@ -0,0 +1,30 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestAPCAContrast(t *testing.T) {
+	// Reference values of the apca-w3 0.0.98G implementation.
+	tests := []struct {
+		text, bg color.RGBA
+		want     float32
+	}{
+		{text: color.RGBA{0x88, 0x88, 0x88, 0xff}, bg: color.RGBA{0xff, 0xff, 0xff, 0xff}, want: 63.0565},
+		{text: color.RGBA{0xff, 0xff, 0xff, 0xff}, bg: color.RGBA{0x88, 0x88, 0x88, 0xff}, want: -68.5415},
+		{text: color.RGBA{0x00, 0x00, 0x00, 0xff}, bg: color.RGBA{0xaa, 0xaa, 0xaa, 0xff}, want: 58.1463},
+		{text: color.RGBA{0xaa, 0xaa, 0xaa, 0xff}, bg: color.RGBA{0x00, 0x00, 0x00, 0xff}, want: -56.2411},
+		{text: color.RGBA{0x00, 0x00, 0x00, 0xff}, bg: color.RGBA{0xff, 0xff, 0xff, 0xff}, want: 106.0407},
+		{text: color.RGBA{0xff, 0xff, 0xff, 0xff}, bg: color.RGBA{0x00, 0x00, 0x00, 0xff}, want: -107.8847},
+		{text: color.RGBA{0x77, 0x77, 0x77, 0xff}, bg: color.RGBA{0x77, 0x77, 0x77, 0xff}, want: 0},
+	}
+	for _, test := range tests {
+		got := APCAContrast(test.text, test.bg)
+		if math32.Abs(got-test.want) > 0.01 {
+			t.Errorf("APCAContrast(%v, %v) = %v, want %v", test.text, test.bg, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package colorspace
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/chewxy/math32"
+)
+
+var errCSSSyntax = errors.New("invalid CSS color syntax")
+
+// ParseCSSColor parses a CSS Color Level 4 color value such as "#ff8000", "rebeccapurple",
+// "rgb(255 128 0)", "hsl(30deg 100% 50%)", "hwb(30 10% 0%)", "oklch(70% 0.15 60)" or "color(srgb-linear 1 0.5 0)".
+// The opacity/alpha component is parsed but discarded.
+// Colors outside the sRGB gamut are gamut mapped in [OKLCH]. Keywords "currentcolor" and relative color syntax
+// are not supported.
+func ParseCSSColor(s string) (SRGB, error) {
+	xyz, err := parseCSSColorXYZ(s)
+	if err != nil {
+		return SRGB{}, err
+	}
+	return xyzToSRGBMapped(xyz), nil
+}
+
+// xyzToSRGBMapped converts D65 XYZ to sRGB gamut mapping in OKLCH if necessary.
+func xyzToSRGBMapped(xyz CIEXYZ) SRGB {
+	lrgb := xyz.LSRGB()
+	const tol = 1e-4
+	if lrgb.R >= -tol && lrgb.G >= -tol && lrgb.B >= -tol && lrgb.R <= 1+tol && lrgb.G <= 1+tol && lrgb.B <= 1+tol {
+		return lrgb.ClipToGamut().SRGB()
+	}
+	return oklchToSRGB(xyz.OKLAB().OKLCH())
+}
+
+// parseCSSColorXYZ parses a CSS color returning its XYZ value relative to D65.
+func parseCSSColorXYZ(s string) (CIEXYZ, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "#") {
+		srgb, err := parseHexColor(s[1:])
+		return srgb.LSRGB().CIEXYZ(), err
+	}
+	if hex, ok := cssNamedColors[s]; ok {
+		return hexToSRGB(hex).LSRGB().CIEXYZ(), nil
+	} else if s == "transparent" {
+		return CIEXYZ{}, nil
+	}
+	open := strings.IndexByte(s, '(')
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return CIEXYZ{}, errors.New("unknown CSS color: " + s)
+	}
+	fn := strings.TrimSpace(s[:open])
+	args, err := splitCSSArgs(s[open+1 : len(s)-1])
+	if err != nil {
+		return CIEXYZ{}, err
+	}
+	switch fn {
+	case "rgb", "rgba":
+		if len(args) != 3 {
+			return CIEXYZ{}, errCSSSyntax
+		}
+		var v [3]float32
+		for i, arg := range args {
+			v[i], err = parseCSSNumber(arg, 100, 255)
+			if err != nil {
+				return CIEXYZ{}, err
+			}
+		}
+		return SRGB{R: v[0] / 255, G: v[1] / 255, B: v[2] / 255}.LSRGB().CIEXYZ(), nil
+	case "hsl", "hsla":
+		if len(args) != 3 {
+			return CIEXYZ{}, errCSSSyntax
+		}
+		h, err1 := parseCSSAngle(args[0])
+		sat, err2 := parseCSSNumber(args[1], 100, 100)
+		l, err3 := parseCSSNumber(args[2], 100, 100)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return CIEXYZ{}, err
+		}
+		return HSL{H: h, S: sat / 100, L: l / 100}.SRGB().LSRGB().CIEXYZ(), nil
+	case "hwb":
+		if len(args) != 3 {
+			return CIEXYZ{}, errCSSSyntax
+		}
+		h, err1 := parseCSSAngle(args[0])
+		w, err2 := parseCSSNumber(args[1], 100, 100)
+		b, err3 := parseCSSNumber(args[2], 100, 100)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return CIEXYZ{}, err
+		}
+		return HWB{H: h, W: w / 100, B: b / 100}.SRGB().LSRGB().CIEXYZ(), nil
+	case "lab", "lch", "oklab", "oklch":
+		if len(args) != 3 {
+			return CIEXYZ{}, errCSSSyntax
+		}
+		// Percentage references from CSS Color 4.
+		lref, cref := float32(100), float32(125)
+		if fn == "lch" {
+			cref = 150
+		} else if fn == "oklab" || fn == "oklch" {
+			lref, cref = 1, 0.4
+		}
+		l, err1 := parseCSSNumber(args[0], 100, lref)
+		c1, err2 := parseCSSNumber(args[1], 100, cref)
+		var c2 float32
+		var err3 error
+		if strings.HasSuffix(fn, "ch") {
+			c2, err3 = parseCSSAngle(args[2])
+		} else {
+			c2, err3 = parseCSSNumber(args[2], 100, cref)
+		}
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return CIEXYZ{}, err
+		}
+		switch fn {
+		case "lab":
+			return CIELAB{L: l, A: c1, B: c2}.CIEXYZ().d50ToD65(), nil
+		case "lch":
+			return CIELCH{L: l, C: c1, H: c2}.CIELAB().CIEXYZ().d50ToD65(), nil
+		case "oklab":
+			return OKLAB{L: l, A: c1, B: c2}.CIEXYZ(), nil
+		default:
+			return OKLCH{L: l, C: c1, H: c2}.OKLAB().CIEXYZ(), nil
+		}
+	case "color":
+		if len(args) != 4 {
+			return CIEXYZ{}, errCSSSyntax
+		}
+		var v [3]float32
+		for i, arg := range args[1:] {
+			v[i], err = parseCSSNumber(arg, 100, 1)
+			if err != nil {
+				return CIEXYZ{}, err
+			}
+		}
+		return cssPredefinedToXYZ(args[0], v)
+	}
+	return CIEXYZ{}, errors.New("unsupported CSS color function: " + fn)
+}
+
+// cssPredefinedToXYZ converts the components of the CSS color() function
+// in the named predefined color space to D65 XYZ.
+func cssPredefinedToXYZ(space string, v [3]float32) (CIEXYZ, error) {
+	switch space {
+	case "srgb":
+		return SRGB{R: v[0], G: v[1], B: v[2]}.LSRGB().CIEXYZ(), nil
+	case "srgb-linear":
+		return LSRGB{R: v[0], G: v[1], B: v[2]}.CIEXYZ(), nil
+	case "display-p3":
+		return DisplayP3{R: v[0], G: v[1], B: v[2]}.CIEXYZ(), nil
+	case "rec2020":
+		return Rec2020{R: v[0], G: v[1], B: v[2]}.CIEXYZ(), nil
+	case "prophoto-rgb":
+		return ProPhotoRGB{R: v[0], G: v[1], B: v[2]}.CIEXYZ(), nil
+	case "xyz", "xyz-d65":
+		return CIEXYZ{X: v[0], Y: v[1], Z: v[2]}, nil
+	case "xyz-d50":
+		return CIEXYZ{X: v[0], Y: v[1], Z: v[2]}.d50ToD65(), nil
+	}
+	return CIEXYZ{}, errors.New("unsupported CSS predefined color space: " + space)
+}
+
+// splitCSSArgs splits the arguments of a CSS color function supporting both legacy comma
+// separated and modern space separated syntax. The alpha component, if present, is discarded.
+func splitCSSArgs(s string) ([]string, error) {
+	if slash := strings.IndexByte(s, '/'); slash >= 0 {
+		s = s[:slash]
+	}
+	var args []string
+	if strings.IndexByte(s, ',') >= 0 {
+		args = strings.Split(s, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+		if len(args) == 4 {
+			args = args[:3] // Legacy alpha.
+		}
+	} else {
+		args = strings.Fields(s)
+	}
+	for _, arg := range args {
+		if arg == "" {
+			return nil, errCSSSyntax
+		}
+	}
+	return args, nil
+}
+
+// parseCSSNumber parses a CSS number or percentage. Percentages are
+// scaled so that pctRef percent maps to ref. The keyword "none" is parsed as zero.
+func parseCSSNumber(s string, pctRef, ref float32) (float32, error) {
+	if s == "none" {
+		return 0, nil
+	}
+	isPct := strings.HasSuffix(s, "%")
+	if isPct {
+		s = s[:len(s)-1]
+	}
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, errors.New("invalid CSS number: " + s)
+	}
+	v := float32(f)
+	if isPct {
+		v = v * ref / pctRef
+	}
+	return v, nil
+}
+
+// parseCSSAngle parses a CSS angle or number returning the value in degrees.
+func parseCSSAngle(s string) (float32, error) {
+	if s == "none" {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		scale  float32
+	}{
+		{"deg", 1}, {"grad", 360. / 400}, {"rad", 180 / math32.Pi}, {"turn", 360},
+	}
+	scale := float32(1)
+	for _, unit := range units {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = s[:len(s)-len(unit.suffix)]
+			scale = unit.scale
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, errors.New("invalid CSS angle: " + s)
+	}
+	return float32(f) * scale, nil
+}
+
+// parseHexColor parses the hex digits of a CSS hex color (without leading #) in
+// 3, 4, 6 or 8 digit form.
+func parseHexColor(s string) (SRGB, error) {
+	switch len(s) {
+	case 3, 4:
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	case 6, 8:
+		s = s[:6]
+	default:
+		return SRGB{}, errors.New("invalid CSS hex color length")
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return SRGB{}, errors.New("invalid CSS hex color: " + s)
+	}
+	return hexToSRGB(uint32(v)), nil
+}
+
+// hexToSRGB converts a 0xRRGGBB value to SRGB.
+func hexToSRGB(hex uint32) SRGB {
+	return RGB8ToSRGB(uint8(hex>>16), uint8(hex>>8), uint8(hex))
+}
+
+// cssNamedColors are the CSS Color 4 named colors.
+var cssNamedColors = map[string]uint32{
+	"aliceblue": 0xf0f8ff, "antiquewhite": 0xfaebd7, "aqua": 0x00ffff, "aquamarine": 0x7fffd4,
+	"azure": 0xf0ffff, "beige": 0xf5f5dc, "bisque": 0xffe4c4, "black": 0x000000,
+	"blanchedalmond": 0xffebcd, "blue": 0x0000ff, "blueviolet": 0x8a2be2, "brown": 0xa52a2a,
+	"burlywood": 0xdeb887, "cadetblue": 0x5f9ea0, "chartreuse": 0x7fff00, "chocolate": 0xd2691e,
+	"coral": 0xff7f50, "cornflowerblue": 0x6495ed, "cornsilk": 0xfff8dc, "crimson": 0xdc143c,
+	"cyan": 0x00ffff, "darkblue": 0x00008b, "darkcyan": 0x008b8b, "darkgoldenrod": 0xb8860b,
+	"darkgray": 0xa9a9a9, "darkgreen": 0x006400, "darkgrey": 0xa9a9a9, "darkkhaki": 0xbdb76b,
+	"darkmagenta": 0x8b008b, "darkolivegreen": 0x556b2f, "darkorange": 0xff8c00, "darkorchid": 0x9932cc,
+	"darkred": 0x8b0000, "darksalmon": 0xe9967a, "darkseagreen": 0x8fbc8f, "darkslateblue": 0x483d8b,
+	"darkslategray": 0x2f4f4f, "darkslategrey": 0x2f4f4f, "darkturquoise": 0x00ced1, "darkviolet": 0x9400d3,
+	"deeppink": 0xff1493, "deepskyblue": 0x00bfff, "dimgray": 0x696969, "dimgrey": 0x696969,
+	"dodgerblue": 0x1e90ff, "firebrick": 0xb22222, "floralwhite": 0xfffaf0, "forestgreen": 0x228b22,
+	"fuchsia": 0xff00ff, "gainsboro": 0xdcdcdc, "ghostwhite": 0xf8f8ff, "gold": 0xffd700,
+	"goldenrod": 0xdaa520, "gray": 0x808080, "green": 0x008000, "greenyellow": 0xadff2f,
+	"grey": 0x808080, "honeydew": 0xf0fff0, "hotpink": 0xff69b4, "indianred": 0xcd5c5c,
+	"indigo": 0x4b0082, "ivory": 0xfffff0, "khaki": 0xf0e68c, "lavender": 0xe6e6fa,
+	"lavenderblush": 0xfff0f5, "lawngreen": 0x7cfc00, "lemonchiffon": 0xfffacd, "lightblue": 0xadd8e6,
+	"lightcoral": 0xf08080, "lightcyan": 0xe0ffff, "lightgoldenrodyellow": 0xfafad2, "lightgray": 0xd3d3d3,
+	"lightgreen": 0x90ee90, "lightgrey": 0xd3d3d3, "lightpink": 0xffb6c1, "lightsalmon": 0xffa07a,
+	"lightseagreen": 0x20b2aa, "lightskyblue": 0x87cefa, "lightslategray": 0x778899, "lightslategrey": 0x778899,
+	"lightsteelblue": 0xb0c4de, "lightyellow": 0xffffe0, "lime": 0x00ff00, "limegreen": 0x32cd32,
+	"linen": 0xfaf0e6, "magenta": 0xff00ff, "maroon": 0x800000, "mediumaquamarine": 0x66cdaa,
+	"mediumblue": 0x0000cd, "mediumorchid": 0xba55d3, "mediumpurple": 0x9370db, "mediumseagreen": 0x3cb371,
+	"mediumslateblue": 0x7b68ee, "mediumspringgreen": 0x00fa9a, "mediumturquoise": 0x48d1cc, "mediumvioletred": 0xc71585,
+	"midnightblue": 0x191970, "mintcream": 0xf5fffa, "mistyrose": 0xffe4e1, "moccasin": 0xffe4b5,
+	"navajowhite": 0xffdead, "navy": 0x000080, "oldlace": 0xfdf5e6, "olive": 0x808000,
+	"olivedrab": 0x6b8e23, "orange": 0xffa500, "orangered": 0xff4500, "orchid": 0xda70d6,
+	"palegoldenrod": 0xeee8aa, "palegreen": 0x98fb98, "paleturquoise": 0xafeeee, "palevioletred": 0xdb7093,
+	"papayawhip": 0xffefd5, "peachpuff": 0xffdab9, "peru": 0xcd853f, "pink": 0xffc0cb,
+	"plum": 0xdda0dd, "powderblue": 0xb0e0e6, "purple": 0x800080, "rebeccapurple": 0x663399,
+	"red": 0xff0000, "rosybrown": 0xbc8f8f, "royalblue": 0x4169e1, "saddlebrown": 0x8b4513,
+	"salmon": 0xfa8072, "sandybrown": 0xf4a460, "seagreen": 0x2e8b57, "seashell": 0xfff5ee,
+	"sienna": 0xa0522d, "silver": 0xc0c0c0, "skyblue": 0x87ceeb, "slateblue": 0x6a5acd,
+	"slategray": 0x708090, "slategrey": 0x708090, "snow": 0xfffafa, "springgreen": 0x00ff7f,
+	"steelblue": 0x4682b4, "tan": 0xd2b48c, "teal": 0x008080, "thistle": 0xd8bfd8,
+	"tomato": 0xff6347, "turquoise": 0x40e0d0, "violet": 0xee82ee, "wheat": 0xf5deb3,
+	"white": 0xffffff, "whitesmoke": 0xf5f5f5, "yellow": 0xffff00, "yellowgreen": 0x9acd32,
+}
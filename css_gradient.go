@@ -0,0 +1,354 @@
+package colorspace
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+)
+
+// HueInterpolation selects how hue angles are interpolated as defined by CSS Color 4.
+type HueInterpolation uint8
+
+const (
+	// HueShorter interpolates along the shortest arc between hues. This is the default.
+	HueShorter HueInterpolation = iota
+	// HueLonger interpolates along the longest arc between hues.
+	HueLonger
+	// HueIncreasing interpolates with increasing hue angle.
+	HueIncreasing
+	// HueDecreasing interpolates with decreasing hue angle.
+	HueDecreasing
+)
+
+// InterpHue interpolates between hues h1 and h2 in degrees using the given method.
+// The result is in [0,360).
+func InterpHue(h1, h2, v float32, method HueInterpolation) float32 {
+	h1, h2 = wrapHue(h1), wrapHue(h2)
+	d := h2 - h1
+	switch method {
+	case HueShorter:
+		if d > 180 {
+			d -= 360
+		} else if d < -180 {
+			d += 360
+		}
+	case HueLonger:
+		if d > 0 && d < 180 {
+			d -= 360
+		} else if d > -180 && d <= 0 {
+			d += 360
+		}
+	case HueIncreasing:
+		if d < 0 {
+			d += 360
+		}
+	case HueDecreasing:
+		if d > 0 {
+			d -= 360
+		}
+	}
+	return wrapHue(h1 + v*d)
+}
+
+// ParseCSSLinearGradient parses a CSS linear-gradient() value into a [Gradient] and
+// returns the angle of the gradient line in degrees, where 0 points up and 90 points right as in CSS.
+// Supported syntax includes angles, side directions ("to right"), color interpolation methods
+// ("in oklch longer hue"), color stops with one or two percentage positions and interpolation hints.
+// Colors are parsed with [ParseCSSColor]. Corner directions ("to top right") are
+// returned as the angle for a square box. Without an interpolation method colors are interpolated in sRGB,
+// matching browser behavior for legacy colors. Use [LinearGradientPos] to evaluate the gradient per pixel.
+func ParseCSSLinearGradient(s string) (g Gradient, angle float32, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	const prefix = "linear-gradient("
+	if !strings.HasPrefix(s, prefix) || !strings.HasSuffix(s, ")") {
+		return g, 0, errors.New("expected linear-gradient() syntax")
+	}
+	args := splitTopLevelCommas(s[len(prefix) : len(s)-1])
+	angle = 180 // Default is "to bottom".
+	g.Lerp = LerpSRGB
+	if len(args) > 0 && isCSSGradientPrelude(args[0]) {
+		angle, g.Lerp, err = parseCSSGradientPrelude(args[0])
+		if err != nil {
+			return g, 0, err
+		}
+		args = args[1:]
+	}
+	type hint struct {
+		afterStop int
+		pos       float32
+	}
+	var positions []float32
+	var hints []hint
+	const unset = -math32.MaxFloat32
+	for _, arg := range args {
+		if arg == "" {
+			return g, 0, errCSSSyntax
+		}
+		if pos, err := parseCSSPosition(arg); err == nil {
+			if len(g.Stops) == 0 || (len(hints) > 0 && hints[len(hints)-1].afterStop == len(g.Stops)-1) {
+				return g, 0, errors.New("CSS interpolation hint must be between two color stops")
+			}
+			hints = append(hints, hint{afterStop: len(g.Stops) - 1, pos: pos})
+			continue
+		}
+		colorStr, posStrs := splitCSSColorStop(arg)
+		c, err := ParseCSSColor(colorStr)
+		if err != nil {
+			return g, 0, err
+		}
+		if len(posStrs) > 2 {
+			return g, 0, errors.New("too many positions in CSS color stop: " + arg)
+		}
+		stopPositions := []float32{unset}
+		if len(posStrs) > 0 {
+			stopPositions = stopPositions[:0]
+		}
+		for _, posStr := range posStrs {
+			pos, err := parseCSSPosition(posStr)
+			if err != nil {
+				return g, 0, err
+			}
+			stopPositions = append(stopPositions, pos)
+		}
+		for _, pos := range stopPositions {
+			g.Stops = append(g.Stops, GradientStop{Color: c})
+			positions = append(positions, pos)
+		}
+	}
+	if len(g.Stops) < 2 {
+		return g, 0, errors.New("CSS gradient requires at least two color stops")
+	} else if len(hints) > 0 && hints[len(hints)-1].afterStop == len(g.Stops)-1 {
+		return g, 0, errors.New("CSS interpolation hint must be between two color stops")
+	}
+	// Resolve missing positions as specified by CSS Images 3.
+	if positions[0] == unset {
+		positions[0] = 0
+	}
+	if positions[len(positions)-1] == unset {
+		positions[len(positions)-1] = 1
+	}
+	max := positions[0]
+	for i := range positions {
+		if positions[i] != unset {
+			max = math32.Max(max, positions[i])
+			positions[i] = max
+		}
+	}
+	for i := 1; i < len(positions); i++ {
+		if positions[i] != unset {
+			continue
+		}
+		j := i
+		for positions[j] == unset {
+			j++
+		}
+		start, end := positions[i-1], positions[j]
+		for k := i; k < j; k++ {
+			positions[k] = start + (end-start)*float32(k-i+1)/float32(j-i+1)
+		}
+	}
+	for i := range g.Stops {
+		g.Stops[i].Pos = positions[i]
+	}
+	for _, h := range hints {
+		s0, s1 := g.Stops[h.afterStop], g.Stops[h.afterStop+1]
+		if s1.Pos > s0.Pos {
+			g.Stops[h.afterStop].Hint = ms1.Clamp((h.pos-s0.Pos)/(s1.Pos-s0.Pos), epsUnit, 1)
+		}
+	}
+	return g, angle, nil
+}
+
+// LinearGradientPos returns the position t along a CSS linear gradient line with the given angle in degrees
+// for the point (x, y) in a box of the given width and height, with y pointing down.
+// To evaluate a gradient per pixel pass the pixel center i.e: x+0.5, y+0.5 and use the result with [Gradient.At].
+func LinearGradientPos(angle, width, height, x, y float32) float32 {
+	sin, cos := math32.Sincos(angle * math32.Pi / 180)
+	length := math32.Abs(width*sin) + math32.Abs(height*cos)
+	if length == 0 {
+		return 0
+	}
+	return ((x-width/2)*sin-(y-height/2)*cos)/length + 0.5
+}
+
+func isCSSGradientPrelude(arg string) bool {
+	if strings.HasPrefix(arg, "to ") || strings.HasPrefix(arg, "in ") {
+		return true
+	}
+	fields := strings.Fields(arg)
+	if len(fields) == 0 {
+		return false
+	}
+	if _, err := ParseCSSColor(fields[0]); err == nil {
+		return false
+	}
+	_, err := parseCSSAngle(fields[0])
+	return err == nil
+}
+
+// parseCSSGradientPrelude parses the direction and color interpolation method of a CSS gradient.
+func parseCSSGradientPrelude(arg string) (angle float32, lerp func(c1, c2 color.Color, v float32) color.Color, err error) {
+	angle = 180
+	lerp = LerpSRGB
+	fields := strings.Fields(arg)
+	for len(fields) > 0 {
+		switch fields[0] {
+		case "to":
+			var n int
+			angle, n, err = parseCSSSideDirection(fields[1:])
+			fields = fields[1+n:]
+		case "in":
+			if len(fields) < 2 {
+				return 0, nil, errCSSSyntax
+			}
+			method := HueShorter
+			space := fields[1]
+			fields = fields[2:]
+			if len(fields) >= 2 && fields[1] == "hue" {
+				switch fields[0] {
+				case "shorter":
+				case "longer":
+					method = HueLonger
+				case "increasing":
+					method = HueIncreasing
+				case "decreasing":
+					method = HueDecreasing
+				default:
+					return 0, nil, errors.New("unknown CSS hue interpolation method: " + fields[0])
+				}
+				fields = fields[2:]
+			}
+			lerp, err = cssLerp(space, method)
+		default:
+			angle, err = parseCSSAngle(fields[0])
+			fields = fields[1:]
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	return angle, lerp, nil
+}
+
+// parseCSSSideDirection parses the keywords following "to" in a CSS gradient direction
+// and returns the angle and number of keywords consumed.
+func parseCSSSideDirection(fields []string) (angle float32, n int, err error) {
+	var dx, dy float32
+	for n < len(fields) && n < 2 {
+		switch fields[n] {
+		case "top":
+			dy = -1
+		case "bottom":
+			dy = 1
+		case "left":
+			dx = -1
+		case "right":
+			dx = 1
+		default:
+			if n == 0 {
+				return 0, 0, errors.New("invalid CSS gradient direction: " + fields[n])
+			}
+			return wrapHue(math32.Atan2(dx, -dy) * 180 / math32.Pi), n, nil
+		}
+		n++
+	}
+	if n == 0 {
+		return 0, 0, errCSSSyntax
+	}
+	return wrapHue(math32.Atan2(dx, -dy) * 180 / math32.Pi), n, nil
+}
+
+// cssLerp returns the interpolation function for the CSS color interpolation space.
+func cssLerp(space string, method HueInterpolation) (func(c1, c2 color.Color, v float32) color.Color, error) {
+	switch space {
+	case "srgb":
+		return LerpSRGB, nil
+	case "srgb-linear":
+		return LerpLSRGB, nil
+	case "xyz", "xyz-d65":
+		return LerpCIEXYZ, nil
+	case "oklab":
+		return LerpOKLAB, nil
+	case "lab":
+		return func(c1, c2 color.Color, v float32) color.Color {
+			lab := colorToCIELAB(c1).Lerp(colorToCIELAB(c2), v)
+			return xyzToSRGBMapped(lab.CIEXYZ().d50ToD65())
+		}, nil
+	case "oklch":
+		return func(c1, c2 color.Color, v float32) color.Color {
+			l, c, h := lerpPolar(colorToOKLCH(c1).vec().Array(), colorToOKLCH(c2).vec().Array(), v, method)
+			return oklchToSRGB(OKLCH{L: l, C: c, H: h})
+		}, nil
+	case "lch":
+		return func(c1, c2 color.Color, v float32) color.Color {
+			l, c, h := lerpPolar(colorToCIELAB(c1).CIELCH().vec().Array(), colorToCIELAB(c2).CIELCH().vec().Array(), v, method)
+			return xyzToSRGBMapped(CIELCH{L: l, C: c, H: h}.CIELAB().CIEXYZ().d50ToD65())
+		}, nil
+	case "hsl":
+		return func(c1, c2 color.Color, v float32) color.Color {
+			hsl1, hsl2 := ColorToSRGB(c1).HSL(), ColorToSRGB(c2).HSL()
+			l, s, h := lerpPolar([3]float32{hsl1.L, hsl1.S, hsl1.H}, [3]float32{hsl2.L, hsl2.S, hsl2.H}, v, method)
+			return HSL{H: h, S: s, L: l}.SRGB()
+		}, nil
+	case "hwb":
+		return func(c1, c2 color.Color, v float32) color.Color {
+			return lerpHWB(ColorToSRGB(c1).HWB(), ColorToSRGB(c2).HWB(), v, method).SRGB()
+		}, nil
+	}
+	return nil, errors.New("unsupported CSS interpolation color space: " + space)
+}
+
+// lerpPolar interpolates two colors in a cylindrical space given as (lightness, chroma, hue)
+// treating hues of achromatic colors as missing as specified by CSS Color 4.
+func lerpPolar(from, to [3]float32, v float32, method HueInterpolation) (l, c, h float32) {
+	const eps = 0.000004
+	if from[1] < eps {
+		from[2] = to[2]
+	} else if to[1] < eps {
+		to[2] = from[2]
+	}
+	return ms1.Interp(from[0], to[0], v), ms1.Interp(from[1], to[1], v), InterpHue(from[2], to[2], v, method)
+}
+
+// parseCSSPosition parses a CSS percentage or zero as a position in [0,1].
+func parseCSSPosition(s string) (float32, error) {
+	if s == "0" {
+		return 0, nil
+	} else if !strings.HasSuffix(s, "%") {
+		return 0, errors.New("unsupported CSS gradient position: " + s)
+	}
+	return parseCSSNumber(s, 100, 1)
+}
+
+// splitCSSColorStop splits a CSS color stop into the color and its positions.
+func splitCSSColorStop(s string) (colorStr string, positions []string) {
+	end := strings.LastIndexByte(s, ')')
+	if end < 0 {
+		fields := strings.Fields(s)
+		return fields[0], fields[1:]
+	}
+	return s[:end+1], strings.Fields(s[end+1:])
+}
+
+// splitTopLevelCommas splits s by commas not enclosed in parentheses and trims the result.
+func splitTopLevelCommas(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
@@ -0,0 +1,80 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestParseCSSColor(t *testing.T) {
+	tests := []struct {
+		s    string
+		want SRGB
+	}{
+		{s: "red", want: SRGB{R: 1}},
+		{s: "#f00", want: SRGB{R: 1}},
+		{s: "#FF000080", want: SRGB{R: 1}},
+		{s: "rgb(255, 0, 0)", want: SRGB{R: 1}},
+		{s: "rgba(100% 0% 0% / 0.5)", want: SRGB{R: 1}},
+		{s: "hsl(120deg 100% 50%)", want: SRGB{G: 1}},
+		{s: "hsl(0.5turn, 100%, 50%)", want: SRGB{G: 1, B: 1}},
+		{s: "hwb(120 0% 0%)", want: SRGB{G: 1}},
+		{s: "hwb(0.5turn 20% 30%)", want: SRGB{R: 0.2, G: 0.7, B: 0.7}},
+		{s: "hwb(90 60% 60%)", want: SRGB{R: 0.5, G: 0.5, B: 0.5}},
+		{s: "oklab(0.6279554 0.2248631 0.1258463)", want: SRGB{R: 1}},
+		{s: "oklch(62.79554% 0.2576833 29.2338851)", want: SRGB{R: 1}},
+		{s: "lab(54.29 80.81 69.89)", want: SRGB{R: 1}},
+		{s: "color(srgb-linear 0 0 1)", want: SRGB{B: 1}},
+		{s: "color(display-p3 0.9175 0.2003 0.1386)", want: SRGB{R: 1}},
+		{s: "color(xyz-d65 0.9505 1 1.089)", want: SRGB{R: 1, G: 1, B: 1}},
+	}
+	for _, test := range tests {
+		got, err := ParseCSSColor(test.s)
+		if err != nil {
+			t.Errorf("ParseCSSColor(%q): %v", test.s, err)
+			continue
+		}
+		if d := sqdist(got.vec(), test.want.vec()); d > 1e-4 {
+			t.Errorf("ParseCSSColor(%q) = %v, want %v", test.s, got, test.want)
+		}
+	}
+	for _, bad := range []string{"", "notacolor", "#12345", "rgb(1 2)", "color(foo 1 2 3)", "hsl(a b c)"} {
+		if _, err := ParseCSSColor(bad); err == nil {
+			t.Errorf("expected error parsing %q", bad)
+		}
+	}
+}
+
+func TestParseCSSLinearGradient(t *testing.T) {
+	g, angle, err := ParseCSSLinearGradient("linear-gradient(to right in oklch longer hue, red, rgb(0 0 255) 40% 60%, 80%, lime)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if angle != 90 {
+		t.Errorf("expected angle 90, got %v", angle)
+	}
+	wantPos := []float32{0, 0.4, 0.6, 1}
+	if len(g.Stops) != len(wantPos) {
+		t.Fatalf("expected %d stops, got %d", len(wantPos), len(g.Stops))
+	}
+	for i, stop := range g.Stops {
+		if math32.Abs(stop.Pos-wantPos[i]) > 1e-6 {
+			t.Errorf("stop %d: want pos %v, got %v", i, wantPos[i], stop.Pos)
+		}
+	}
+	if math32.Abs(g.Stops[2].Hint-0.5) > 1e-6 {
+		t.Errorf("expected hint 0.5, got %v", g.Stops[2].Hint)
+	}
+	if got := LinearGradientPos(angle, 100, 10, 100, 5); math32.Abs(got-1) > 1e-6 {
+		t.Errorf("expected right edge at position 1, got %v", got)
+	}
+	_, angle, err = ParseCSSLinearGradient("linear-gradient(45deg, #000, #fff)")
+	if err != nil || angle != 45 {
+		t.Errorf("expected 45deg gradient, got %v, %v", angle, err)
+	}
+	for _, bad := range []string{"linear-gradient(red)", "linear-gradient(red, 10%, 20%, blue)", "radial-gradient(red, blue)", "linear-gradient(red 10px, blue)"} {
+		if _, _, err := ParseCSSLinearGradient(bad); err == nil {
+			t.Errorf("expected error parsing %q", bad)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package colorspace
+
+import (
+	"image/color"
+	"strings"
+)
+
+// ExtractedColor is a color found in a stylesheet or document. See [ExtractCSSColors].
+type ExtractedColor struct {
+	Color SRGB
+	// Literals are the distinct source literals, i.e: "#f00" and "red", merged into Color.
+	Literals []string
+	// Count is the number of occurrences of all literals.
+	Count int
+}
+
+// ExtractCSSColors scans CSS or SVG text for color literals: hex colors, named colors and the color functions
+// understood by [ParseCSSColor]. Colors within threshold of each other in [OKLAB] are merged into the first
+// color found, a threshold of 0.02 merging roughly indistinguishable colors; use 0 to merge only identical colors.
+// Entries are returned in order of first appearance. Literals that fail to parse are ignored.
+// Named colors are only matched as standalone words so class names like ".red" are not reported, and hex colors
+// only in declaration values, attributes and function arguments so ID selectors like "#fade" are not reported.
+// The contents of url() values are skipped.
+func ExtractCSSColors(text string, threshold float32) []ExtractedColor {
+	var entries []ExtractedColor
+	var labs []OKLAB
+	for _, lit := range scanCSSColorLiterals(text) {
+		c, err := ParseCSSColor(lit)
+		if err != nil {
+			continue
+		}
+		lab := c.LSRGB().CIEXYZ().OKLAB()
+		found := -1
+		for i := range labs {
+			if labs[i].DeltaE(lab) <= threshold {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			entries = append(entries, ExtractedColor{Color: c})
+			labs = append(labs, lab)
+			found = len(entries) - 1
+		}
+		e := &entries[found]
+		e.Count++
+		if !containsString(e.Literals, lit) {
+			e.Literals = append(e.Literals, lit)
+		}
+	}
+	return entries
+}
+
+// CSSPalette returns the colors found by [ExtractCSSColors] as a palette.
+func CSSPalette(text string, threshold float32) color.Palette {
+	entries := ExtractCSSColors(text, threshold)
+	p := make(color.Palette, len(entries))
+	for i, e := range entries {
+		p[i] = e.Color
+	}
+	return p
+}
+
+// scanCSSColorLiterals returns the candidate color literals of text in order of appearance.
+func scanCSSColorLiterals(text string) []string {
+	lower := strings.ToLower(text)
+	var lits []string
+	for i := 0; i < len(lower); {
+		if i > 0 && isCSSIdentByte(lower[i-1]) {
+			i++
+			continue
+		}
+		ch := lower[i]
+		switch {
+		case ch == '#':
+			end := i + 1
+			for end < len(lower) && isHexByte(lower[end]) {
+				end++
+			}
+			n := end - i - 1
+			if (end == len(lower) || !isCSSIdentByte(lower[end])) && (n == 3 || n == 4 || n == 6 || n == 8) && inCSSValue(lower, i, end) {
+				lits = append(lits, text[i:end])
+			}
+			i = end
+		case ch >= 'a' && ch <= 'z':
+			end := i
+			for end < len(lower) && isCSSIdentByte(lower[end]) {
+				end++
+			}
+			ident := lower[i:end]
+			if ident == "url" && end < len(lower) && lower[end] == '(' {
+				// Skip URLs so file names and fragments like url(red.png) are not reported.
+				if close := strings.IndexByte(lower[end:], ')'); close >= 0 {
+					end += close + 1
+				}
+			} else if end < len(lower) && lower[end] == '(' && isCSSColorFunc(ident) {
+				if close := strings.IndexByte(lower[end:], ')'); close >= 0 {
+					end += close + 1
+					lits = append(lits, text[i:end])
+				}
+			} else if _, ok := cssNamedColors[ident]; ok && (i == 0 || lower[i-1] != '.' && lower[i-1] != '#') {
+				lits = append(lits, text[i:end])
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return lits
+}
+
+// inCSSValue reports whether lower[start:end] is in value position: after a property colon, an attribute's
+// equals sign or quote, or inside a function, and not followed by a rule block as selectors are.
+func inCSSValue(lower string, start, end int) bool {
+	if j := strings.IndexAny(lower[end:], "{;}\"'<>"); j >= 0 && lower[end+j] == '{' {
+		return false
+	}
+	i := strings.LastIndexAny(lower[:start], "{;}:(=\"'")
+	return i >= 0 && strings.IndexByte(":(=\"'", lower[i]) >= 0
+}
+
+func isCSSColorFunc(name string) bool {
+	switch name {
+	case "rgb", "rgba", "hsl", "hsla", "lab", "lch", "oklab", "oklch", "color":
+		return true
+	}
+	return false
+}
+
+func isCSSIdentByte(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_'
+}
+
+func isHexByte(ch byte) bool {
+	return ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'f'
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package colorspace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractCSSColors(t *testing.T) {
+	const css = `
+.red { color: #F00; border: 1px solid red; }
+#header { background: rgb(255 0 0 / 50%); fill: oklch(70% 0.1 250); }
+.note { color: #fefefe; background-color: white; }
+<rect fill="Navy" stroke="url(#grad)"/>
+#fade, #bad { color: inherit; }
+a:hover #add {}
+.bg { background: url(red.png) no-repeat; mask: url("#fff"); }
+`
+	got := ExtractCSSColors(css, 0.02)
+	want := []struct {
+		lits  []string
+		count int
+	}{
+		{lits: []string{"#F00", "red", "rgb(255 0 0 / 50%)"}, count: 3},
+		{lits: []string{"oklch(70% 0.1 250)"}, count: 1},
+		{lits: []string{"#fefefe", "white"}, count: 2},
+		{lits: []string{"Navy"}, count: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d colors, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i].Literals, want[i].lits) || got[i].Count != want[i].count {
+			t.Errorf("entry %d: got %v x%d, want %v x%d", i, got[i].Literals, got[i].Count, want[i].lits, want[i].count)
+		}
+	}
+	// Exact matching keeps near white separate from white.
+	if n := len(CSSPalette(css, 0)); n != 5 {
+		t.Errorf("exact palette has %d colors, want 5", n)
+	}
+}
@@ -0,0 +1,70 @@
+package colorspace
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+// Vision is a type of color vision, either normal trichromacy or a color vision deficiency (CVD).
+type Vision uint8
+
+const (
+	VisionNormal Vision = iota
+	// VisionProtanopia lacks long wavelength (red) cones. Reds appear dark and are confused with greens.
+	VisionProtanopia
+	// VisionDeuteranopia lacks medium wavelength (green) cones, the most common CVD. Reds are confused with greens.
+	VisionDeuteranopia
+	// VisionTritanopia lacks short wavelength (blue) cones. Blues are confused with greens and yellows with violets.
+	VisionTritanopia
+	numVisions
+)
+
+// String returns the name of the type of vision.
+func (v Vision) String() string {
+	switch v {
+	case VisionNormal:
+		return "normal"
+	case VisionProtanopia:
+		return "protanopia"
+	case VisionDeuteranopia:
+		return "deuteranopia"
+	case VisionTritanopia:
+		return "tritanopia"
+	}
+	return "Vision(" + strconv.Itoa(int(v)) + ")"
+}
+
+// Full severity simulation matrices in linear sRGB of Machado, Oliveira and Fernandes (2009).
+var cvdMatrices = [numVisions]ms3.Mat3{
+	VisionNormal: ms3.IdentityMat3(),
+	VisionProtanopia: ms3.NewMat3([]float32{
+		0.152286, 1.052583, -0.204868,
+		0.114503, 0.786281, 0.099216,
+		-0.003882, -0.048116, 1.051998,
+	}),
+	VisionDeuteranopia: ms3.NewMat3([]float32{
+		0.367322, 0.860646, -0.227968,
+		0.280085, 0.672501, 0.047413,
+		-0.011820, 0.042940, 0.968881,
+	}),
+	VisionTritanopia: ms3.NewMat3([]float32{
+		1.255528, -0.076749, -0.178779,
+		-0.078411, 0.930809, 0.147602,
+		0.004733, 0.691367, 0.309900,
+	}),
+}
+
+// SimulateCVD returns how the color c appears to a person with vision v using the model of Machado,
+// Oliveira and Fernandes (2009). severity in [0,1] blends between normal vision at 0 and
+// dichromacy at 1 in linear light, which approximates the anomalous trichromacy of the model.
+func SimulateCVD(c color.Color, v Vision, severity float32) SRGB {
+	if v == VisionNormal || v >= numVisions {
+		return ColorToSRGB(c)
+	}
+	lin := ColorToSRGB(c).LSRGB().vec()
+	sim := ms3.MulMatVec(cvdMatrices[v], lin)
+	mixed := ms3.Add(lin, ms3.Scale(clamp01(severity), ms3.Sub(sim, lin)))
+	return LSRGB{R: mixed.X, G: mixed.Y, B: mixed.Z}.ClipToGamut().SRGB()
+}
@@ -0,0 +1,27 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestSimulateCVD(t *testing.T) {
+	red, green := color.RGBA{R: 0xd0, G: 0x30, B: 0x30, A: 0xff}, color.RGBA{R: 0x40, G: 0x90, B: 0x20, A: 0xff}
+	normal := colorToOKLAB(red).DeltaE(colorToOKLAB(green))
+	for _, v := range []Vision{VisionProtanopia, VisionDeuteranopia} {
+		d := colorToOKLAB(SimulateCVD(red, v, 1)).DeltaE(colorToOKLAB(SimulateCVD(green, v, 1)))
+		if d > 0.6*normal {
+			t.Errorf("%v: red and green differ by %v, want much less than %v", v, d, normal)
+		}
+	}
+	gray := color.Gray{Y: 0x80}
+	for v := VisionNormal; v < numVisions; v++ {
+		got := SimulateCVD(gray, v, 1)
+		if d := colorToOKLAB(got).DeltaE(colorToOKLAB(gray)); d > 0.01 {
+			t.Errorf("%v changes gray to %v", v, got)
+		}
+		if got, want := SimulateCVD(red, v, 0), ColorToSRGB(red); sqdist(got.vec(), want.vec()) > 1e-10 {
+			t.Errorf("%v at zero severity changes %v to %v", v, want, got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package colorspace
+
+import "image/color"
+
+// ColorCycle yields successive plot series colors from a palette, skipping colors that are too similar
+// to the canvas background, foreground text or gridlines for series to stand out from them.
+// Create it with [NewColorCycle]. The zero value yields no colors.
+type ColorCycle struct {
+	colors []color.Color // Usable palette colors in order.
+	next   int
+}
+
+// NewColorCycle returns a cycle over palette skipping colors within minDeltaE (OKLAB Euclidean distance,
+// see [OKLAB.DeltaE]) of any color in avoid, i.e: the background and gridline colors. A minDeltaE of
+// 0.1 keeps series clearly distinguishable from the avoided colors.
+// If every palette color is too close to the avoided colors the single palette color furthest
+// from them is used so the cycle always yields colors given a non-empty palette.
+func NewColorCycle(palette []color.Color, avoid []color.Color, minDeltaE float32) *ColorCycle {
+	avoidLab := make([]OKLAB, len(avoid))
+	for i, c := range avoid {
+		avoidLab[i] = colorToOKLAB(c)
+	}
+	cc := &ColorCycle{}
+	best, bestDist := -1, float32(-1)
+	for i, c := range palette {
+		lab := colorToOKLAB(c)
+		dist := float32(-1) // Closest avoided color, -1 if none.
+		for _, a := range avoidLab {
+			if d := lab.DeltaE(a); dist < 0 || d < dist {
+				dist = d
+			}
+		}
+		if dist < 0 || dist >= minDeltaE {
+			cc.colors = append(cc.colors, c)
+		} else if dist > bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	if len(cc.colors) == 0 && best >= 0 {
+		cc.colors = append(cc.colors, palette[best])
+	}
+	return cc
+}
+
+// Next returns the next series color, wrapping around to the first usable color after the last.
+// Next returns nil if the cycle has no colors.
+func (cc *ColorCycle) Next() color.Color {
+	if len(cc.colors) == 0 {
+		return nil
+	}
+	c := cc.colors[cc.next]
+	cc.next = (cc.next + 1) % len(cc.colors)
+	return c
+}
+
+// Reset restarts the cycle so the next call to Next returns the first usable color.
+func (cc *ColorCycle) Reset() { cc.next = 0 }
+
+// Len returns the number of usable colors before the cycle repeats.
+func (cc *ColorCycle) Len() int { return len(cc.colors) }
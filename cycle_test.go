@@ -0,0 +1,33 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestColorCycle(t *testing.T) {
+	white, black := SRGB{R: 1, G: 1, B: 1}, SRGB{}
+	palette := []color.Color{SRGB{R: 1}, SRGB{R: 0.98, G: 0.98, B: 0.98}, SRGB{B: 1}, SRGB{R: 0.01, G: 0.01, B: 0.01}}
+	cc := NewColorCycle(palette, []color.Color{white, black}, 0.15)
+	if cc.Len() != 2 {
+		t.Fatalf("expected near white and near black to be skipped, got %d colors", cc.Len())
+	}
+	want := []color.Color{SRGB{R: 1}, SRGB{B: 1}, SRGB{R: 1}}
+	for i, w := range want {
+		if got := cc.Next(); got != w {
+			t.Errorf("color %d: got %v, want %v", i, got, w)
+		}
+	}
+	cc.Reset()
+	if got := cc.Next(); got != (SRGB{R: 1}) {
+		t.Errorf("after reset got %v", got)
+	}
+	// All colors collide: the furthest one is kept.
+	cc = NewColorCycle(palette[1:2], []color.Color{white}, 0.5)
+	if cc.Len() != 1 || cc.Next() != palette[1] {
+		t.Errorf("expected fallback to the furthest color")
+	}
+	if got := new(ColorCycle).Next(); got != nil {
+		t.Errorf("zero cycle yielded %v", got)
+	}
+}
@@ -0,0 +1,73 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// DarkMode derives dark theme counterparts of light theme colors. Naively inverting colors produces
+// garish, oversaturated results with different contrast. Instead DarkMode maps the light background
+// to a dark background and finds for every color the lightness whose WCAG [ContrastRatio] against the dark
+// background equals its contrast against the light background, so text and accents remain as legible as in
+// the light theme. Lightness ordering is thereby inverted while hue is kept and chroma is compressed.
+type DarkMode struct {
+	// Background is the OKLCH lightness of the dark background. 0.2 is a common choice;
+	// pure black causes smearing on OLED displays and excessive contrast.
+	Background float32
+	// ChromaScale multiplies the chroma of every color since saturated colors vibrate against dark backgrounds.
+	ChromaScale float32
+}
+
+// NewDarkMode returns a DarkMode with a 0.2 lightness background and chroma reduced by 20%.
+func NewDarkMode() DarkMode {
+	return DarkMode{Background: 0.2, ChromaScale: 0.8}
+}
+
+// Palette returns the dark counterpart of each color of the light palette. The lightest color
+// of the palette is taken as the light background and is mapped to the dark background.
+func (d DarkMode) Palette(light color.Palette) color.Palette {
+	if len(light) == 0 {
+		return nil
+	}
+	bg := light[0]
+	ybg := ColorToSRGB(bg).RelativeLuminance()
+	for _, c := range light[1:] {
+		if y := ColorToSRGB(c).RelativeLuminance(); y > ybg {
+			bg, ybg = c, y
+		}
+	}
+	dark := make(color.Palette, len(light))
+	for i, c := range light {
+		dark[i] = d.Color(c, bg)
+	}
+	return dark
+}
+
+// Color returns the dark theme counterpart of c which appears over lightBg in the light theme.
+// See [DarkMode].
+func (d DarkMode) Color(c, lightBg color.Color) SRGB {
+	darkBg := d.background(lightBg)
+	lch := colorToOKLCH(c)
+	lch.C *= d.ChromaScale
+	target := ContrastRatio(c, lightBg)
+	ydark := darkBg.RelativeLuminance()
+	// Bisect lightness above the dark background for the target contrast.
+	lo, hi := colorToOKLCH(darkBg).L, float32(1)
+	for i := 0; i < 24; i++ {
+		lch.L = 0.5 * (lo + hi)
+		if contrastRatio(oklchToSRGB(lch).RelativeLuminance(), ydark) < target {
+			lo = lch.L
+		} else {
+			hi = lch.L
+		}
+	}
+	lch.L = hi
+	return oklchToSRGB(lch)
+}
+
+// background returns the dark background corresponding to the light background lightBg, keeping its tint.
+func (d DarkMode) background(lightBg color.Color) SRGB {
+	lch := colorToOKLCH(lightBg)
+	return oklchToSRGB(OKLCH{L: d.Background, C: math32.Max(lch.C*d.ChromaScale, 0), H: lch.H})
+}
@@ -0,0 +1,41 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestDarkMode(t *testing.T) {
+	light := color.Palette{
+		SRGB{R: 1, G: 1, B: 1},          // Background.
+		SRGB{R: 0.1, G: 0.1, B: 0.12},   // Body text.
+		SRGB{R: 0.45, G: 0.45, B: 0.5},  // Secondary text.
+		SRGB{R: 0.05, G: 0.35, B: 0.85}, // Link.
+	}
+	dm := NewDarkMode()
+	dark := dm.Palette(light)
+	bg := dark[0]
+	if l := colorToOKLCH(bg).L; math32.Abs(l-dm.Background) > 0.01 {
+		t.Errorf("dark background lightness %v, want %v", l, dm.Background)
+	}
+	for i := 1; i < len(light); i++ {
+		want := ContrastRatio(light[i], light[0])
+		got := ContrastRatio(dark[i], bg)
+		if math32.Abs(got-want) > 0.1 {
+			t.Errorf("color %d: dark contrast %v, want %v", i, got, want)
+		}
+		if colorToOKLCH(dark[i]).L <= colorToOKLCH(bg).L {
+			t.Errorf("color %d is not lighter than the dark background", i)
+		}
+	}
+	// Lightness ordering is inverted: body text becomes lighter than secondary text.
+	if colorToOKLCH(dark[1]).L <= colorToOKLCH(dark[2]).L {
+		t.Error("lightness ordering not inverted")
+	}
+	link, darkLink := colorToOKLCH(light[3]), colorToOKLCH(dark[3])
+	if math32.Abs(link.H-darkLink.H) > 5 {
+		t.Errorf("link hue changed from %v to %v", link.H, darkLink.H)
+	}
+}
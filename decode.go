@@ -0,0 +1,217 @@
+package colorspace
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+var errImageFormat = errors.New("unsupported image format: only JPEG and PNG are supported")
+
+// DecodeImageSRGB decodes a JPEG or PNG image and converts its pixels from the color space declared by its
+// embedded ICC profile or EXIF color space tag to sRGB, so Adobe RGB or Display P3 tagged files are not
+// silently interpreted as sRGB. Untagged images are assumed to be sRGB. Out of gamut colors are clipped.
+// See [ImageProfile] for the supported metadata. Images with an embedded profile that is damaged or not
+// supported, i.e: CMYK, gray or LUT-based profiles, are decoded as untagged sRGB images as most viewers do.
+func DecodeImageSRGB(r io.Reader) (*image.RGBA64, error) {
+	img, profile, err := decodeTagged(r)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return mapImageSRGB(img, func(c SRGB) SRGB { return c }), nil
+	}
+	return mapImageSRGB(img, func(c SRGB) SRGB {
+		return profile.CIEXYZ(c.R, c.G, c.B).LSRGB().ClipToGamut().SRGB()
+	}), nil
+}
+
+// DecodeImageLinear is like [DecodeImageSRGB] but returns the pixels in linear light sRGB without clipping,
+// so colors outside the sRGB gamut are preserved as channel values outside [0,1].
+func DecodeImageLinear(r io.Reader) (*LinearRGBAImage, error) {
+	img, profile, err := decodeTagged(r)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return PremultiplyImageLinear(img), nil
+	}
+	bounds := img.Bounds()
+	dst := NewLinearRGBAImage(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			dst.SetLinearRGBA(x, y, premultiplyLSRGB(profile.CIEXYZ(c.R, c.G, c.B).LSRGB(), a))
+		}
+	}
+	return dst, nil
+}
+
+// ImageProfile returns the color profile of the encoded JPEG or PNG image data. It reads embedded ICC profiles
+// (JPEG APP2 segments and the PNG iCCP chunk), the EXIF ColorSpace and interoperability tags
+// of JPEG files, which identify Adobe RGB images written by cameras without embedding a profile,
+// and the PNG gAMA and cHRM chunks. See [PNGColorInfo].
+// It returns a nil profile for untagged and sRGB images.
+func ImageProfile(data []byte) (*ICCProfile, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return pngProfile(data)
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		return jpegProfile(data)
+	}
+	return nil, errImageFormat
+}
+
+func decodeTagged(r io.Reader) (image.Image, *ICCProfile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, err := ImageProfile(data)
+	if err == errImageFormat {
+		return nil, nil, err
+	} else if err != nil {
+		profile = nil // Fall back to sRGB for unusable profiles.
+	}
+	var img image.Image
+	if data[0] == 0x89 {
+		img, err = png.Decode(bytes.NewReader(data))
+	} else {
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	}
+	return img, profile, err
+}
+
+func pngProfile(data []byte) (*ICCProfile, error) {
+	chunks, err := pngColorChunks(data)
+	switch {
+	case err != nil:
+		return nil, err
+	case chunks.iccp != nil:
+		// Profile name, NUL, compression method and zlib stream.
+		nul := bytes.IndexByte(chunks.iccp, 0)
+		if nul < 0 || nul+2 > len(chunks.iccp) {
+			return nil, errICCProfile
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(chunks.iccp[nul+2:]))
+		if err != nil {
+			return nil, err
+		}
+		icc, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+		return ParseICCProfile(icc)
+	case chunks.sRGB:
+		return nil, nil
+	case chunks.gAMA || chunks.cHRM:
+		// gAMA and cHRM are only used in absence of iCCP and sRGB chunks.
+		return chunks.info.RGBSpace().profile("PNG gAMA/cHRM"), nil
+	}
+	return nil, nil
+}
+
+func jpegProfile(data []byte) (*ICCProfile, error) {
+	var chunks [][]byte
+	var exif []byte
+	for p := data[2:]; len(p) >= 4 && p[0] == 0xff; {
+		marker := p[1]
+		if marker == 0xda || marker == 0xd9 { // Start of scan or end of image.
+			break
+		}
+		n := int(binary.BigEndian.Uint16(p[2:]))
+		if n < 2 || n+2 > len(p) {
+			return nil, errors.New("truncated JPEG segment")
+		}
+		body := p[4 : 2+n]
+		switch {
+		case marker == 0xe2 && bytes.HasPrefix(body, []byte("ICC_PROFILE\x00")) && len(body) >= 14:
+			seq, total := int(body[12]), int(body[13])
+			if chunks == nil {
+				chunks = make([][]byte, total)
+			}
+			if seq >= 1 && seq <= len(chunks) {
+				chunks[seq-1] = body[14:]
+			}
+		case marker == 0xe1 && bytes.HasPrefix(body, []byte("Exif\x00\x00")):
+			exif = body[6:]
+		}
+		p = p[2+n:]
+	}
+	if chunks != nil {
+		return ParseICCProfile(bytes.Join(chunks, nil))
+	}
+	if exif != nil && exifIsAdobeRGB(exif) {
+		return adobeRGBProfile(), nil
+	}
+	return nil, nil
+}
+
+// exifIsAdobeRGB reports whether the EXIF TIFF structure declares an uncalibrated color space
+// with the "R03" (Adobe RGB) interoperability index as specified by DCF.
+func exifIsAdobeRGB(tiff []byte) bool {
+	if len(tiff) < 8 {
+		return false
+	}
+	var bo binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(tiff, []byte("II*\x00")):
+		bo = binary.LittleEndian
+	case bytes.HasPrefix(tiff, []byte("MM\x00*")):
+		bo = binary.BigEndian
+	default:
+		return false
+	}
+	// ifdEntry returns the value field of the given tag in the IFD at offset.
+	ifdEntry := func(offset uint32, tag uint16) ([]byte, bool) {
+		if uint64(offset)+2 > uint64(len(tiff)) {
+			return nil, false
+		}
+		n := int(bo.Uint16(tiff[offset:]))
+		for i := 0; i < n; i++ {
+			e := int(offset) + 2 + 12*i
+			if e+12 > len(tiff) {
+				return nil, false
+			}
+			if bo.Uint16(tiff[e:]) == tag {
+				return tiff[e+8 : e+12], true
+			}
+		}
+		return nil, false
+	}
+	exifPtr, ok := ifdEntry(bo.Uint32(tiff[4:]), 0x8769)
+	if !ok {
+		return false
+	}
+	exifIFD := bo.Uint32(exifPtr)
+	cs, ok := ifdEntry(exifIFD, 0xa001)
+	if !ok || bo.Uint16(cs) != 0xffff {
+		return false // sRGB or missing.
+	}
+	interopPtr, ok := ifdEntry(exifIFD, 0xa005)
+	if !ok {
+		return false
+	}
+	index, ok := ifdEntry(bo.Uint32(interopPtr), 0x0001)
+	return ok && string(index[:3]) == "R03"
+}
+
+// adobeRGBProfile returns the Adobe RGB (1998) color space with its colorants adapted to D50 as in Adobe's profile.
+func adobeRGBProfile() *ICCProfile {
+	gamma := TransferGamma(563. / 256)
+	return &ICCProfile{
+		Description: "Adobe RGB (1998)",
+		Red:         CIEXYZ{X: 0.6097, Y: 0.3111, Z: 0.0195},
+		Green:       CIEXYZ{X: 0.2053, Y: 0.6257, Z: 0.0609},
+		Blue:        CIEXYZ{X: 0.1492, Y: 0.0632, Z: 0.7446},
+		TRC:         [3]TransferFunction{gamma, gamma, gamma},
+	}
+}
@@ -0,0 +1,188 @@
+package colorspace
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+// buildICCProfile returns a minimal version 2 RGB matrix/TRC profile with a pure gamma curve.
+func buildICCProfile(desc string, p *ICCProfile, gamma float32) []byte {
+	be := binary.BigEndian
+	xyzTag := func(c CIEXYZ) []byte {
+		b := append([]byte("XYZ "), 0, 0, 0, 0)
+		for _, v := range c.Array() {
+			b = be.AppendUint32(b, uint32(int32(math32.Round(v*65536))))
+		}
+		return b
+	}
+	curv := be.AppendUint32(append([]byte("curv"), 0, 0, 0, 0), 1)
+	curv = be.AppendUint16(curv, uint16(math32.Round(gamma*256)))
+	descTag := be.AppendUint32(append([]byte("desc"), 0, 0, 0, 0), uint32(len(desc)+1))
+	descTag = append(append(descTag, desc...), 0)
+	tags := []struct {
+		sig  string
+		data []byte
+	}{
+		{"desc", descTag}, {"rXYZ", xyzTag(p.Red)}, {"gXYZ", xyzTag(p.Green)}, {"bXYZ", xyzTag(p.Blue)},
+		{"rTRC", curv}, {"gTRC", curv}, {"bTRC", curv},
+	}
+	header := make([]byte, 128)
+	copy(header[12:], "mntr")
+	copy(header[16:], "RGB XYZ ")
+	copy(header[36:], "acsp")
+	table := be.AppendUint32(nil, uint32(len(tags)))
+	var body []byte
+	offset := 128 + 4 + 12*len(tags)
+	for _, tag := range tags {
+		table = append(table, tag.sig...)
+		table = be.AppendUint32(table, uint32(offset+len(body)))
+		table = be.AppendUint32(table, uint32(len(tag.data)))
+		body = append(body, tag.data...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+	data := append(append(header, table...), body...)
+	be.PutUint32(data, uint32(len(data)))
+	return data
+}
+
+func TestParseICCProfile(t *testing.T) {
+	adobe := adobeRGBProfile()
+	p, err := ParseICCProfile(buildICCProfile("Adobe RGB (1998)", adobe, 2.2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Description != "Adobe RGB (1998)" {
+		t.Errorf("unexpected description %q", p.Description)
+	}
+	if sqdist(p.Green.vec(), adobe.Green.vec()) > 1e-8 {
+		t.Errorf("green colorant %+v, want %+v", p.Green, adobe.Green)
+	}
+	if v := p.TRC[0].ToLinear(0.5); math32.Abs(v-math32.Pow(0.5, 2.2)) > 1e-3 {
+		t.Errorf("unexpected TRC value %v", v)
+	}
+	// The colorants add up to the D50 white which is converted to D65 white.
+	white := p.CIEXYZ(1, 1, 1).LSRGB()
+	if sqdist(white.vec(), LSRGB{R: 1, G: 1, B: 1}.vec()) > 1e-4 {
+		t.Errorf("profile white is %+v in linear sRGB", white)
+	}
+	if _, err := ParseICCProfile([]byte("not a profile")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// pngWithICCP returns the encoded PNG with an iCCP chunk holding icc inserted after IHDR.
+func pngWithICCP(encoded, icc []byte) []byte {
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	zw.Write(icc)
+	zw.Close()
+	chunk := append([]byte("iCCP"), append([]byte("icc\x00\x00"), zbuf.Bytes()...)...)
+	const ihdrEnd = 8 + 25
+	var tagged []byte
+	tagged = append(tagged, encoded[:ihdrEnd]...)
+	tagged = binary.BigEndian.AppendUint32(tagged, uint32(len(chunk)-4))
+	tagged = append(tagged, chunk...)
+	tagged = binary.BigEndian.AppendUint32(tagged, crc32.ChecksumIEEE(chunk))
+	return append(tagged, encoded[ihdrEnd:]...)
+}
+
+func TestDecodeImageTagged(t *testing.T) {
+	pixel := color.NRGBA{R: 51, G: 153, B: 102, A: 255}
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, pixel)
+	adobe := adobeRGBProfile()
+	want := adobe.CIEXYZ(0.2, 0.6, 0.4).LSRGB()
+
+	var buf bytes.Buffer
+	png.Encode(&buf, src)
+	tagged := pngWithICCP(buf.Bytes(), buildICCProfile("Adobe RGB (1998)", adobe, 563./256))
+
+	lin, err := DecodeImageLinear(bytes.NewReader(tagged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := lin.LinearRGBAAt(0, 0).Unpremultiply()
+	if sqdist(got.vec(), want.vec()) > 1e-4 {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+	untagged := RGB8ToSRGB(pixel.R, pixel.G, pixel.B).LSRGB()
+	if sqdist(untagged.vec(), want.vec()) < 1e-3 {
+		t.Fatal("test color does not discriminate Adobe RGB from sRGB")
+	}
+	srgb, err := DecodeImageSRGB(bytes.NewReader(tagged))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := srgb.At(0, 0).RGBA(); absDiffU32(r, 0) > 0x200 || g < 0x8000 || b > g {
+		t.Errorf("unexpected sRGB pixel %v", srgb.At(0, 0))
+	}
+
+	// JPEG with EXIF declaring Adobe RGB via the R03 interoperability index.
+	buf.Reset()
+	jpeg.Encode(&buf, src, nil)
+	le := binary.LittleEndian
+	tiff := []byte("II*\x00")
+	tiff = le.AppendUint32(tiff, 8)
+	tiff = le.AppendUint16(tiff, 1) // IFD0 at 8.
+	tiff = append(le.AppendUint16(le.AppendUint16(tiff, 0x8769), 4), 1, 0, 0, 0)
+	tiff = le.AppendUint32(le.AppendUint32(tiff, 26), 0)
+	tiff = le.AppendUint16(tiff, 2) // Exif IFD at 26.
+	tiff = append(le.AppendUint16(le.AppendUint16(tiff, 0xa001), 3), 1, 0, 0, 0, 0xff, 0xff, 0, 0)
+	tiff = append(le.AppendUint16(le.AppendUint16(tiff, 0xa005), 4), 1, 0, 0, 0)
+	tiff = le.AppendUint32(le.AppendUint32(tiff, 56), 0)
+	tiff = le.AppendUint16(tiff, 1) // Interop IFD at 56.
+	tiff = append(le.AppendUint16(le.AppendUint16(tiff, 0x0001), 2), 4, 0, 0, 0, 'R', '0', '3', 0)
+	tiff = le.AppendUint32(tiff, 0)
+	app1 := append([]byte("Exif\x00\x00"), tiff...)
+	jpg := append([]byte{0xff, 0xd8, 0xff, 0xe1}, byte((len(app1)+2)>>8), byte(len(app1)+2))
+	jpg = append(append(jpg, app1...), buf.Bytes()[2:]...)
+	profile, err := ImageProfile(jpg)
+	if err != nil || profile == nil || profile.Description != "Adobe RGB (1998)" {
+		t.Errorf("EXIF Adobe RGB not detected: %v %v", profile, err)
+	}
+	if _, err := DecodeImageSRGB(bytes.NewReader(jpg)); err != nil {
+		t.Error(err)
+	}
+	if profile, err := ImageProfile(buf.Bytes()); profile != nil || err != nil {
+		t.Errorf("untagged JPEG returned profile %v, %v", profile, err)
+	}
+}
+
+func TestDecodeImageUnsupportedProfile(t *testing.T) {
+	pixel := color.NRGBA{R: 51, G: 153, B: 102, A: 255}
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	src.SetNRGBA(0, 0, pixel)
+	var buf bytes.Buffer
+	png.Encode(&buf, src)
+	gray := buildICCProfile("Gray Gamma 2.2", adobeRGBProfile(), 2.2)
+	copy(gray[16:], "GRAY")
+	want := RGB8ToSRGB(pixel.R, pixel.G, pixel.B)
+	for name, icc := range map[string][]byte{"gray": gray, "truncated": gray[:100]} {
+		tagged := pngWithICCP(buf.Bytes(), icc)
+		if _, err := ImageProfile(tagged); err == nil {
+			t.Errorf("%s: expected ImageProfile error", name)
+		}
+		img, err := DecodeImageSRGB(bytes.NewReader(tagged))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if got := ColorToSRGB(img.At(0, 0)); sqdist(got.vec(), want.vec()) > 1e-6 {
+			t.Errorf("%s: decoded %v, want untagged %v", name, got, want)
+		}
+		if _, err := DecodeImageLinear(bytes.NewReader(tagged)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// DeltaE2000 returns the CIEDE2000 color difference between two CIELAB colors.
+// CIEDE2000 corrects the perceptual non-uniformities of plain Euclidean distance in [CIELAB]
+// (CIE76) and is the industry standard for color difference. A difference of about 1 is
+// the smallest perceptible by a trained observer. Parametric weighting factors kL, kC, kH are all 1.
+func (reference CIELAB) DeltaE2000(sample CIELAB) float32 {
+	const (
+		deg     = math32.Pi / 180
+		pow25_7 = 6103515625 // 25^7
+	)
+	L1, a1, b1 := reference.L, reference.A, reference.B
+	L2, a2, b2 := sample.L, sample.A, sample.B
+	C1 := math32.Hypot(a1, b1)
+	C2 := math32.Hypot(a2, b2)
+	Cbar7 := math32.Pow(0.5*(C1+C2), 7)
+	G := 0.5 * (1 - math32.Sqrt(Cbar7/(Cbar7+pow25_7)))
+	a1p := (1 + G) * a1
+	a2p := (1 + G) * a2
+	C1p := math32.Hypot(a1p, b1)
+	C2p := math32.Hypot(a2p, b2)
+	h1p := hueAngle(b1, a1p)
+	h2p := hueAngle(b2, a2p)
+
+	dLp := L2 - L1
+	dCp := C2p - C1p
+	var dhp float32
+	if C1p*C2p != 0 {
+		dhp = h2p - h1p
+		if dhp > 180 {
+			dhp -= 360
+		} else if dhp < -180 {
+			dhp += 360
+		}
+	}
+	dHp := 2 * math32.Sqrt(C1p*C2p) * math32.Sin(dhp*deg/2)
+
+	Lbarp := 0.5 * (L1 + L2)
+	Cbarp := 0.5 * (C1p + C2p)
+	hbarp := h1p + h2p
+	if C1p*C2p != 0 {
+		if math32.Abs(h1p-h2p) > 180 {
+			if hbarp < 360 {
+				hbarp += 360
+			} else {
+				hbarp -= 360
+			}
+		}
+		hbarp *= 0.5
+	}
+	T := 1 - 0.17*math32.Cos((hbarp-30)*deg) + 0.24*math32.Cos(2*hbarp*deg) +
+		0.32*math32.Cos((3*hbarp+6)*deg) - 0.20*math32.Cos((4*hbarp-63)*deg)
+	dTheta := 30 * math32.Exp(-sq((hbarp-275)/25))
+	Cbarp7 := math32.Pow(Cbarp, 7)
+	RC := 2 * math32.Sqrt(Cbarp7/(Cbarp7+pow25_7))
+	Lm50 := sq(Lbarp - 50)
+	SL := 1 + 0.015*Lm50/math32.Sqrt(20+Lm50)
+	SC := 1 + 0.045*Cbarp
+	SH := 1 + 0.015*Cbarp*T
+	RT := -math32.Sin(2*dTheta*deg) * RC
+
+	dL := dLp / SL
+	dC := dCp / SC
+	dH := dHp / SH
+	return math32.Sqrt(dL*dL + dC*dC + dH*dH + RT*dC*dH)
+}
+
+// hueAngle returns the angle of the (a, b) vector in degrees in [0,360).
+func hueAngle(b, a float32) float32 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	return wrapHue(math32.Atan2(b, a) * 180 / math32.Pi)
+}
+
+func sq(a float32) float32 { return a * a }
@@ -0,0 +1,31 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestDeltaE2000(t *testing.T) {
+	// Test data from Sharma, Wu and Dalal (2005).
+	tests := []struct {
+		c1, c2 CIELAB
+		want   float32
+	}{
+		{c1: CIELAB{L: 50, A: 2.6772, B: -79.7751}, c2: CIELAB{L: 50, A: 0, B: -82.7485}, want: 2.0425},
+		{c1: CIELAB{L: 50, A: -1, B: 2}, c2: CIELAB{L: 50, A: 0, B: 0}, want: 2.3669},
+		{c1: CIELAB{L: 50, A: 2.49, B: -0.001}, c2: CIELAB{L: 50, A: -2.49, B: 0.0009}, want: 7.1792},
+		{c1: CIELAB{L: 60.2574, A: -34.0099, B: 36.2677}, c2: CIELAB{L: 60.4626, A: -34.1751, B: 39.4387}, want: 1.2644},
+		{c1: CIELAB{L: 22.7233, A: 20.0904, B: -46.694}, c2: CIELAB{L: 23.0331, A: 14.973, B: -42.5619}, want: 2.0373},
+		{c1: CIELAB{L: 2.0776, A: 0.0795, B: -1.135}, c2: CIELAB{L: 0.9033, A: -0.0636, B: -0.5514}, want: 0.9082},
+	}
+	for _, test := range tests {
+		got := test.c1.DeltaE2000(test.c2)
+		if math32.Abs(got-test.want) > 1e-3 {
+			t.Errorf("DeltaE2000(%v, %v) = %v, want %v", test.c1, test.c2, got, test.want)
+		}
+		if reversed := test.c2.DeltaE2000(test.c1); math32.Abs(reversed-got) > 1e-4 {
+			t.Errorf("DeltaE2000 not symmetric: %v != %v", got, reversed)
+		}
+	}
+}
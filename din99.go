@@ -0,0 +1,107 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// DIN99 is the color space of DIN 6176 obtained by logarithmically compressing the lightness and chroma
+// of [CIELAB] and rotating its opponent axes. Euclidean distance in DIN99 approximates the CIEDE2000
+// difference at a fraction of its cost, which suits nearest color searches. See [DIN99.DeltaE].
+type DIN99 struct {
+	L float32 // Lightness in [0,100].
+	A float32 // Red-green axis.
+	B float32 // Yellow-blue axis.
+}
+
+// DIN99d is the DIN99 variant of Cui et al. with a modified X tristimulus value that improves uniformity
+// for blue colors. It is computed from XYZ rather than CIELAB. See [CIEXYZ.DIN99d].
+type DIN99d struct {
+	L float32 // Lightness in [0,100].
+	A float32 // Red-green axis.
+	B float32 // Yellow-blue axis.
+}
+
+func (c DIN99) vec() ms3.Vec       { return ms3.Vec{X: c.L, Y: c.A, Z: c.B} }
+func (c DIN99d) vec() ms3.Vec      { return ms3.Vec{X: c.L, Y: c.A, Z: c.B} }
+func (c DIN99) Array() [3]float32  { return c.vec().Array() }
+func (c DIN99d) Array() [3]float32 { return c.vec().Array() }
+
+// DeltaE returns the DIN99 color difference, the Euclidean distance between the colors.
+func (c DIN99) DeltaE(other DIN99) float32 {
+	return ms3.Norm(ms3.Sub(c.vec(), other.vec()))
+}
+
+// DeltaE returns the DIN99d color difference, the Euclidean distance between the colors.
+func (c DIN99d) DeltaE(other DIN99d) float32 {
+	return ms3.Norm(ms3.Sub(c.vec(), other.vec()))
+}
+
+// din99Params are the constants of a DIN99 formula.
+type din99Params struct {
+	l1, l2     float32 // Lightness scale and compression.
+	rotation   float32 // Rotation of the a, b axes in degrees.
+	f          float32 // Scale of the second rotated axis.
+	c1, c2     float32 // Chroma scale and compression.
+	hueRotated bool    // The rotation is added back to the hue.
+}
+
+var (
+	din99Formula  = din99Params{l1: 105.51, l2: 0.0158, rotation: 16, f: 0.7, c1: 1 / 0.045, c2: 0.045}
+	din99dFormula = din99Params{l1: 325.22, l2: 0.0036, rotation: 50, f: 1.14, c1: 22.5, c2: 0.06, hueRotated: true}
+)
+
+// DIN99 converts the CIELAB color to DIN99.
+func (c CIELAB) DIN99() DIN99 { return DIN99(din99Formula.forward(c)) }
+
+// CIELAB converts the DIN99 color to CIELAB.
+func (c DIN99) CIELAB() CIELAB { return din99Formula.inverse(CIELAB(c)) }
+
+// DIN99d converts D65 relative XYZ to DIN99d.
+func (c CIEXYZ) DIN99d() DIN99d {
+	lab := din99dModifyX(c.vec()).cielab(din99dModifyX(d65).vec())
+	return DIN99d(din99dFormula.forward(lab))
+}
+
+// CIEXYZ converts the DIN99d color to D65 relative XYZ.
+func (c DIN99d) CIEXYZ() CIEXYZ {
+	xyz := din99dFormula.inverse(CIELAB(c)).ciexyz(din99dModifyX(d65).vec())
+	xyz.X = (xyz.X + 0.12*xyz.Z) / 1.12
+	return xyz
+}
+
+// din99dModifyX returns XYZ with the modified X' = 1.12X - 0.12Z of DIN99d.
+func din99dModifyX(v ms3.Vec) CIEXYZ {
+	return CIEXYZ{X: 1.12*v.X - 0.12*v.Z, Y: v.Y, Z: v.Z}
+}
+
+func (p din99Params) forward(lab CIELAB) CIELAB {
+	sin, cos := math32.Sincos(p.rotation * math32.Pi / 180)
+	e := lab.A*cos + lab.B*sin
+	f := p.f * (-lab.A*sin + lab.B*cos)
+	g := math32.Hypot(e, f)
+	chroma := p.c1 * math32.Log1p(p.c2*g)
+	h := math32.Atan2(f, e)
+	if p.hueRotated {
+		h += p.rotation * math32.Pi / 180
+	}
+	hsin, hcos := math32.Sincos(h)
+	return CIELAB{L: p.l1 * math32.Log1p(p.l2*lab.L), A: chroma * hcos, B: chroma * hsin}
+}
+
+func (p din99Params) inverse(din CIELAB) CIELAB {
+	chroma := math32.Hypot(din.A, din.B)
+	g := math32.Expm1(chroma/p.c1) / p.c2
+	h := math32.Atan2(din.B, din.A)
+	if p.hueRotated {
+		h -= p.rotation * math32.Pi / 180
+	}
+	hsin, hcos := math32.Sincos(h)
+	e, f := g*hcos, g*hsin/p.f
+	sin, cos := math32.Sincos(p.rotation * math32.Pi / 180)
+	return CIELAB{
+		L: math32.Expm1(din.L/p.l1) / p.l2,
+		A: e*cos - f*sin,
+		B: e*sin + f*cos,
+	}
+}
@@ -0,0 +1,60 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+func TestDIN99RoundTrip(t *testing.T) {
+	labs := []CIELAB{
+		{L: 50, A: 2.6772, B: -79.7751},
+		{L: 60.2574, A: -34.0099, B: 36.2677},
+		{L: 2.0776, A: 0.0795, B: -1.135},
+		{L: 95, A: -10, B: 80},
+		{L: 30, A: 60, B: 20},
+	}
+	for _, lab := range labs {
+		got := lab.DIN99().CIELAB()
+		if sqdist(got.vec(), lab.vec()) > 1e-6 {
+			t.Errorf("DIN99 round trip of %v = %v", lab, got)
+		}
+		xyz := lab.CIEXYZ().d50ToD65()
+		gotXYZ := xyz.DIN99d().CIEXYZ()
+		if sqdist(gotXYZ.vec(), xyz.vec()) > 1e-9 {
+			t.Errorf("DIN99d round trip of %v = %v", xyz, gotXYZ)
+		}
+	}
+}
+
+func TestDIN99White(t *testing.T) {
+	white := CIELAB{L: 100}.DIN99()
+	if sqdist(white.vec(), ms3.Vec{X: 100}) > 1e-4 {
+		t.Errorf("DIN99 of white = %v, want L=100", white)
+	}
+	whited := LSRGB{R: 1, G: 1, B: 1}.CIEXYZ().DIN99d()
+	if sqdist(whited.vec(), ms3.Vec{X: 100}) > 1e-3 {
+		t.Errorf("DIN99d of white = %v, want L=100", whited)
+	}
+}
+
+func TestDIN99ApproximatesDeltaE2000(t *testing.T) {
+	// Pairs of Sharma, Wu and Dalal (2005) with small differences away from black.
+	pairs := [][2]CIELAB{
+		{{L: 50, A: 2.6772, B: -79.7751}, {L: 50, A: 0, B: -82.7485}},
+		{{L: 50, A: -1, B: 2}, {L: 50, A: 0, B: 0}},
+		{{L: 60.2574, A: -34.0099, B: 36.2677}, {L: 60.4626, A: -34.1751, B: 39.4387}},
+		{{L: 22.7233, A: 20.0904, B: -46.694}, {L: 23.0331, A: 14.973, B: -42.5619}},
+	}
+	for _, p := range pairs {
+		want := p[0].DeltaE2000(p[1])
+		got := p[0].DIN99().DeltaE(p[1].DIN99())
+		gotd := p[0].CIEXYZ().d50ToD65().DIN99d().DeltaE(p[1].CIEXYZ().d50ToD65().DIN99d())
+		for _, d := range [2]float32{got, gotd} {
+			if ratio := d / want; ratio < 0.5 || ratio > 2 || math32.IsNaN(ratio) {
+				t.Errorf("DIN99 difference of %v and %v = %v, far from CIEDE2000 %v", p[0], p[1], d, want)
+			}
+		}
+	}
+}
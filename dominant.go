@@ -0,0 +1,116 @@
+package colorspace
+
+import (
+	"image"
+	"sort"
+
+	"github.com/chewxy/math32"
+)
+
+// PixelWeight returns the importance of the pixel at (x,y) with color c when extracting dominant colors.
+// Weights must be non-negative. Pixels of zero weight are ignored.
+type PixelWeight func(x, y int, c OKLCH) float32
+
+// CenterWeight returns a weighting that favors pixels near the center of bounds with a Gaussian
+// falloff, since photographic subjects tend to be centered. At the edges the weight is about 0.1.
+func CenterWeight(bounds image.Rectangle) PixelWeight {
+	cx := float32(bounds.Min.X+bounds.Max.X-1) / 2
+	cy := float32(bounds.Min.Y+bounds.Max.Y-1) / 2
+	// sigma chosen so that weight at the middle of each edge is exp(-2.3)≈0.1.
+	sx := math32.Max(float32(bounds.Dx())/2, 1) / math32.Sqrt(2*2.3)
+	sy := math32.Max(float32(bounds.Dy())/2, 1) / math32.Sqrt(2*2.3)
+	return func(x, y int, _ OKLCH) float32 {
+		dx := (float32(x) - cx) / sx
+		dy := (float32(y) - cy) / sy
+		return math32.Exp(-0.5 * (dx*dx + dy*dy))
+	}
+}
+
+// ChromaWeight returns a weighting proportional to OKLCH chroma so that colorful subjects win over
+// gray, white or black backgrounds. A small base weight keeps fully achromatic images usable.
+func ChromaWeight() PixelWeight {
+	return func(_, _ int, c OKLCH) float32 {
+		return 0.01 + c.C
+	}
+}
+
+// MaskWeight returns a weighting given by the alpha channel of mask at each pixel,
+// i.e: a subject mask produced by a segmentation model or by [SelectionMask].
+func MaskWeight(mask image.Image) PixelWeight {
+	return func(x, y int, _ OKLCH) float32 {
+		_, _, _, a := mask.At(x, y).RGBA()
+		return float32(a) / 0xffff
+	}
+}
+
+// CombineWeights returns a weighting that is the product of the given weightings.
+func CombineWeights(weights ...PixelWeight) PixelWeight {
+	return func(x, y int, c OKLCH) float32 {
+		w := float32(1)
+		for _, fn := range weights {
+			w *= fn(x, y, c)
+		}
+		return w
+	}
+}
+
+// DominantColors returns up to k dominant colors of img found by weighted k-means clustering in [OKLAB]
+// and the fraction of total weight each represents, sorted from most to least dominant.
+// weight may be nil to weight all pixels equally; pass [CenterWeight], [ChromaWeight], [MaskWeight]
+// or a combination so background-heavy photos do not report the background as the theme color.
+// Fully transparent pixels are ignored.
+func DominantColors(img image.Image, k int, weight PixelWeight) (colors []SRGB, fractions []float32) {
+	if k <= 0 {
+		panic("dominant color count must be positive")
+	}
+	var h colorHistogram
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.At(x, y)
+			if _, _, _, a := c.RGBA(); a == 0 {
+				continue
+			}
+			w := float32(1)
+			if weight != nil {
+				w = weight(x, y, colorToOKLCH(c))
+			}
+			if w > 0 {
+				h.add(c, w)
+			}
+		}
+	}
+	samples, weights := h.samples()
+	if len(samples) == 0 {
+		return nil, nil
+	}
+	centroids := kmeansOKLAB(samples, weights, k)
+	mass := make([]float32, len(centroids))
+	var total float32
+	for i, s := range samples {
+		mass[nearestOKLAB(centroids, s)] += weights[i]
+		total += weights[i]
+	}
+	idx := make([]int, len(centroids))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return mass[idx[i]] > mass[idx[j]] })
+	colors = make([]SRGB, len(idx))
+	fractions = make([]float32, len(idx))
+	for i, j := range idx {
+		colors[i] = centroids[j].CIEXYZ().LSRGB().ClipToGamut().SRGB()
+		fractions[i] = mass[j] / total
+	}
+	return colors, fractions
+}
+
+// DominantColor returns the most dominant color of img, i.e: a theme color. See [DominantColors].
+// It returns black for an empty or fully transparent image.
+func DominantColor(img image.Image, weight PixelWeight) SRGB {
+	colors, _ := DominantColors(img, 5, weight)
+	if len(colors) == 0 {
+		return SRGB{}
+	}
+	return colors[0]
+}
@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDominantColor(t *testing.T) {
+	bg := color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	subject := color.RGBA{R: 220, G: 30, B: 30, A: 255}
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	mask := image.NewAlpha(img.Bounds())
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if x >= 5 && x < 15 && y >= 5 && y < 15 {
+				img.SetRGBA(x, y, subject)
+				mask.SetAlpha(x, y, color.Alpha{A: 255})
+			} else {
+				img.SetRGBA(x, y, bg)
+			}
+		}
+	}
+	isSubject := func(c SRGB) bool {
+		r, g, b := c.RGB8()
+		return r == subject.R && g == subject.G && b == subject.B
+	}
+	colors, fractions := DominantColors(img, 3, nil)
+	if len(colors) != 2 || isSubject(colors[0]) || fractions[0] != 0.75 {
+		t.Errorf("unweighted dominant colors should be background first, got %v %v", colors, fractions)
+	}
+	for name, w := range map[string]PixelWeight{
+		"center": CenterWeight(img.Bounds()),
+		"chroma": ChromaWeight(),
+		"mask":   MaskWeight(mask),
+		"both":   CombineWeights(CenterWeight(img.Bounds()), ChromaWeight()),
+	} {
+		if got := DominantColor(img, w); !isSubject(got) {
+			t.Errorf("%s weighting: expected subject color, got %+v", name, got)
+		}
+	}
+}
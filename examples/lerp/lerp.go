@@ -77,4 +77,16 @@ var lerps = []Lerp{
 		Name: "OKLCH",
 		F:    colorspace.LerpOKLCH,
 	},
+	{
+		Name: "CIELUV",
+		F:    colorspace.LerpCIELUV,
+	},
+	{
+		Name: "HSLuv",
+		F:    colorspace.LerpHSLuv,
+	},
+	{
+		Name: "paint",
+		F:    colorspace.LerpPaint,
+	},
 }
@@ -0,0 +1,63 @@
+package colorspace
+
+import "github.com/soypat/geometry/ms3"
+
+// rgbToLinearSRGB returns the matrix converting linear RGB with chromaticities c to linear sRGB,
+// adapting the white point to D65 with the Bradford transform.
+func (c *Chromaticities) rgbToLinearSRGB() ms3.Mat3 {
+	return ms3.MulMat3(xyzToLinSRGB, c.matrix())
+}
+
+// LinearPlanes splits the premultiplied linear light image into float32 planes as consumed by OpenEXR
+// libraries, which also store premultiplied linear values. Colors are converted to the RGB space of
+// chroma; a nil chroma copies the values exactly. The alpha plane is always returned.
+func LinearPlanes(img *LinearRGBAImage, chroma *Chromaticities) (r, g, b, a *Plane) {
+	bounds := img.Rect
+	planes := [4]*Plane{NewPlane(bounds), NewPlane(bounds), NewPlane(bounds), NewPlane(bounds)}
+	toDst := ms3.IdentityMat3()
+	if chroma != nil {
+		toDst = chroma.rgbToLinearSRGB().Inverse()
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.LinearRGBAAt(x, y)
+			v := ms3.Vec{X: c.R, Y: c.G, Z: c.B}
+			if chroma != nil {
+				v = ms3.MulMatVec(toDst, v)
+			}
+			i := planes[0].PixOffset(x, y)
+			planes[0].Pix[i], planes[1].Pix[i], planes[2].Pix[i], planes[3].Pix[i] = v.X, v.Y, v.Z, c.A
+		}
+	}
+	return planes[0], planes[1], planes[2], planes[3]
+}
+
+// LinearImageFromPlanes combines premultiplied linear float32 planes, i.e: decoded from an OpenEXR file,
+// into a linear light sRGB image. Colors are converted from the RGB space of chroma, usually read from the
+// file's chromaticities attribute; a nil chroma copies the values exactly. A nil alpha plane means
+// the image is opaque. Values outside [0,1] are preserved. It panics if the planes' bounds differ.
+func LinearImageFromPlanes(r, g, b, a *Plane, chroma *Chromaticities) *LinearRGBAImage {
+	bounds := r.Rect
+	if g.Rect != bounds || b.Rect != bounds || a != nil && a.Rect != bounds {
+		panic("plane bounds mismatch")
+	}
+	toSRGB := ms3.IdentityMat3()
+	if chroma != nil {
+		toSRGB = chroma.rgbToLinearSRGB()
+	}
+	dst := NewLinearRGBAImage(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			v := ms3.Vec{X: r.Value(x, y), Y: g.Value(x, y), Z: b.Value(x, y)}
+			if chroma != nil {
+				v = ms3.MulMatVec(toSRGB, v)
+			}
+			alpha := float32(1)
+			if a != nil {
+				alpha = a.Value(x, y)
+			}
+			dst.SetLinearRGBA(x, y, LinearRGBA{R: v.X, G: v.Y, B: v.Z, A: alpha})
+		}
+	}
+	return dst
+}
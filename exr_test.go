@@ -0,0 +1,49 @@
+package colorspace
+
+import (
+	"image"
+	"testing"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+func TestLinearPlanes(t *testing.T) {
+	img := NewLinearRGBAImage(image.Rect(1, 1, 3, 2))
+	hdr := LinearRGBA{R: 12.5, G: 0.25, B: -0.01, A: 1}
+	img.SetLinearRGBA(1, 1, hdr)
+	img.SetLinearRGBA(2, 1, LinearRGBA{R: 0.1, G: 0.2, B: 0.3, A: 0.5})
+
+	r, g, b, a := LinearPlanes(img, nil)
+	back := LinearImageFromPlanes(r, g, b, a, nil)
+	for i := range img.Pix {
+		if back.Pix[i] != img.Pix[i] {
+			t.Fatalf("round trip not lossless at %d: %v != %v", i, back.Pix[i], img.Pix[i])
+		}
+	}
+	if opaque := LinearImageFromPlanes(r, g, b, nil, nil); opaque.LinearRGBAAt(2, 1).A != 1 {
+		t.Error("nil alpha plane should produce opaque image")
+	}
+
+	// Round trip through ACES-like wide gamut primaries.
+	aces := &Chromaticities{
+		Red:   CIExy{X: 0.713, Y: 0.293},
+		Green: CIExy{X: 0.165, Y: 0.830},
+		Blue:  CIExy{X: 0.128, Y: 0.044},
+		White: CIExy{X: 0.32168, Y: 0.33767},
+	}
+	r, g, b, a = LinearPlanes(img, aces)
+	if r.Value(1, 1) == hdr.R {
+		t.Error("values were not converted to the target primaries")
+	}
+	back = LinearImageFromPlanes(r, g, b, a, aces)
+	got := back.LinearRGBAAt(1, 1)
+	if sqdist(ms3.Vec{X: got.R, Y: got.G, Z: got.B}, ms3.Vec{X: hdr.R, Y: hdr.G, Z: hdr.B}) > 1e-6*hdr.R*hdr.R {
+		t.Errorf("chromaticities round trip %+v, want %+v", got, hdr)
+	}
+	// sRGB chromaticities are an identity conversion.
+	srgb := &Chromaticities{Red: srgbPrimaries[0], Green: srgbPrimaries[1], Blue: srgbPrimaries[2], White: srgbPrimaries[3]}
+	r, _, _, _ = LinearPlanes(img, srgb)
+	if d := r.Value(1, 1) - hdr.R; d > 1e-2 || d < -1e-2 {
+		t.Errorf("sRGB chromaticities changed red from %v to %v", hdr.R, r.Value(1, 1))
+	}
+}
@@ -0,0 +1,44 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+)
+
+// FalseColorScale returns a camera-style false color exposure scale over the Rec. 709 luma
+// signal level in [0,1] (0–100 IRE). Most levels are shown as gray while the following bands are highlighted:
+//   - purple: 0–2.5%, crushed blacks.
+//   - blue: 2.5–4%, just above black.
+//   - green: 38–42%, 18% gray (middle gray).
+//   - pink: 52–56%, one stop over middle gray, typical for skin.
+//   - yellow: 97–99%, just below clipping.
+//   - red: 99–100%, clipped highlights.
+//
+// The returned scale may be modified to change bands or highlight colors.
+func FalseColorScale() Scale {
+	return Scale{
+		Min: 0,
+		Max: 1,
+		Colormap: BandColormap{
+			Bands: []ColorBand{
+				{Start: 0, End: 0.025, Color: color.RGBA{R: 128, B: 200, A: 255}},
+				{Start: 0.025, End: 0.04, Color: color.RGBA{G: 90, B: 255, A: 255}},
+				{Start: 0.38, End: 0.42, Color: color.RGBA{G: 200, B: 40, A: 255}},
+				{Start: 0.52, End: 0.56, Color: color.RGBA{R: 255, G: 120, B: 190, A: 255}},
+				{Start: 0.97, End: 0.99, Color: color.RGBA{R: 255, G: 230, A: 255}},
+				{Start: 0.99, End: 1.01, Color: color.RGBA{R: 255, A: 255}},
+			},
+			Base: ColormapFunc(func(t float32) color.Color { return SRGB{R: t, G: t, B: t} }),
+		},
+	}
+}
+
+// FalseColor returns a copy of img with each pixel replaced by the color of scale at the pixel's
+// Rec. 709 luma signal level Y′ in [0,1], i.e: [FalseColorScale] for exposure checking.
+// Alpha is preserved.
+func FalseColor(img image.Image, scale Scale) *image.RGBA64 {
+	return mapImageSRGB(img, func(c SRGB) SRGB {
+		luma, _, _ := rec709YCbCr(c)
+		return ColorToSRGB(scale.At(luma))
+	})
+}
@@ -0,0 +1,237 @@
+package colorspace
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+var errSingularFit = errors.New("singular system: not enough distinct patches to fit")
+
+// ColorMatrix is an affine color correction mapping linear camera or display RGB to [CIEXYZ].
+// It is the result of [FitColorMatrix].
+type ColorMatrix struct {
+	M      ms3.Mat3
+	Offset ms3.Vec
+}
+
+// Apply maps the linear RGB color to XYZ.
+func (cm ColorMatrix) Apply(c LSRGB) CIEXYZ {
+	v := ms3.Add(ms3.MulMatVec(cm.M, c.vec()), cm.Offset)
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// FitColorMatrix fits a 3×3 matrix (or 3×4 with offset if withOffset is true) mapping measured linear RGB
+// values of patches to reference XYZ values relative to D65 by linear least squares. This is the core of simple
+// camera and display calibration. The residual CIEDE2000 difference of each patch after correction is returned.
+// At least 3 patches (4 with offset) of linearly independent colors are required.
+func FitColorMatrix(measured []LSRGB, reference []CIEXYZ, withOffset bool) (ColorMatrix, DeltaEReport, error) {
+	if len(measured) != len(reference) {
+		panic("measured and reference patch count mismatch")
+	}
+	nfeat := 3
+	if withOffset {
+		nfeat = 4
+	}
+	features := make([][]float64, len(measured))
+	targets := make([][3]float64, len(reference))
+	for i, c := range measured {
+		features[i] = []float64{float64(c.R), float64(c.G), float64(c.B), 1}[:nfeat]
+		targets[i] = [3]float64{float64(reference[i].X), float64(reference[i].Y), float64(reference[i].Z)}
+	}
+	coef, err := leastSquares(features, targets)
+	if err != nil {
+		return ColorMatrix{}, DeltaEReport{}, err
+	}
+	var cm ColorMatrix
+	cm.M = ms3.NewMat3([]float32{
+		float32(coef[0][0]), float32(coef[1][0]), float32(coef[2][0]),
+		float32(coef[0][1]), float32(coef[1][1]), float32(coef[2][1]),
+		float32(coef[0][2]), float32(coef[1][2]), float32(coef[2][2]),
+	})
+	if withOffset {
+		cm.Offset = ms3.Vec{X: float32(coef[3][0]), Y: float32(coef[3][1]), Z: float32(coef[3][2])}
+	}
+	fitted := make([]CIEXYZ, len(measured))
+	for i, c := range measured {
+		fitted[i] = cm.Apply(c)
+	}
+	return cm, residualReport(fitted, reference), nil
+}
+
+// residualReport returns the CIEDE2000 differences between fitted and reference D65 XYZ values.
+func residualReport(fitted, reference []CIEXYZ) DeltaEReport {
+	got := make([]CIELAB, len(fitted))
+	want := make([]CIELAB, len(reference))
+	for i := range fitted {
+		got[i] = fitted[i].d65ToD50().CIELAB()
+		want[i] = reference[i].d65ToD50().CIELAB()
+	}
+	return CompareCIELAB(got, want)
+}
+
+// leastSquares solves for coefficients C minimizing |F*C - T|² where each row of F are the
+// features of a sample and each row of T its three targets. Returned coefficients have one
+// row per feature. Normal equations are solved in float64 for numerical stability.
+func leastSquares(features [][]float64, targets [][3]float64) ([][3]float64, error) {
+	if len(features) == 0 {
+		return nil, errSingularFit
+	}
+	n := len(features[0])
+	if len(features) < n {
+		return nil, errSingularFit
+	}
+	// Build augmented normal equations [FᵀF | FᵀT].
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n+3)
+	}
+	for s, f := range features {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i][j] += f[i] * f[j]
+			}
+			for k := 0; k < 3; k++ {
+				a[i][n+k] += f[i] * targets[s][k]
+			}
+		}
+	}
+	// Gauss-Jordan elimination with partial pivoting.
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, errSingularFit
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		inv := 1 / a[col][col]
+		for j := col; j < n+3; j++ {
+			a[col][j] *= inv
+		}
+		for row := 0; row < n; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			factor := a[row][col]
+			for j := col; j < n+3; j++ {
+				a[row][j] -= factor * a[col][j]
+			}
+		}
+	}
+	coef := make([][3]float64, n)
+	for i := range coef {
+		coef[i] = [3]float64{a[i][n], a[i][n+1], a[i][n+2]}
+	}
+	return coef, nil
+}
+
+// RootPolynomialCorrection is a root-polynomial color correction mapping linear camera RGB to [CIEXYZ]
+// as described by Finlayson, Mackiewicz and Hurlbert (2015). Unlike plain polynomial regression it
+// is exposure invariant: scaling the input RGB scales the output XYZ by the same factor.
+// It is the result of [FitRootPolynomial].
+type RootPolynomialCorrection struct {
+	// Order of the root-polynomial in [1,3]. Order 1 is equivalent to a [ColorMatrix] without offset.
+	Order int
+	// Coefficients has one row of X, Y, Z weights per root-polynomial term.
+	Coefficients [][3]float32
+}
+
+// FitRootPolynomial fits a root-polynomial correction of the given order (1, 2 or 3) mapping measured
+// linear RGB values of patches to reference XYZ values relative to D65. Use this when a linear [ColorMatrix]
+// is not accurate enough. Order 2 requires at least 6 patches and order 3 at least 13.
+// The residual CIEDE2000 difference of each patch after correction is returned.
+func FitRootPolynomial(measured []LSRGB, reference []CIEXYZ, order int) (RootPolynomialCorrection, DeltaEReport, error) {
+	if len(measured) != len(reference) {
+		panic("measured and reference patch count mismatch")
+	} else if order < 1 || order > 3 {
+		panic("root-polynomial order must be 1, 2 or 3")
+	}
+	features := make([][]float64, len(measured))
+	targets := make([][3]float64, len(reference))
+	for i, c := range measured {
+		features[i] = rootPolyTerms(nil, c, order)
+		targets[i] = [3]float64{float64(reference[i].X), float64(reference[i].Y), float64(reference[i].Z)}
+	}
+	coef, err := leastSquares(features, targets)
+	if err != nil {
+		return RootPolynomialCorrection{}, DeltaEReport{}, err
+	}
+	rp := RootPolynomialCorrection{Order: order, Coefficients: make([][3]float32, len(coef))}
+	for i, row := range coef {
+		rp.Coefficients[i] = [3]float32{float32(row[0]), float32(row[1]), float32(row[2])}
+	}
+	fitted := make([]CIEXYZ, len(measured))
+	for i, c := range measured {
+		fitted[i] = rp.Apply(c)
+	}
+	return rp, residualReport(fitted, reference), nil
+}
+
+// Apply maps the linear RGB color to XYZ.
+func (rp RootPolynomialCorrection) Apply(c LSRGB) CIEXYZ {
+	var buf [13]float64
+	terms := rootPolyTerms(buf[:0], c, rp.Order)
+	if len(terms) != len(rp.Coefficients) {
+		panic("root-polynomial coefficient count does not match order")
+	}
+	var xyz [3]float64
+	for i, term := range terms {
+		for k := range xyz {
+			xyz[k] += term * float64(rp.Coefficients[i][k])
+		}
+	}
+	return CIEXYZ{X: float32(xyz[0]), Y: float32(xyz[1]), Z: float32(xyz[2])}
+}
+
+// rootPolyTerms appends the root-polynomial terms of the given order for c to dst.
+// Negative channels are treated as zero.
+func rootPolyTerms(dst []float64, c LSRGB, order int) []float64 {
+	r := math.Max(float64(c.R), 0)
+	g := math.Max(float64(c.G), 0)
+	b := math.Max(float64(c.B), 0)
+	dst = append(dst, r, g, b)
+	if order >= 2 {
+		dst = append(dst, math.Sqrt(r*g), math.Sqrt(g*b), math.Sqrt(r*b))
+	}
+	if order >= 3 {
+		dst = append(dst,
+			math.Cbrt(r*g*g), math.Cbrt(r*b*b), math.Cbrt(g*r*r),
+			math.Cbrt(g*b*b), math.Cbrt(b*r*r), math.Cbrt(b*g*g),
+			math.Cbrt(r*g*b),
+		)
+	}
+	return dst
+}
+
+// ApplyImage returns a copy of the sRGB encoded image with the correction applied to each pixel.
+// The corrected XYZ values are converted back to sRGB and clipped to gamut.
+func (rp RootPolynomialCorrection) ApplyImage(img image.Image) *image.RGBA64 {
+	return applyCorrectionImage(img, rp.Apply)
+}
+
+// ApplyImage returns a copy of the sRGB encoded image with the correction applied to each pixel.
+// The corrected XYZ values are converted back to sRGB and clipped to gamut.
+func (cm ColorMatrix) ApplyImage(img image.Image) *image.RGBA64 {
+	return applyCorrectionImage(img, cm.Apply)
+}
+
+func applyCorrectionImage(img image.Image, correct func(LSRGB) CIEXYZ) *image.RGBA64 {
+	bounds := img.Bounds()
+	dst := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.At(x, y)
+			srgb := correct(ColorToSRGB(c).LSRGB()).LSRGB().ClipToGamut().SRGB()
+			r, g, b, _ := srgb.RGBA()
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: 0xffff})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,79 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestFitColorMatrix(t *testing.T) {
+	patches := ColorChecker24()
+	reference := make([]CIEXYZ, len(patches))
+	measured := make([]LSRGB, len(patches))
+	for i, patch := range patches {
+		reference[i] = patch.Lab.CIEXYZ().d50ToD65()
+		// Simulate a camera with channel crosstalk, gain error and black offset.
+		lin := reference[i].LSRGB()
+		measured[i] = LSRGB{
+			R: 0.9*lin.R + 0.1*lin.G + 0.01,
+			G: 0.05*lin.R + 1.1*lin.G + 0.01,
+			B: 0.1*lin.G + 0.8*lin.B + 0.01,
+		}
+	}
+	_, report, err := FitColorMatrix(measured, reference, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Max > 0.1 {
+		t.Errorf("expected exact affine fit, got max residual %v", report.Max)
+	}
+	_, report3x3, err := FitColorMatrix(measured, reference, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report3x3.Mean <= report.Mean {
+		t.Errorf("expected 3x3 fit without offset to be worse than affine fit")
+	}
+	if _, _, err := FitColorMatrix(measured[:2], reference[:2], false); err == nil {
+		t.Error("expected error fitting with too few patches")
+	}
+}
+
+func TestFitRootPolynomial(t *testing.T) {
+	patches := ColorChecker24()
+	reference := make([]CIEXYZ, len(patches))
+	measured := make([]LSRGB, len(patches))
+	for i, patch := range patches {
+		reference[i] = patch.Lab.CIEXYZ().d50ToD65()
+		// Simulate a camera with nonlinear channel interaction.
+		lin := reference[i].LSRGB().ClipToGamut()
+		measured[i] = LSRGB{
+			R: 0.9*lin.R + 0.2*math32.Sqrt(lin.R*lin.G),
+			G: lin.G,
+			B: 0.8*lin.B + 0.1*lin.G,
+		}
+	}
+	_, linear, err := FitColorMatrix(measured, reference, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for order := 1; order <= 3; order++ {
+		rp, report, err := FitRootPolynomial(measured, reference, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order == 1 && math32.Abs(report.Mean-linear.Mean) > 1e-2 {
+			t.Errorf("expected order 1 fit to match linear fit, got %v and %v", report.Mean, linear.Mean)
+		}
+		if order > 1 && report.Mean >= linear.Mean {
+			t.Errorf("expected order %d fit to improve on linear fit, got %v >= %v", order, report.Mean, linear.Mean)
+		}
+		// Root-polynomials are exposure invariant.
+		c := measured[5]
+		got := rp.Apply(LSRGB{R: 0.5 * c.R, G: 0.5 * c.G, B: 0.5 * c.B})
+		want := rp.Apply(c)
+		if math32.Abs(2*got.Y-want.Y) > 1e-4 {
+			t.Errorf("order %d not exposure invariant: %v vs %v", order, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+// FixtureLayout is the order and meaning of the color channels of a DMX512 or Art-Net lighting fixture.
+type FixtureLayout uint8
+
+const (
+	// LayoutRGB is a red, green and blue emitter fixture.
+	LayoutRGB FixtureLayout = iota
+	// LayoutRGBA is a red, green, blue and amber emitter fixture.
+	LayoutRGBA
+	// LayoutRGBW is a red, green, blue and white emitter fixture.
+	LayoutRGBW
+	// LayoutCMY is a subtractive cyan, magenta and yellow color mixing fixture, i.e: dichroic flags in front of a white lamp.
+	LayoutCMY
+)
+
+// NumChannels returns the number of DMX channels used by the layout.
+func (l FixtureLayout) NumChannels() int {
+	switch l {
+	case LayoutRGB, LayoutCMY:
+		return 3
+	case LayoutRGBA, LayoutRGBW:
+		return 4
+	}
+	panic("unknown fixture layout")
+}
+
+// Fixture converts colors to the DMX channel values of a lighting fixture.
+type Fixture struct {
+	Layout FixtureLayout
+	// Calibration maps a target linear sRGB color to the fixture's linear red, green and blue
+	// drive levels, correcting for the fixture primaries. The zero value is treated as the identity.
+	// See [NewFixtureCalibration].
+	Calibration ms3.Mat3
+	// Extra is the linear sRGB output at full drive of the amber or white emitter
+	// in [LayoutRGBA] and [LayoutRGBW] fixtures. See [EmitterLSRGB].
+	Extra LSRGB
+}
+
+// NewFixtureCalibration returns the calibration matrix of a fixture whose red, green and blue
+// emitters at full drive measure the given XYZ values relative to a D65 white of Y=1.
+func NewFixtureCalibration(red, green, blue CIEXYZ) ms3.Mat3 {
+	emitterToXYZ := ms3.NewMat3([]float32{
+		red.X, green.X, blue.X,
+		red.Y, green.Y, blue.Y,
+		red.Z, green.Z, blue.Z,
+	})
+	return ms3.MulMat3(emitterToXYZ.Inverse(), linSRGBToXYZ)
+}
+
+// Channels appends the 8-bit DMX channel values of color c at brightness in [0,1] to dst in the fixture's layout order.
+// Values are linear in emitted light. Colors outside the fixture's gamut are clipped.
+func (f Fixture) Channels(dst []byte, c color.Color, brightness float32) []byte {
+	lin := ColorToSRGB(c).LSRGB().ScaleBrightness(brightness)
+	if f.Calibration != (ms3.Mat3{}) {
+		v := ms3.MulMatVec(f.Calibration, lin.vec())
+		lin = LSRGB{R: v.X, G: v.Y, B: v.Z}
+	}
+	lin = lin.ClipToGamut()
+	switch f.Layout {
+	case LayoutRGB:
+		return append(dst, dmx8(lin.R), dmx8(lin.G), dmx8(lin.B))
+	case LayoutRGBA, LayoutRGBW:
+		w := lin.RGBW(f.Extra)
+		return append(dst, dmx8(w.R), dmx8(w.G), dmx8(w.B), dmx8(w.W))
+	case LayoutCMY:
+		// Flags subtract the complementary primary from the lamp's white light.
+		return append(dst, dmx8(1-lin.R), dmx8(1-lin.G), dmx8(1-lin.B))
+	}
+	panic("unknown fixture layout")
+}
+
+// dmx8 converts a level in [0,1] to an 8-bit DMX value rounded to nearest.
+func dmx8(v float32) byte {
+	return byte(clamp01(v)*0xff + 0.5)
+}
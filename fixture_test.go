@@ -0,0 +1,37 @@
+package colorspace
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+)
+
+func TestFixtureChannels(t *testing.T) {
+	identity := NewFixtureCalibration(
+		LSRGB{R: 1}.CIEXYZ(),
+		LSRGB{G: 1}.CIEXYZ(),
+		LSRGB{B: 1}.CIEXYZ(),
+	)
+	white := LSRGB{R: 1, G: 1, B: 1}
+	for _, test := range []struct {
+		f          Fixture
+		c          color.Color
+		brightness float32
+		want       []byte
+	}{
+		{f: Fixture{Layout: LayoutRGB}, c: color.RGBA{R: 255, A: 255}, brightness: 1, want: []byte{255, 0, 0}},
+		{f: Fixture{Layout: LayoutRGB, Calibration: identity}, c: color.RGBA{G: 255, A: 255}, brightness: 0.5, want: []byte{0, 128, 0}},
+		{f: Fixture{Layout: LayoutRGBW, Extra: white}, c: color.White, brightness: 1, want: []byte{0, 0, 0, 255}},
+		{f: Fixture{Layout: LayoutRGBW, Extra: white}, c: color.RGBA{R: 255, G: 255, A: 255}, brightness: 1, want: []byte{255, 255, 0, 0}},
+		{f: Fixture{Layout: LayoutCMY}, c: color.White, brightness: 1, want: []byte{0, 0, 0}},
+		{f: Fixture{Layout: LayoutCMY}, c: color.RGBA{R: 255, A: 255}, brightness: 1, want: []byte{0, 255, 255}},
+	} {
+		got := test.f.Channels(nil, test.c, test.brightness)
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("layout %d color %v: got %v, want %v", test.f.Layout, test.c, got, test.want)
+		}
+		if len(got) != test.f.Layout.NumChannels() {
+			t.Errorf("layout %d: got %d channels, want %d", test.f.Layout, len(got), test.f.Layout.NumChannels())
+		}
+	}
+}
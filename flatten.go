@@ -0,0 +1,84 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+)
+
+// Flatten composites the possibly translucent color c over the opaque background bg in linear light
+// and returns the resulting opaque color. Compositing gamma-encoded values, as [draw.Over] does,
+// produces darker edges than design tools that blend in linear light. The alpha of bg is ignored.
+func Flatten(c, bg color.Color) SRGB {
+	fg, a := unpremultiplied(c)
+	return flattenLinear(fg, a, ColorToSRGB(bg))
+}
+
+// FlattenImage composites img over the opaque background bg in linear light and returns the
+// resulting opaque image, i.e: to preview a transparent image over a [Checkerboard] or matte color
+// given as an [image.Uniform]. See [Flatten].
+func FlattenImage(img, bg image.Image) *image.RGBA64 {
+	bounds := img.Bounds()
+	dst := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			fg, a := unpremultiplied(img.At(x, y))
+			r, g, b, _ := flattenLinear(fg, a, ColorToSRGB(bg.At(x, y))).RGBA()
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: 0xffff})
+		}
+	}
+	return dst
+}
+
+func flattenLinear(fg SRGB, alpha float32, bg SRGB) SRGB {
+	if alpha >= 1 {
+		return fg
+	} else if alpha <= 0 {
+		return bg
+	}
+	return bg.LSRGB().Lerp(fg.LSRGB(), alpha).SRGB()
+}
+
+// Checkerboard is an infinite procedural checkerboard image as used by design tools to show transparency.
+// Squares are Size pixels wide and alternate between Light and Dark, with a Light square at the origin.
+type Checkerboard struct {
+	Size        int
+	Light, Dark color.Color
+}
+
+// NewCheckerboard returns the light and mid gray 8 pixel checkerboard common in image editors.
+func NewCheckerboard() *Checkerboard {
+	return &Checkerboard{
+		Size:  8,
+		Light: color.Gray{Y: 0xff},
+		Dark:  color.Gray{Y: 0xcc},
+	}
+}
+
+// ColorModel implements [image.Image].
+func (cb *Checkerboard) ColorModel() color.Model { return color.RGBA64Model }
+
+// Bounds implements [image.Image]. The checkerboard is effectively infinite like [image.Uniform].
+func (cb *Checkerboard) Bounds() image.Rectangle {
+	return image.Rectangle{Min: image.Point{X: -1e9, Y: -1e9}, Max: image.Point{X: 1e9, Y: 1e9}}
+}
+
+// At implements [image.Image].
+func (cb *Checkerboard) At(x, y int) color.Color {
+	size := cb.Size
+	if size <= 0 {
+		size = 1
+	}
+	// Floor division so squares remain even across the origin.
+	if (floorDiv(x, size)+floorDiv(y, size))%2 == 0 {
+		return cb.Light
+	}
+	return cb.Dark
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,31 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	// Half transparent white over black yields linear 0.5, which is sRGB 188.
+	got := Flatten(color.NRGBA{R: 255, G: 255, B: 255, A: 128}, color.Black)
+	if r, g, b := got.RGB8(); r != 188 || g != 188 || b != 188 {
+		t.Errorf("got (%d,%d,%d), want 188 gray", r, g, b)
+	}
+	if got := Flatten(color.Transparent, color.White); got != (SRGB{R: 1, G: 1, B: 1}) {
+		t.Errorf("transparent over white should be white, got %+v", got)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 1))
+	cb := NewCheckerboard()
+	flat := FlattenImage(img, cb)
+	if c := flat.RGBA64At(0, 0); c.R != 0xffff || c.A != 0xffff {
+		t.Errorf("expected light square at origin, got %v", c)
+	}
+	if c := flat.RGBA64At(8, 0); c.R != 0xcccc {
+		t.Errorf("expected dark square at x=8, got %v", c)
+	}
+	if cb.At(-1, 0) != cb.Dark || cb.At(-1, -1) != cb.Light {
+		t.Error("checkerboard squares should alternate across the origin")
+	}
+}
@@ -0,0 +1,81 @@
+package colorspace
+
+import (
+	"errors"
+
+	"github.com/chewxy/math32"
+)
+
+// ErrOutOfGamut is returned by checked conversions when the result falls
+// outside the destination gamut and had to be clipped.
+var ErrOutOfGamut = errors.New("color out of destination gamut")
+
+// LSRGBChecked converts the color to linear sRGB like [CIEXYZ.LSRGB] but clips the result to gamut
+// and returns [ErrOutOfGamut] if any channel fell outside [0,1] by more than rounding error.
+func (c CIEXYZ) LSRGBChecked() (LSRGB, error) {
+	lin := c.LSRGB()
+	if gamutExcess(lin) > epsUnit {
+		return lin.ClipToGamut(), ErrOutOfGamut
+	}
+	return lin.ClipToGamut(), nil
+}
+
+// SRGBChecked converts the color to gamma-encoded sRGB clipping the result to gamut.
+// It returns [ErrOutOfGamut] if clipping was needed. See [CIEXYZ.LSRGBChecked].
+func (c CIEXYZ) SRGBChecked() (SRGB, error) {
+	lin, err := c.LSRGBChecked()
+	return lin.SRGB(), err
+}
+
+// GamutReport accumulates statistics on how much clipping occurs when converting
+// a batch of colors to sRGB so that batch tools can count and log out of gamut colors.
+// The zero value is ready to use.
+type GamutReport struct {
+	// Total is the number of colors converted.
+	Total int
+	// OutOfGamut is the number of colors that had to be clipped.
+	OutOfGamut int
+	// MaxExcess is the largest distance by which a linear sRGB channel fell outside [0,1].
+	MaxExcess float32
+	// SumExcess is the sum of the per-color largest channel excess. Used to compute the mean.
+	SumExcess float32
+}
+
+// LSRGB converts c to linear sRGB clipping it to gamut and recording whether clipping occurred.
+func (r *GamutReport) LSRGB(c CIEXYZ) LSRGB {
+	lin := c.LSRGB()
+	r.Total++
+	if excess := gamutExcess(lin); excess > epsUnit {
+		r.OutOfGamut++
+		r.SumExcess += excess
+		r.MaxExcess = math32.Max(r.MaxExcess, excess)
+	}
+	return lin.ClipToGamut()
+}
+
+// SRGB converts c to gamma-encoded sRGB clipping it to gamut and recording whether clipping occurred.
+func (r *GamutReport) SRGB(c CIEXYZ) SRGB {
+	return r.LSRGB(c).SRGB()
+}
+
+// OutOfGamutFraction returns the fraction of converted colors that were clipped in [0,1].
+func (r GamutReport) OutOfGamutFraction() float32 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float32(r.OutOfGamut) / float32(r.Total)
+}
+
+// MeanExcess returns the mean excess of the clipped colors.
+func (r GamutReport) MeanExcess() float32 {
+	if r.OutOfGamut == 0 {
+		return 0
+	}
+	return r.SumExcess / float32(r.OutOfGamut)
+}
+
+// gamutExcess returns the largest distance of a channel outside [0,1].
+func gamutExcess(c LSRGB) float32 {
+	v := c.vec()
+	return math32.Max(math32.Max(-v.Min(), v.Max()-1), 0)
+}
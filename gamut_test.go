@@ -0,0 +1,26 @@
+package colorspace
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGamutReport(t *testing.T) {
+	inGamut := SRGB{R: 0.5, G: 0.2, B: 0.9}.LSRGB().CIEXYZ()
+	outGamut := OKLCH{L: 0.7, C: 0.4, H: 150}.OKLAB().CIEXYZ()
+	if _, err := inGamut.SRGBChecked(); err != nil {
+		t.Errorf("unexpected error for in gamut color: %v", err)
+	}
+	if _, err := outGamut.SRGBChecked(); !errors.Is(err, ErrOutOfGamut) {
+		t.Errorf("expected out of gamut error, got %v", err)
+	}
+	var report GamutReport
+	report.SRGB(inGamut)
+	report.SRGB(outGamut)
+	if report.Total != 2 || report.OutOfGamut != 1 {
+		t.Errorf("unexpected report counts: %+v", report)
+	}
+	if report.OutOfGamutFraction() != 0.5 || report.MaxExcess <= 0 || report.MeanExcess() != report.MaxExcess {
+		t.Errorf("unexpected report statistics: %+v", report)
+	}
+}
@@ -0,0 +1,148 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// GamutSpace identifies a standard RGB gamut for [ImageGamutReport].
+type GamutSpace uint8
+
+const (
+	GamutSRGB GamutSpace = iota
+	GamutDisplayP3
+	GamutRec2020
+	// GamutBeyondRec2020 indicates colors outside even the Rec.2020 gamut, i.e: from camera raw data.
+	GamutBeyondRec2020
+)
+
+// String returns the name of the gamut.
+func (g GamutSpace) String() string {
+	switch g {
+	case GamutSRGB:
+		return "sRGB"
+	case GamutDisplayP3:
+		return "Display P3"
+	case GamutRec2020:
+		return "Rec.2020"
+	}
+	return "beyond Rec.2020"
+}
+
+// ImageGamutReport describes the gamut of the colors in an image. See [AnalyzeImageGamut].
+type ImageGamutReport struct {
+	// Pixels is the number of analyzed pixels. Fully transparent pixels are skipped.
+	Pixels int
+	// OutsideSRGB, OutsideP3 and OutsideRec2020 are the fractions of pixels in [0,1] outside each gamut.
+	OutsideSRGB, OutsideP3, OutsideRec2020 float32
+	// Hull is the convex hull of the chromaticities of non-black pixels in counter-clockwise order.
+	Hull []CIExy
+}
+
+// AnalyzeImageGamut reports how much of img falls outside the sRGB, Display P3 and Rec.2020 gamuts
+// so ingest pipelines can decide in which space to encode masters. decode converts pixels to D65 relative
+// XYZ according to the image's color space, i.e: [DecodeDisplayP3]. A nil decode assumes sRGB,
+// in which case all pixels lie inside every gamut.
+func AnalyzeImageGamut(img image.Image, decode func(color.Color) CIEXYZ) ImageGamutReport {
+	if decode == nil {
+		decode = func(c color.Color) CIEXYZ {
+			s, _ := unpremultiplied(c)
+			return s.LSRGB().CIEXYZ()
+		}
+	}
+	var report ImageGamutReport
+	var outSRGB, outP3, out2020 int
+	// Chromaticities are quantized to bound the cost of the hull for large images.
+	const quant = 1e4
+	seen := make(map[[2]int32]struct{})
+	var points []CIExy
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.At(x, y)
+			if _, _, _, a := c.RGBA(); a == 0 {
+				continue
+			}
+			xyz := decode(c)
+			report.Pixels++
+			if gamutExcess(xyz.LSRGB()) > epsUnit {
+				outSRGB++
+			}
+			if gamutExcess(LSRGB(xyz.LinearP3())) > epsUnit {
+				outP3++
+			}
+			if gamutExcess(LSRGB(xyz.LinearRec2020())) > epsUnit {
+				out2020++
+			}
+			if xyz.Y <= epsUnit {
+				continue // Black has no meaningful chromaticity.
+			}
+			xy := xyz.CIExy()
+			key := [2]int32{int32(xy.X * quant), int32(xy.Y * quant)}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				points = append(points, xy)
+			}
+		}
+	}
+	if report.Pixels > 0 {
+		n := float32(report.Pixels)
+		report.OutsideSRGB = float32(outSRGB) / n
+		report.OutsideP3 = float32(outP3) / n
+		report.OutsideRec2020 = float32(out2020) / n
+	}
+	report.Hull = convexHullXY(points)
+	return report
+}
+
+// SuggestedSpace returns the smallest gamut which contains all but the given fraction of pixels,
+// i.e: 0.001 to tolerate 0.1% of clipped pixels.
+func (r ImageGamutReport) SuggestedSpace(tolerance float32) GamutSpace {
+	switch {
+	case r.OutsideSRGB <= tolerance:
+		return GamutSRGB
+	case r.OutsideP3 <= tolerance:
+		return GamutDisplayP3
+	case r.OutsideRec2020 <= tolerance:
+		return GamutRec2020
+	}
+	return GamutBeyondRec2020
+}
+
+// DecodeDisplayP3 interprets the channels of c as Display P3 and returns its XYZ value.
+// Use with [AnalyzeImageGamut] for images tagged with a Display P3 profile.
+func DecodeDisplayP3(c color.Color) CIEXYZ {
+	s, _ := unpremultiplied(c)
+	return DisplayP3(s).CIEXYZ()
+}
+
+// convexHullXY returns the convex hull of points in counter-clockwise order using Andrew's monotone chain.
+func convexHullXY(points []CIExy) []CIExy {
+	if len(points) < 3 {
+		return append([]CIExy{}, points...)
+	}
+	pts := append([]CIExy{}, points...)
+	sort.Slice(pts, func(i, j int) bool {
+		return pts[i].X < pts[j].X || pts[i].X == pts[j].X && pts[i].Y < pts[j].Y
+	})
+	cross := func(o, a, b CIExy) float32 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+	hull := make([]CIExy, 0, 2*len(pts))
+	for _, p := range pts { // Lower hull.
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- { // Upper hull.
+		p := pts[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
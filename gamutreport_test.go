@@ -0,0 +1,39 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestAnalyzeImageGamut(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img.Set(1, 0, color.RGBA{G: 255, A: 255})
+	img.Set(2, 0, color.RGBA{B: 255, A: 255})
+	img.Set(3, 0, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+
+	srgb := AnalyzeImageGamut(img, nil)
+	if srgb.Pixels != 4 || srgb.OutsideSRGB != 0 || srgb.SuggestedSpace(0) != GamutSRGB {
+		t.Errorf("sRGB image report %+v", srgb)
+	}
+	// The hull of the sRGB primaries is the triangle; gray lies inside.
+	if len(srgb.Hull) != 3 {
+		t.Errorf("expected triangular hull, got %v", srgb.Hull)
+	}
+
+	p3 := AnalyzeImageGamut(img, DecodeDisplayP3)
+	// The P3 red primary lies marginally outside the Rec.2020 gamut.
+	if p3.OutsideSRGB != 0.75 || p3.OutsideP3 != 0 || p3.OutsideRec2020 != 0.25 {
+		t.Errorf("P3 image report %+v", p3)
+	}
+	if got := p3.SuggestedSpace(0.001); got != GamutDisplayP3 {
+		t.Errorf("suggested %v, want %v", got, GamutDisplayP3)
+	}
+	if got := p3.SuggestedSpace(0.8); got != GamutSRGB {
+		t.Errorf("tolerant suggestion %v, want %v", got, GamutSRGB)
+	}
+	if GamutRec2020.String() != "Rec.2020" {
+		t.Error("unexpected gamut name")
+	}
+}
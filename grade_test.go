@@ -0,0 +1,83 @@
+package colorspace
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestGradeJSON(t *testing.T) {
+	g := Grade{
+		Kelvin:     4500,
+		Exposure:   0.5,
+		ToneCurve:  []CurvePoint{{In: 0, Out: 0.05}, {In: 0.5, Out: 0.55}, {In: 1, Out: 0.95}},
+		Shadows:    SplitTone{Hue: 200, Amount: 0.3},
+		Highlights: SplitTone{Hue: 60, Amount: 0.2},
+		Balance:    0.1,
+		Chroma:     -0.2,
+	}
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Grade
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, g) {
+		t.Errorf("round trip of %+v = %+v", g, got)
+	}
+	c := SRGB{R: 0.6, G: 0.4, B: 0.3}
+	if a, b := g.Apply(c), got.Apply(c); a != b {
+		t.Errorf("decoded grade applies differently: %v != %v", a, b)
+	}
+	for _, bad := range []string{
+		`{"toneCurve":[{"in":0.5,"out":0.5},{"in":0.5,"out":0.6}]}`,
+		`{"toneCurve":[{"in":0,"out":1.5}]}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), &got); err == nil {
+			t.Errorf("invalid grade %s accepted", bad)
+		}
+	}
+}
+
+func TestGradeApply(t *testing.T) {
+	colors := []SRGB{{R: 0.6, G: 0.4, B: 0.3}, {R: 0.1, G: 0.2, B: 0.7}, {R: 0.5, G: 0.5, B: 0.5}}
+	for _, c := range colors {
+		if got := (Grade{}).Apply(c); sqdist(got.vec(), c.vec()) > 1e-8 {
+			t.Errorf("zero grade changed %v to %v", c, got)
+		}
+	}
+	gray := SRGB{R: 0.5, G: 0.5, B: 0.5}
+	// One stop doubles linear light.
+	if got, want := (Grade{Exposure: 1}).Apply(gray).LSRGB().R, 2*gray.LSRGB().R; math32.Abs(got-want) > 1e-4 {
+		t.Errorf("exposure +1 = %v, want %v", got, want)
+	}
+	// Warm light is neutralized: the white balanced image is cooler.
+	if got := (Grade{Kelvin: 3200}).Apply(gray); got.B <= got.R {
+		t.Errorf("white balance for 3200K = %v, want bluer than red", got)
+	}
+	if got := colorToOKLCH((Grade{Chroma: -1}).Apply(colors[0])); got.C > 1e-3 {
+		t.Errorf("full desaturation left chroma %v", got.C)
+	}
+	curve := Grade{ToneCurve: []CurvePoint{{In: 0, Out: 0.2}, {In: 1, Out: 0.8}}}
+	if got := colorToOKLCH(curve.Apply(SRGB{})).L; math32.Abs(got-0.2) > 1e-3 {
+		t.Errorf("tone curve lifted black to %v, want 0.2", got)
+	}
+	split := Grade{Shadows: SplitTone{Hue: 250, Amount: 1}, Highlights: SplitTone{Hue: 70, Amount: 1}}
+	dark, light := colorToOKLAB(split.Apply(SRGB{R: 0.1, G: 0.1, B: 0.1})), colorToOKLAB(split.Apply(SRGB{R: 0.9, G: 0.9, B: 0.9}))
+	if dark.B >= 0 || light.B <= 0 {
+		t.Errorf("split toning: shadows %v should be blue and highlights %v yellow", dark, light)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+	out := Grade{Exposure: 1}.ApplyImage(img)
+	if _, _, _, a := out.At(1, 0).RGBA(); a != 0 {
+		t.Error("transparent pixel became opaque")
+	}
+}
@@ -0,0 +1,86 @@
+package colorspace
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+)
+
+// GradientStop is a color at a position along a [Gradient].
+type GradientStop struct {
+	Pos   float32 // Position in [0,1] along the gradient.
+	Color color.Color
+	// Hint is the relative position in (0,1) between this stop and the next
+	// at which colors are blended half-way, as with CSS color interpolation hints.
+	// Zero is equivalent to 0.5, an even blend.
+	Hint float32
+}
+
+// Gradient is a multi-stop color gradient. Colors between stops are
+// interpolated with Lerp which selects the interpolation color space.
+type Gradient struct {
+	// Stops of the gradient sorted by increasing position.
+	Stops []GradientStop
+	// Lerp is the interpolation function between two stops, i.e: [LerpOKLCH].
+	// If nil [LerpOKLAB] is used.
+	Lerp func(c1, c2 color.Color, v float32) color.Color
+}
+
+// NewGradient returns a gradient with the colors evenly spaced along [0,1] and
+// interpolated by lerp. If lerp is nil [LerpOKLAB] is used.
+func NewGradient(lerp func(c1, c2 color.Color, v float32) color.Color, colors ...color.Color) Gradient {
+	g := Gradient{Lerp: lerp, Stops: make([]GradientStop, len(colors))}
+	for i, c := range colors {
+		var pos float32
+		if len(colors) > 1 {
+			pos = float32(i) / float32(len(colors)-1)
+		}
+		g.Stops[i] = GradientStop{Pos: pos, Color: c}
+	}
+	return g
+}
+
+// At returns the color of the gradient at position t. t is clamped to the
+// positions of the first and last stops. At panics if the gradient has no stops.
+func (g Gradient) At(t float32) color.Color {
+	if len(g.Stops) == 0 {
+		panic("gradient has no stops")
+	}
+	first, last := g.Stops[0], g.Stops[len(g.Stops)-1]
+	if t <= first.Pos {
+		return first.Color
+	} else if t >= last.Pos {
+		return last.Color
+	}
+	// Find first stop with position greater than t.
+	i := sort.Search(len(g.Stops), func(i int) bool { return g.Stops[i].Pos > t })
+	s0, s1 := g.Stops[i-1], g.Stops[i]
+	lerp := g.Lerp
+	if lerp == nil {
+		lerp = LerpOKLAB
+	}
+	v := ms1.Clamp((t-s0.Pos)/(s1.Pos-s0.Pos), 0, 1)
+	if s0.Hint > 0 && s0.Hint != 0.5 {
+		if s0.Hint >= 1 {
+			v = 0
+		} else {
+			v = math32.Pow(v, math32.Log(0.5)/math32.Log(s0.Hint))
+		}
+	}
+	return lerp(s0.Color, s1.Color, v)
+}
+
+// Colors returns n colors evenly sampled along the gradient including both ends.
+func (g Gradient) Colors(n int) color.Palette {
+	p := make(color.Palette, n)
+	for i := range p {
+		var t float32
+		if n > 1 {
+			t = float32(i) / float32(n-1)
+		}
+		p[i] = g.At(t)
+	}
+	return p
+}
@@ -0,0 +1,91 @@
+package colorspace
+
+import (
+	"image/color"
+	"sort"
+	"sync"
+)
+
+var (
+	presetsMu       sync.RWMutex
+	gradientPresets = map[string]Gradient{
+		// All built-in presets have monotonically increasing lightness so they remain legible in grayscale.
+		"grayscale": oklchGradient(
+			OKLCH{L: 0, C: 0, H: 0},
+			OKLCH{L: 1, C: 0, H: 0},
+		),
+		"sunset": oklchGradient(
+			OKLCH{L: 0.30, C: 0.12, H: 300},
+			OKLCH{L: 0.55, C: 0.18, H: 350},
+			OKLCH{L: 0.72, C: 0.16, H: 45},
+			OKLCH{L: 0.92, C: 0.14, H: 95},
+		),
+		"ocean": oklchGradient(
+			OKLCH{L: 0.25, C: 0.08, H: 265},
+			OKLCH{L: 0.50, C: 0.12, H: 245},
+			OKLCH{L: 0.75, C: 0.10, H: 205},
+			OKLCH{L: 0.95, C: 0.04, H: 180},
+		),
+		"heat": oklchGradient(
+			OKLCH{L: 0.15, C: 0.00, H: 30},
+			OKLCH{L: 0.48, C: 0.18, H: 30},
+			OKLCH{L: 0.72, C: 0.17, H: 60},
+			OKLCH{L: 0.97, C: 0.08, H: 100},
+		),
+		"forest": oklchGradient(
+			OKLCH{L: 0.25, C: 0.06, H: 155},
+			OKLCH{L: 0.55, C: 0.13, H: 145},
+			OKLCH{L: 0.85, C: 0.13, H: 115},
+		),
+		"ice": oklchGradient(
+			OKLCH{L: 0.35, C: 0.10, H: 275},
+			OKLCH{L: 0.70, C: 0.09, H: 235},
+			OKLCH{L: 0.97, C: 0.02, H: 220},
+		),
+	}
+)
+
+// GradientPreset returns the gradient registered with the given name and true if it exists.
+// Built-in presets are "grayscale", "sunset", "ocean", "heat", "forest" and "ice",
+// all of which have monotonically increasing lightness and interpolate in [OKLCH].
+// The returned gradient's stops are a copy which may be modified freely.
+func GradientPreset(name string) (Gradient, bool) {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	g, ok := gradientPresets[name]
+	g.Stops = append([]GradientStop(nil), g.Stops...)
+	return g, ok
+}
+
+// GradientPresetNames returns the sorted names of all registered gradient presets.
+func GradientPresetNames() []string {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	names := make([]string, 0, len(gradientPresets))
+	for name := range gradientPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterGradientPreset registers a gradient under name, replacing any existing preset with the same name.
+// The stops of g are copied so later changes to them do not affect the preset.
+func RegisterGradientPreset(name string, g Gradient) {
+	if len(g.Stops) == 0 {
+		panic("gradient has no stops")
+	}
+	g.Stops = append([]GradientStop(nil), g.Stops...)
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+	gradientPresets[name] = g
+}
+
+// oklchGradient returns a gradient with evenly spaced OKLCH stops interpolated in OKLCH.
+func oklchGradient(stops ...OKLCH) Gradient {
+	colors := make([]color.Color, len(stops))
+	for i, c := range stops {
+		colors[i] = oklchToSRGB(c)
+	}
+	return NewGradient(LerpOKLCH, colors...)
+}
@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	g := NewGradient(nil, jet[0], jet[5], jet[10])
+	for i, stop := range g.Stops {
+		got := ColorToSRGB(g.At(stop.Pos))
+		want := ColorToSRGB(stop.Color)
+		if sqdist(got.vec(), want.vec()) > 1e-6 {
+			t.Errorf("stop %d: want %v, got %v", i, want, got)
+		}
+	}
+	if p := g.Colors(7); len(p) != 7 {
+		t.Errorf("expected 7 colors, got %d", len(p))
+	}
+}
+
+func TestGradientPresets(t *testing.T) {
+	names := GradientPresetNames()
+	if len(names) == 0 {
+		t.Fatal("no gradient presets")
+	}
+	for _, name := range names {
+		g, ok := GradientPreset(name)
+		if !ok {
+			t.Fatalf("preset %q not found", name)
+		}
+		// Presets should be grayscale-safe.
+		prevL := float32(-1)
+		for _, c := range g.Colors(32) {
+			L := colorToOKLCH(c).L
+			if L < prevL-1e-3 {
+				t.Errorf("preset %q lightness not monotonic: %v < %v", name, L, prevL)
+			}
+			prevL = L
+		}
+	}
+}
+
+func TestRegisterGradientPresetCopies(t *testing.T) {
+	g := NewGradient(LerpOKLCH, SRGB{}, SRGB{R: 1, G: 1, B: 1})
+	RegisterGradientPreset("test-copy", g)
+	g.Stops[0].Color = SRGB{R: 1}
+	got, _ := GradientPreset("test-copy")
+	if got.Stops[0].Color != (SRGB{}) {
+		t.Errorf("registered preset changed by caller: %+v", got.Stops[0])
+	}
+	got.Stops[0].Color = SRGB{G: 1}
+	if again, _ := GradientPreset("test-copy"); again.Stops[0].Color != (SRGB{}) {
+		t.Errorf("registered preset changed through lookup: %+v", again.Stops[0])
+	}
+}
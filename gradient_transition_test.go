@@ -0,0 +1,59 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestCrossfadeGradients(t *testing.T) {
+	red, blue, white := SRGB{R: 1}, SRGB{B: 1}, SRGB{R: 1, G: 1, B: 1}
+	from := NewGradient(LerpOKLAB, red, white)
+	to := NewGradient(LerpOKLCH, blue, white, red)
+	if got := CrossfadeGradients(from, to, 0); len(got.Stops) != 2 {
+		t.Errorf("v=0 should return from, got %d stops", len(got.Stops))
+	}
+	mid := CrossfadeGradients(from, to, 0.5)
+	if len(mid.Stops) != 3 || mid.Stops[1].Pos != 0.5 {
+		t.Fatalf("expected stops at union positions, got %+v", mid.Stops)
+	}
+	// Ends blend red with blue in OKLCH: saturated purple, not a dull RGB average.
+	start := ColorToSRGB(mid.At(0))
+	if lch := start.LSRGB().CIEXYZ().OKLAB().OKLCH(); lch.C < 0.2 {
+		t.Errorf("crossfaded red and blue lost chroma: %+v", lch)
+	}
+	// End stop blends white with red.
+	want := ColorToSRGB(LerpOKLCH(white, red, 0.5))
+	if got := ColorToSRGB(mid.At(1)); sqdist(got.vec(), want.vec()) > 1e-6 {
+		t.Errorf("end stop %+v, want %+v", got, want)
+	}
+	// Equal stop counts interpolate positions and hints.
+	a := Gradient{Stops: []GradientStop{{Pos: 0, Color: red, Hint: 0.2}, {Pos: 0.5, Color: white}, {Pos: 1, Color: blue}}}
+	b := Gradient{Stops: []GradientStop{{Pos: 0, Color: red}, {Pos: 0.7, Color: white}, {Pos: 1, Color: blue}}}
+	ab := CrossfadeGradients(a, b, 0.5)
+	if ab.Stops[1].Pos != 0.6 || ab.Stops[0].Hint != 0.35 {
+		t.Errorf("matched stops %+v", ab.Stops)
+	}
+}
+
+func TestGradientTransition(t *testing.T) {
+	from := NewGradient(nil, color.Black, color.White)
+	to := NewGradient(nil, SRGB{R: 1}, SRGB{B: 1})
+	tr := GradientTransition{From: from, To: to, Duration: time.Second}
+	if got := ColorToSRGB(tr.At(-time.Second).At(0)); got != (SRGB{}) {
+		t.Errorf("before start got %+v", got)
+	}
+	if got := ColorToSRGB(tr.At(2 * time.Second).At(0)); got != (SRGB{R: 1}) {
+		t.Errorf("after end got %+v", got)
+	}
+	// Smoothstep easing is symmetric at the midpoint.
+	half := ColorToSRGB(tr.At(time.Second / 2).At(0))
+	want := ColorToSRGB(LerpOKLCHA(color.Black, SRGB{R: 1}, 0.5))
+	if sqdist(half.vec(), want.vec()) > 1e-6 {
+		t.Errorf("midpoint %+v, want %+v", half, want)
+	}
+	tr.Ease = func(v float32) float32 { return 0 }
+	if got := ColorToSRGB(tr.At(time.Second / 2).At(0)); got != (SRGB{}) {
+		t.Errorf("custom easing ignored: %+v", got)
+	}
+}
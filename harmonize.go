@@ -0,0 +1,72 @@
+package colorspace
+
+import (
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+)
+
+// Hue harmonization constants.
+const (
+	harmonizeClusters  = 6
+	harmonizeMinChroma = 0.03 // OKLCH chroma below which dominant colors carry no hue.
+	harmonizeHueSpread = 30   // Hue distance in degrees over which cluster shifts blend.
+)
+
+// HarmonizeHues shifts the hues of img towards the dominant hues of reference so assets from different
+// sources look consistent when composited. Dominant colors of both images are found as in [DominantColors]
+// weighted by [ChromaWeight]. Each chromatic dominant color of img is rotated towards the closest
+// chromatic dominant hue of reference in [OKLCH] by at most maxShift degrees, i.e: 15. Pixels are rotated
+// by a blend of the shifts of dominant colors of similar hue so gradients stay smooth; near-neutral pixels
+// are left as is. Lightness and chroma are kept and alpha is preserved. If either image has no chromatic
+// dominant colors img is returned unchanged.
+func HarmonizeHues(img, reference image.Image, maxShift float32) *image.RGBA64 {
+	refHues := dominantHues(reference)
+	hues := dominantHues(img)
+	shifts := make([]float32, len(hues))
+	for i, h := range hues {
+		if len(refHues) == 0 {
+			break
+		}
+		best := hueDelta(h, refHues[0])
+		for _, ref := range refHues[1:] {
+			if d := hueDelta(h, ref); math32.Abs(d) < math32.Abs(best) {
+				best = d
+			}
+		}
+		shifts[i] = ms1.Clamp(best, -maxShift, maxShift)
+	}
+	return mapImageSRGB(img, func(c SRGB) SRGB {
+		lch := colorToOKLCH(c)
+		var shift, wsum float32
+		for i, h := range hues {
+			d := hueDelta(lch.H, h) / harmonizeHueSpread
+			w := math32.Exp(-d * d)
+			shift += w * shifts[i]
+			wsum += w
+		}
+		if wsum < 1e-6 {
+			return c
+		}
+		// Shifts fade out for pixels whose hue is far from every dominant color or that are near-neutral.
+		shift *= math32.Min(wsum, 1) / wsum * smoothstep(0, harmonizeMinChroma, lch.C)
+		if shift == 0 {
+			return c
+		}
+		lch.H = normalizeHue(lch.H + shift)
+		return oklchToSRGB(lch)
+	})
+}
+
+// dominantHues returns the OKLCH hues of the chromatic dominant colors of img.
+func dominantHues(img image.Image) []float32 {
+	colors, _ := DominantColors(img, harmonizeClusters, ChromaWeight())
+	var hues []float32
+	for _, c := range colors {
+		if lch := colorToOKLCH(c); lch.C >= harmonizeMinChroma {
+			hues = append(hues, lch.H)
+		}
+	}
+	return hues
+}
@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestHarmonizeHues(t *testing.T) {
+	fill := func(img *image.RGBA, r image.Rectangle, c color.Color) {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				img.Set(x, y, c)
+			}
+		}
+	}
+	orange := OKLCH{L: 0.7, C: 0.12, H: 60}
+	gray := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	fill(img, img.Bounds(), oklchToSRGB(orange))
+	fill(img, image.Rect(0, 0, 16, 4), gray)
+	img.Set(0, 15, color.RGBA{})
+
+	ref := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	fill(ref, ref.Bounds(), oklchToSRGB(OKLCH{L: 0.6, C: 0.15, H: 30}))
+
+	got := HarmonizeHues(img, ref, 15)
+	lch := colorToOKLCH(got.At(8, 8))
+	if d := hueDelta(orange.H, lch.H); math32.Abs(d+15) > 1 {
+		t.Errorf("orange shifted by %v degrees, want -15", d)
+	}
+	if math32.Abs(lch.L-orange.L) > 0.01 || math32.Abs(lch.C-orange.C) > 0.01 {
+		t.Errorf("harmonized orange %v changed lightness or chroma of %v", lch, orange)
+	}
+	if g := colorToOKLAB(got.At(8, 1)).DeltaE(colorToOKLAB(gray)); g > 1e-3 {
+		t.Errorf("gray changed by %v", g)
+	}
+	if _, _, _, a := got.At(0, 15).RGBA(); a != 0 {
+		t.Error("transparent pixel became opaque")
+	}
+
+	small := HarmonizeHues(img, ref, 5)
+	if d := hueDelta(orange.H, colorToOKLCH(small.At(8, 8)).H); math32.Abs(d+5) > 1 {
+		t.Errorf("orange shifted by %v degrees, want -5", d)
+	}
+	// A reference without chromatic colors leaves the image unchanged.
+	grayRef := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	fill(grayRef, grayRef.Bounds(), gray)
+	same := HarmonizeHues(img, grayRef, 15)
+	if d := colorToOKLAB(same.At(8, 8)).DeltaE(colorToOKLAB(img.At(8, 8))); d > 1e-3 {
+		t.Errorf("achromatic reference changed image by %v", d)
+	}
+}
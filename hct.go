@@ -0,0 +1,93 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+// HCT is the hue, chroma and tone color space of Material Design 3. Hue and chroma are those of
+// [CAM16] under [CAM16Default] viewing conditions and tone is CIELAB lightness L*, which directly
+// determines the contrast ratio between two colors. Keeping hue and chroma while changing tone
+// yields the tonal palettes Material color schemes are built from.
+type HCT struct {
+	H float32 // CAM16 hue in degrees in [0,360).
+	C float32 // CAM16 chroma. The maximum in sRGB depends on hue and tone and is about 130 at most.
+	T float32 // Tone in [0,100]: 0 is black and 100 is white.
+}
+
+func (c HCT) vec() ms3.Vec      { return ms3.Vec{X: c.H, Y: c.C, Z: c.T} }
+func (c HCT) Array() [3]float32 { return c.vec().Array() }
+
+// HCT converts the sRGB color to HCT.
+func (c SRGB) HCT() HCT {
+	xyz := c.LSRGB().CIEXYZ()
+	cam := CAM16Default.CAM16(xyz)
+	return HCT{H: cam.H, C: cam.C, T: lstarFromY(xyz.Y)}
+}
+
+// SRGB converts the HCT color to sRGB. Tone and hue are preserved exactly while chroma is reduced
+// to the largest in the sRGB gamut if the requested chroma is not achievable.
+func (c HCT) SRGB() SRGB {
+	t := ms1.Clamp(c.T, 0, 100)
+	y := yFromLstar(t)
+	if c.C <= 0 || t <= 0 || t >= 100 {
+		return LSRGB{R: y, G: y, B: y}.SRGB()
+	}
+	h := normalizeHue(c.H)
+	if lin, ok := hctSolve(h, c.C, y); ok {
+		return lin.SRGB()
+	}
+	lo, hi := float32(0), c.C
+	best := LSRGB{R: y, G: y, B: y}
+	for i := 0; i < 20; i++ {
+		mid := 0.5 * (lo + hi)
+		if lin, ok := hctSolve(h, mid, y); ok {
+			lo, best = mid, lin
+		} else {
+			hi = mid
+		}
+	}
+	return best.SRGB()
+}
+
+// Lerp interpolates between two HCT colors along the shortest hue arc. The hue of an
+// achromatic color is taken from the other color.
+func (from HCT) Lerp(to HCT, v float32) HCT {
+	c := CIELCH{L: from.T, C: from.C, H: from.H}.Lerp(CIELCH{L: to.T, C: to.C, H: to.H}, v)
+	return HCT{H: c.H, C: c.C, T: c.L}
+}
+
+// LerpHCT interpolates in HCT (hue, chroma, tone) along the shortest hue arc.
+// Result chroma is reduced at constant hue and tone if out of the sRGB gamut.
+func LerpHCT(c1, c2 color.Color, v float32) color.Color {
+	return ColorToSRGB(c1).HCT().Lerp(ColorToSRGB(c2).HCT(), v).SRGB()
+}
+
+// hctSolve returns the linear sRGB color with CAM16 hue h and chroma c and relative luminance y
+// and whether it lies inside the sRGB gamut.
+func hctSolve(h, c, y float32) (LSRGB, bool) {
+	const tol = 1e-5
+	// Luminance grows about as the square of CAM16 lightness.
+	j := 100 * math32.Sqrt(y)
+	var xyz CIEXYZ
+	for i := 0; i < 12; i++ {
+		xyz = CAM16Default.CIEXYZ(CAM16{J: j, C: c, H: h})
+		if xyz.Y <= 0 {
+			j *= 1.5
+			continue
+		}
+		if math32.Abs(xyz.Y-y) < 1e-6 {
+			break
+		}
+		j -= (xyz.Y - y) * j / (2 * xyz.Y)
+	}
+	if math32.Abs(xyz.Y-y) > 1e-4 {
+		return LSRGB{}, false
+	}
+	lin := xyz.LSRGB()
+	ok := lin.R >= -tol && lin.G >= -tol && lin.B >= -tol && lin.R <= 1+tol && lin.G <= 1+tol && lin.B <= 1+tol
+	return lin.ClipToGamut(), ok
+}
@@ -0,0 +1,37 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestHCT(t *testing.T) {
+	red := SRGB{R: 1}.HCT()
+	if math32.Abs(red.H-27.41) > 0.1 || math32.Abs(red.C-113.36) > 0.1 || math32.Abs(red.T-53.24) > 0.05 {
+		t.Errorf("red HCT %+v, want H=27.41 C=113.36 T=53.24", red)
+	}
+	for _, c := range []SRGB{{R: 1}, {G: 1}, {B: 1}, {R: 0.2, G: 0.5, B: 0.8}, {R: 0.9, G: 0.8, B: 0.1}, {R: 0.5, G: 0.5, B: 0.5}, {R: 0.02, G: 0.01, B: 0.03}} {
+		hct := c.HCT()
+		if got := hct.SRGB(); sqdist(got.vec(), c.vec()) > 1e-5 {
+			t.Errorf("HCT round trip of %+v via %+v gave %+v", c, hct, got)
+		}
+	}
+	// Unachievable chroma is reduced keeping hue and tone.
+	for _, tone := range []float32{10, 50, 90} {
+		got := HCT{H: 250, C: 200, T: tone}.SRGB()
+		if !got.LSRGB().InGamut() {
+			t.Errorf("tone %v: out of gamut %+v", tone, got)
+		}
+		back := got.HCT()
+		if math32.Abs(back.T-tone) > 0.1 || math32.Abs(back.H-250) > 1 {
+			t.Errorf("tone %v: got %+v", tone, back)
+		}
+	}
+	if got := (HCT{H: 120, C: 30, T: 0}).SRGB(); got != (SRGB{}) {
+		t.Errorf("tone 0 should be black, got %+v", got)
+	}
+	if got := ColorToSRGB(LerpHCT(SRGB{R: 1}, SRGB{B: 1}, 0.5)).HCT(); math32.Abs(got.T-0.5*(53.24+32.30)) > 0.2 {
+		t.Errorf("HCT lerp midpoint tone %v", got.T)
+	}
+}
@@ -0,0 +1,75 @@
+package colorspace
+
+import (
+	"image"
+	"sort"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+// MatchHistograms returns a copy of target whose per-channel value distributions match those of reference
+// by cumulative distribution (CDF) matching, a stronger alternative to [ReinhardTransfer] which only matches
+// mean and standard deviation. Channels are those of space, i.e: OKLAB L, a and b or linear sRGB R, G and B.
+// Only channels for which the corresponding element of channels is true are matched, so
+// matching only OKLAB lightness transfers tonality while preserving colors.
+// Fully transparent pixels are ignored, alpha is preserved and out of gamut results are clipped.
+func MatchHistograms(target, reference image.Image, space ColorTransferSpace, channels [3]bool) *image.RGBA64 {
+	src := channelValues(target, space)
+	ref := channelValues(reference, space)
+	return mapImageSRGB(target, func(c SRGB) SRGB {
+		v := space.from(c.LSRGB()).Array()
+		for i := range v {
+			if channels[i] && len(ref[i]) > 0 {
+				v[i] = quantile(ref[i], cdf(src[i], v[i]))
+			}
+		}
+		return space.to(ms3.Vec{X: v[0], Y: v[1], Z: v[2]}).ClipToGamut().SRGB()
+	})
+}
+
+// channelValues returns the sorted values of each channel of the non-transparent pixels of img.
+func channelValues(img image.Image, space ColorTransferSpace) (values [3][]float32) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			for i, v := range space.from(c.LSRGB()).Array() {
+				values[i] = append(values[i], v)
+			}
+		}
+	}
+	for i := range values {
+		sort.Slice(values[i], func(a, b int) bool { return values[i][a] < values[i][b] })
+	}
+	return values
+}
+
+// cdf returns the empirical cumulative distribution in [0,1] of v in sorted. Ties are assigned
+// the mid rank so that constant regions map to the middle of the reference distribution.
+func cdf(sorted []float32, v float32) float32 {
+	n := len(sorted)
+	if n <= 1 {
+		return 0.5
+	}
+	lo := sort.Search(n, func(i int) bool { return sorted[i] >= v })
+	hi := sort.Search(n, func(i int) bool { return sorted[i] > v })
+	rank := 0.5 * float32(lo+hi-1)
+	if rank < 0 {
+		rank = 0
+	}
+	return rank / float32(n-1)
+}
+
+// quantile returns the value at cumulative probability p in [0,1] of sorted by linear interpolation.
+func quantile(sorted []float32, p float32) float32 {
+	pos := p * float32(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float32(i)
+	return sorted[i] + frac*(sorted[i+1]-sorted[i])
+}
@@ -0,0 +1,52 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestMatchHistograms(t *testing.T) {
+	ramp := func(n int, fn func(float32) color.Color) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, n, 1))
+		for x := 0; x < n; x++ {
+			img.Set(x, 0, fn(float32(x)/float32(n-1)))
+		}
+		return img
+	}
+	target := ramp(32, func(v float32) color.Color {
+		return SRGB{R: 0.2 + 0.3*v, G: 0.3 + 0.3*v, B: 0.5 + 0.2*v}
+	})
+	reference := ramp(64, func(v float32) color.Color {
+		g := 0.15 + 0.7*v*v
+		return SRGB{R: g, G: g, B: g}
+	})
+	got := MatchHistograms(target, reference, ColorTransferOKLAB, [3]bool{true, false, false})
+	want := channelValues(reference, ColorTransferOKLAB)[0]
+	gotL := channelValues(got, ColorTransferOKLAB)
+	srcL := channelValues(target, ColorTransferOKLAB)
+	for i, l := range gotL[0] {
+		p := float32(i) / float32(len(gotL[0])-1)
+		if wantL := quantile(want, p); math32.Abs(l-wantL) > 0.01 {
+			t.Errorf("quantile %v: got lightness %v, want %v", p, l, wantL)
+		}
+	}
+	// Unmatched channels keep their distribution up to gamut clipping and quantization.
+	for i := range srcL[1] {
+		if math32.Abs(gotL[1][i]-srcL[1][i]) > 0.02 {
+			t.Errorf("unmatched channel a changed at %d: %v != %v", i, gotL[1][i], srcL[1][i])
+			break
+		}
+	}
+	// Matching an image to itself leaves it unchanged.
+	same := MatchHistograms(target, target, ColorTransferLSRGB, [3]bool{true, true, true})
+	for x := 0; x < 32; x++ {
+		r1, g1, b1, _ := target.At(x, 0).RGBA()
+		r2, g2, b2, _ := same.At(x, 0).RGBA()
+		if absDiffU32(r1, r2) > 0x100 || absDiffU32(g1, g2) > 0x100 || absDiffU32(b1, b2) > 0x100 {
+			t.Fatalf("self match changed pixel %d", x)
+		}
+	}
+}
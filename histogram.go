@@ -0,0 +1,131 @@
+package colorspace
+
+import (
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// OKLCHHistogram is a 3D histogram over [OKLCH] lightness, chroma and hue with
+// configurable bin counts per axis, used for color-based image search and statistics.
+// Lightness bins span [0,1], chroma bins span [0,MaxChroma] and hue bins span [0,360).
+// Values outside the lightness and chroma ranges fall into the first or last bin.
+type OKLCHHistogram struct {
+	LBins, CBins, HBins int
+	// MaxChroma is the upper limit of the chroma range.
+	MaxChroma float32
+	// Counts holds the weighted count of each bin. See [OKLCHHistogram.Index] for its layout.
+	Counts []float32
+	// sums holds the weighted OKLAB sum of each bin to compute representative colors.
+	sums []ms3.Vec
+}
+
+// NewOKLCHHistogram returns an empty histogram with the given bins per axis.
+// MaxChroma is set to 0.37, which contains all of sRGB.
+func NewOKLCHHistogram(lbins, cbins, hbins int) *OKLCHHistogram {
+	if lbins <= 0 || cbins <= 0 || hbins <= 0 {
+		panic("histogram bin counts must be positive")
+	}
+	n := lbins * cbins * hbins
+	return &OKLCHHistogram{
+		LBins:     lbins,
+		CBins:     cbins,
+		HBins:     hbins,
+		MaxChroma: 0.37,
+		Counts:    make([]float32, n),
+		sums:      make([]ms3.Vec, n),
+	}
+}
+
+// Index returns the index into Counts of the bin containing c. Bins are laid out with
+// hue varying fastest, then chroma, then lightness: (l*CBins + c)*HBins + h.
+func (h *OKLCHHistogram) Index(c OKLCH) int {
+	il := histBin(c.L, h.LBins)
+	ic := histBin(c.C/h.MaxChroma, h.CBins)
+	ih := histBin(wrapHue(c.H)/360, h.HBins)
+	return (il*h.CBins+ic)*h.HBins + ih
+}
+
+// BinCoords returns the lightness, chroma and hue bin coordinates of bin index i.
+func (h *OKLCHHistogram) BinCoords(i int) (l, c, hue int) {
+	hue = i % h.HBins
+	c = (i / h.HBins) % h.CBins
+	l = i / (h.HBins * h.CBins)
+	return l, c, hue
+}
+
+// Add adds the color to its bin with the given weight.
+func (h *OKLCHHistogram) Add(c OKLCH, weight float32) {
+	i := h.Index(c)
+	h.Counts[i] += weight
+	h.sums[i] = ms3.Add(h.sums[i], ms3.Scale(weight, c.OKLAB().vec()))
+}
+
+// AddImage adds all pixels of img to the histogram. If mask is not nil each pixel is weighted by the
+// alpha of the mask at the same location, i.e: a mask produced by [SelectionMask].
+// Transparent pixels of img are weighted by their alpha.
+func (h *OKLCHHistogram) AddImage(img, mask image.Image) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := img.At(x, y)
+			_, _, _, a := px.RGBA()
+			weight := float32(a) / 0xffff
+			if mask != nil {
+				_, _, _, ma := mask.At(x, y).RGBA()
+				weight *= float32(ma) / 0xffff
+			}
+			if weight == 0 {
+				continue
+			}
+			// Unpremultiply since colorToOKLCH discards alpha.
+			r, g, b, _ := px.RGBA()
+			lin := SRGB{R: float32(r) / float32(a), G: float32(g) / float32(a), B: float32(b) / float32(a)}.LSRGB()
+			h.Add(lin.CIEXYZ().OKLAB().OKLCH(), weight)
+		}
+	}
+}
+
+// Total returns the sum of all bin counts.
+func (h *OKLCHHistogram) Total() float32 {
+	var sum float32
+	for _, c := range h.Counts {
+		sum += c
+	}
+	return sum
+}
+
+// Representative returns the representative color of bin i: the weighted mean of the colors
+// added to it, or the bin center if the bin is empty.
+func (h *OKLCHHistogram) Representative(i int) OKLCH {
+	if h.Counts[i] > 0 {
+		mean := ms3.Scale(1/h.Counts[i], h.sums[i])
+		return OKLAB{L: mean.X, A: mean.Y, B: mean.Z}.OKLCH()
+	}
+	l, c, hue := h.BinCoords(i)
+	return OKLCH{
+		L: (float32(l) + 0.5) / float32(h.LBins),
+		C: (float32(c) + 0.5) * h.MaxChroma / float32(h.CBins),
+		H: (float32(hue) + 0.5) * 360 / float32(h.HBins),
+	}
+}
+
+// Reset clears all bins so the histogram can be reused.
+func (h *OKLCHHistogram) Reset() {
+	for i := range h.Counts {
+		h.Counts[i] = 0
+		h.sums[i] = ms3.Vec{}
+	}
+}
+
+// histBin returns the bin of a normalized value v in [0,1) clamping values outside the range.
+func histBin(v float32, nbins int) int {
+	i := int(math32.Floor(v * float32(nbins)))
+	if i < 0 {
+		return 0
+	} else if i >= nbins {
+		return nbins - 1
+	}
+	return i
+}
@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestOKLCHHistogram(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	mask := image.NewAlpha(img.Bounds())
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 3 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+			if y < 2 {
+				mask.SetAlpha(x, y, color.Alpha{A: 255})
+			}
+		}
+	}
+	h := NewOKLCHHistogram(4, 4, 12)
+	h.AddImage(img, nil)
+	if total := h.Total(); total != 16 {
+		t.Fatalf("got total %v, want 16", total)
+	}
+	ired := h.Index(colorToOKLCH(red))
+	if h.Counts[ired] != 12 {
+		t.Errorf("got %v red pixels, want 12", h.Counts[ired])
+	}
+	rep := h.Representative(ired)
+	want := colorToOKLCH(red)
+	if math32.Abs(rep.L-want.L) > 1e-4 || math32.Abs(rep.C-want.C) > 1e-4 || math32.Abs(rep.H-want.H) > 1e-2 {
+		t.Errorf("representative %+v differs from red %+v", rep, want)
+	}
+	l, c, hue := h.BinCoords(ired)
+	if got := (l*h.CBins+c)*h.HBins + hue; got != ired {
+		t.Errorf("bin coordinates do not round trip: %d != %d", got, ired)
+	}
+
+	h.Reset()
+	h.AddImage(img, mask)
+	if total := h.Total(); total != 8 {
+		t.Errorf("got masked total %v, want 8", total)
+	}
+	if n := h.Counts[h.Index(colorToOKLCH(blue))]; n != 2 {
+		t.Errorf("got %v masked blue pixels, want 2", n)
+	}
+}
@@ -0,0 +1,139 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// HueStats contains circular statistics over a set of hue angles.
+// Regular (linear) statistics on hue are wrong since hue wraps around at 360 degrees,
+// i.e: the mean of 350° and 10° is 0°, not 180°.
+type HueStats struct {
+	// Mean is the circular mean hue in degrees in [0,360).
+	// It is undefined and set to zero when Resultant is zero.
+	Mean float32
+	// Resultant is the mean resultant length in [0,1]. A value of 1 means all
+	// hues are equal and a value near 0 means hues are spread around the hue circle.
+	Resultant float32
+}
+
+// Variance returns the circular variance in [0,1]. 0 means all hues are equal.
+func (hs HueStats) Variance() float32 { return 1 - hs.Resultant }
+
+// StdDev returns the circular standard deviation in degrees, a measure of hue spread.
+// It is infinite when Resultant is zero.
+func (hs HueStats) StdDev() float32 {
+	return math32.Sqrt(-2*math32.Log(hs.Resultant)) * 180 / math32.Pi
+}
+
+// HueStatistics computes the circular mean and mean resultant length of hue angles in degrees.
+// weights may be nil in which case all hues are weighted equally. A common choice
+// for weights is the chroma of each color so that near-gray colors, whose hue is meaningless,
+// do not contribute to the result.
+func HueStatistics(hues, weights []float32) HueStats {
+	if weights != nil && len(weights) != len(hues) {
+		panic("length of weights must match length of hues")
+	}
+	var sumSin, sumCos, sumW float32
+	for i, h := range hues {
+		var w float32 = 1
+		if weights != nil {
+			w = weights[i]
+		}
+		sin, cos := math32.Sincos(h * math32.Pi / 180)
+		sumSin += w * sin
+		sumCos += w * cos
+		sumW += w
+	}
+	if sumW == 0 {
+		return HueStats{}
+	}
+	r := math32.Hypot(sumSin, sumCos) / sumW
+	if r <= epsUnit {
+		return HueStats{Mean: undefinedHue, Resultant: 0}
+	}
+	return HueStats{
+		Mean:      wrapHue(math32.Atan2(sumSin, sumCos) * 180 / math32.Pi),
+		Resultant: math32.Min(r, 1),
+	}
+}
+
+// HueHistogram accumulates hue angles in degrees into the bins of dst, which span
+// the hue circle with equal widths. Bin i covers hues [i*w, (i+1)*w) where w = 360/len(dst).
+// weights may be nil in which case each hue adds one to its bin. dst is returned.
+func HueHistogram(dst []float32, hues, weights []float32) []float32 {
+	if len(dst) == 0 {
+		panic("zero length histogram")
+	} else if weights != nil && len(weights) != len(hues) {
+		panic("length of weights must match length of hues")
+	}
+	nbins := float32(len(dst))
+	for i, h := range hues {
+		var w float32 = 1
+		if weights != nil {
+			w = weights[i]
+		}
+		ibin := int(wrapHue(h) * nbins / 360)
+		if ibin >= len(dst) {
+			ibin = len(dst) - 1 // Float rounding.
+		}
+		dst[ibin] += w
+	}
+	return dst
+}
+
+// DominantHue returns the dominant hue in degrees of a histogram generated by [HueHistogram].
+// The peak bin and its two neighbors (wrapping around) are combined with a circular mean so that the
+// result is not quantized to bin centers.
+func DominantHue(hist []float32) float32 {
+	if len(hist) == 0 {
+		panic("zero length histogram")
+	}
+	ipeak := 0
+	for i := range hist {
+		if hist[i] > hist[ipeak] {
+			ipeak = i
+		}
+	}
+	n := len(hist)
+	width := 360 / float32(n)
+	var hues, weights [3]float32
+	for j := 0; j < 3; j++ {
+		ibin := (ipeak + j - 1 + n) % n
+		hues[j] = (float32(ibin) + 0.5) * width
+		weights[j] = hist[ibin]
+	}
+	return HueStatistics(hues[:], weights[:]).Mean
+}
+
+// PaletteHues returns the [OKLCH] hue and chroma of each color in the palette.
+// The chromas are suited for use as weights in [HueStatistics] and [HueHistogram].
+func PaletteHues(p color.Palette) (hues, chromas []float32) {
+	hues = make([]float32, len(p))
+	chromas = make([]float32, len(p))
+	for i, c := range p {
+		lch := colorToOKLCH(c)
+		hues[i] = lch.H
+		chromas[i] = lch.C
+	}
+	return hues, chromas
+}
+
+// ImageHues returns the [OKLCH] hue and chroma of each pixel in the image in row-major order.
+// The chromas are suited for use as weights in [HueStatistics] and [HueHistogram].
+func ImageHues(img image.Image) (hues, chromas []float32) {
+	bounds := img.Bounds()
+	n := bounds.Dx() * bounds.Dy()
+	hues = make([]float32, 0, n)
+	chromas = make([]float32, 0, n)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			lch := colorToOKLCH(img.At(x, y))
+			hues = append(hues, lch.H)
+			chromas = append(chromas, lch.C)
+		}
+	}
+	return hues, chromas
+}
@@ -0,0 +1,23 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestHueStatistics(t *testing.T) {
+	stats := HueStatistics([]float32{350, 10}, nil)
+	if math32.Abs(stats.Mean) > 1e-3 && math32.Abs(stats.Mean-360) > 1e-3 {
+		t.Errorf("expected circular mean of 350° and 10° to be 0°, got %v", stats.Mean)
+	}
+	stats = HueStatistics([]float32{0, 180}, nil)
+	if stats.Resultant != 0 {
+		t.Errorf("expected opposite hues to have zero resultant, got %v", stats.Resultant)
+	}
+	hist := HueHistogram(make([]float32, 36), []float32{355, 359, 2, 120}, nil)
+	dominant := DominantHue(hist)
+	if math32.Abs(dominant-360) > 5 && dominant > 5 {
+		t.Errorf("expected dominant hue near 0°, got %v", dominant)
+	}
+}
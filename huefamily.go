@@ -0,0 +1,131 @@
+package colorspace
+
+import "image/color"
+
+// HueFamily is a named range of [OKLCH] hues used for classifying colors, i.e: "blue".
+// The range covers hues in [Start, End) degrees and wraps around 360 when Start > End.
+type HueFamily struct {
+	Name       string
+	Start, End float32
+}
+
+// Contains reports whether the hue in degrees lies within the family's range.
+func (f HueFamily) Contains(hue float32) bool {
+	hue = wrapHue(hue)
+	if f.Start <= f.End {
+		return hue >= f.Start && hue < f.End
+	}
+	return hue >= f.Start || hue < f.End
+}
+
+// DefaultHueFamilies returns eight hue families spanning the OKLCH hue circle:
+// pink, red, orange, yellow, green, cyan, blue and purple.
+func DefaultHueFamilies() []HueFamily {
+	return []HueFamily{
+		{Name: "pink", Start: 340, End: 10},
+		{Name: "red", Start: 10, End: 45},
+		{Name: "orange", Start: 45, End: 85},
+		{Name: "yellow", Start: 85, End: 120},
+		{Name: "green", Start: 120, End: 170},
+		{Name: "cyan", Start: 170, End: 225},
+		{Name: "blue", Start: 225, End: 285},
+		{Name: "purple", Start: 285, End: 340},
+	}
+}
+
+// Lightness qualifiers returned by [HueClassifier.Classify].
+const (
+	LightnessDark   = "dark"
+	LightnessMedium = "medium"
+	LightnessLight  = "light"
+)
+
+// Chroma qualifiers returned by [HueClassifier.Classify].
+const (
+	ChromaNeutral  = "neutral"
+	ChromaMuted    = "muted"
+	ChromaModerate = "moderate"
+	ChromaVivid    = "vivid"
+)
+
+// ColorClass is the result of classifying a color with a [HueClassifier].
+type ColorClass struct {
+	// Family is the name of the hue family or one of "black", "gray" or "white" for neutral colors.
+	Family string
+	// Lightness is one of [LightnessDark], [LightnessMedium] or [LightnessLight].
+	Lightness string
+	// Chroma is one of [ChromaNeutral], [ChromaMuted], [ChromaModerate] or [ChromaVivid].
+	Chroma string
+}
+
+// HueClassifier maps colors to hue families with lightness and chroma qualifiers
+// for faceted search, i.e: "show me blue products". The zero value is not usable;
+// use [NewHueClassifier] for sensible defaults.
+type HueClassifier struct {
+	// Families are searched in order and the first containing the color's hue is returned.
+	Families []HueFamily
+	// NeutralChroma is the OKLCH chroma below which colors are classified as black, gray or white.
+	NeutralChroma float32
+	// VividChroma is the chroma at or above which colors are vivid. Colors
+	// between NeutralChroma and VividChroma are muted (lower half) or moderate (upper half).
+	VividChroma float32
+	// DarkLightness and LightLightness are the OKLCH lightness thresholds of the dark and light qualifiers.
+	DarkLightness, LightLightness float32
+}
+
+// NewHueClassifier returns a classifier with [DefaultHueFamilies] and thresholds tuned for sRGB colors.
+func NewHueClassifier() *HueClassifier {
+	return &HueClassifier{
+		Families:       DefaultHueFamilies(),
+		NeutralChroma:  0.03,
+		VividChroma:    0.13,
+		DarkLightness:  0.45,
+		LightLightness: 0.8,
+	}
+}
+
+// Classify returns the hue family and qualifiers of c. If no family contains
+// the hue of a chromatic color the returned Family is empty.
+func (hc *HueClassifier) Classify(c color.Color) ColorClass {
+	return hc.ClassifyOKLCH(colorToOKLCH(c))
+}
+
+// ClassifyOKLCH is like [HueClassifier.Classify] but accepts an [OKLCH] color.
+func (hc *HueClassifier) ClassifyOKLCH(c OKLCH) ColorClass {
+	var class ColorClass
+	switch {
+	case c.L < hc.DarkLightness:
+		class.Lightness = LightnessDark
+	case c.L >= hc.LightLightness:
+		class.Lightness = LightnessLight
+	default:
+		class.Lightness = LightnessMedium
+	}
+	if c.C < hc.NeutralChroma {
+		class.Chroma = ChromaNeutral
+		switch class.Lightness {
+		case LightnessDark:
+			class.Family = "black"
+		case LightnessLight:
+			class.Family = "white"
+		default:
+			class.Family = "gray"
+		}
+		return class
+	}
+	switch mid := 0.5 * (hc.NeutralChroma + hc.VividChroma); {
+	case c.C >= hc.VividChroma:
+		class.Chroma = ChromaVivid
+	case c.C >= mid:
+		class.Chroma = ChromaModerate
+	default:
+		class.Chroma = ChromaMuted
+	}
+	for _, f := range hc.Families {
+		if f.Contains(c.H) {
+			class.Family = f.Name
+			break
+		}
+	}
+	return class
+}
@@ -0,0 +1,33 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestHueClassifier(t *testing.T) {
+	hc := NewHueClassifier()
+	for _, test := range []struct {
+		c    color.RGBA
+		want ColorClass
+	}{
+		{c: color.RGBA{R: 255, A: 255}, want: ColorClass{Family: "red", Lightness: LightnessMedium, Chroma: ChromaVivid}},
+		{c: color.RGBA{R: 255, G: 165, A: 255}, want: ColorClass{Family: "orange", Lightness: LightnessMedium, Chroma: ChromaVivid}},
+		{c: color.RGBA{R: 255, G: 255, A: 255}, want: ColorClass{Family: "yellow", Lightness: LightnessLight, Chroma: ChromaVivid}},
+		{c: color.RGBA{G: 128, A: 255}, want: ColorClass{Family: "green", Lightness: LightnessMedium, Chroma: ChromaVivid}},
+		{c: color.RGBA{B: 128, A: 255}, want: ColorClass{Family: "blue", Lightness: LightnessDark, Chroma: ChromaVivid}},
+		{c: color.RGBA{R: 128, B: 128, A: 255}, want: ColorClass{Family: "purple", Lightness: LightnessDark, Chroma: ChromaVivid}},
+		{c: color.RGBA{R: 100, G: 110, B: 130, A: 255}, want: ColorClass{Family: "blue", Lightness: LightnessMedium, Chroma: ChromaMuted}},
+		{c: color.RGBA{R: 250, G: 250, B: 250, A: 255}, want: ColorClass{Family: "white", Lightness: LightnessLight, Chroma: ChromaNeutral}},
+		{c: color.RGBA{R: 128, G: 128, B: 128, A: 255}, want: ColorClass{Family: "gray", Lightness: LightnessMedium, Chroma: ChromaNeutral}},
+		{c: color.RGBA{R: 20, G: 20, B: 20, A: 255}, want: ColorClass{Family: "black", Lightness: LightnessDark, Chroma: ChromaNeutral}},
+	} {
+		got := hc.Classify(test.c)
+		if got != test.want {
+			t.Errorf("%v: got %+v, want %+v", test.c, got, test.want)
+		}
+	}
+	if !(HueFamily{Start: 340, End: 10}).Contains(365) {
+		t.Error("wrapping hue family should contain 5 degrees")
+	}
+}
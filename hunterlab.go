@@ -0,0 +1,58 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// HunterLab is the Hunter L, a, b color space of 1948, a square root transformation of [CIEXYZ]
+// that predates CIELAB. It is still reported by colorimeters used for quality control in the food,
+// paint and plastics industries. Values are relative to the white point of the measurement,
+// usually illuminant C or D65. Create illuminant C with Illuminant(1, 0.3101, 0.3162).
+type HunterLab struct {
+	L float32 // Lightness in [0,100].
+	A float32 // Red-green axis.
+	B float32 // Yellow-blue axis.
+}
+
+func (c HunterLab) vec() ms3.Vec      { return ms3.Vec{X: c.L, Y: c.A, Z: c.B} }
+func (c HunterLab) Array() [3]float32 { return c.vec().Array() }
+
+// HunterLab converts the XYZ color to Hunter Lab relative to the white point. The white point
+// must be expressed in the same scale as c, i.e: IlluminantD65(1) for D65 relative XYZ.
+func (c CIEXYZ) HunterLab(white CIEXYZ) HunterLab {
+	ka, kb := hunterCoefficients(white)
+	y := c.Y / white.Y
+	if y <= 0 {
+		return HunterLab{}
+	}
+	sy := math32.Sqrt(y)
+	return HunterLab{
+		L: 100 * sy,
+		A: ka * (c.X/white.X - y) / sy,
+		B: kb * (y - c.Z/white.Z) / sy,
+	}
+}
+
+// CIEXYZ converts the Hunter Lab color to XYZ in the scale of the white point. See [CIEXYZ.HunterLab].
+func (c HunterLab) CIEXYZ(white CIEXYZ) CIEXYZ {
+	ka, kb := hunterCoefficients(white)
+	sy := c.L / 100
+	y := sy * sy
+	return CIEXYZ{
+		X: white.X * (c.A*sy/ka + y),
+		Y: white.Y * y,
+		Z: white.Z * (y - c.B*sy/kb),
+	}
+}
+
+// DeltaE returns the Hunter color difference, the Euclidean distance between the colors.
+func (c HunterLab) DeltaE(other HunterLab) float32 {
+	return ms3.Norm(ms3.Sub(c.vec(), other.vec()))
+}
+
+// hunterCoefficients returns the chromaticity coefficients Ka and Kb of the Hunter Lab white point,
+// which are 175 and 70 for illuminant C.
+func hunterCoefficients(white CIEXYZ) (ka, kb float32) {
+	return 175. / 198.04 * 100 * (white.X + white.Y) / white.Y, 70. / 218.11 * 100 * (white.Y + white.Z) / white.Y
+}
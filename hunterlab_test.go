@@ -0,0 +1,34 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+func TestHunterLab(t *testing.T) {
+	white := IlluminantD65(1)
+	if got := white.HunterLab(white); sqdist(got.vec(), ms3.Vec{X: 100}) > 1e-8 {
+		t.Errorf("white = %v, want L=100", got)
+	}
+	if got := IlluminantD65(0.25).HunterLab(white); sqdist(got.vec(), ms3.Vec{X: 50}) > 1e-8 {
+		t.Errorf("gray of luminance 0.25 = %v, want L=50", got)
+	}
+	ka, kb := hunterCoefficients(Illuminant(1, 0.3101, 0.3162))
+	if math32.Abs(ka-175) > 0.1 || math32.Abs(kb-70) > 0.1 {
+		t.Errorf("illuminant C coefficients = %v, %v, want 175, 70", ka, kb)
+	}
+	for _, c := range []SRGB{{R: 1}, {G: 0.5, B: 0.2}, {R: 0.1, G: 0.2, B: 0.9}, {R: 0.9, G: 0.8, B: 0.1}} {
+		xyz := c.LSRGB().CIEXYZ()
+		hunter := xyz.HunterLab(white)
+		if got := hunter.CIEXYZ(white); sqdist(got.vec(), xyz.vec()) > 1e-10 {
+			t.Errorf("round trip of %v = %v", xyz, got)
+		}
+		lab := xyz.d65ToD50().CIELAB()
+		// Hunter a and b have the same sign as CIELAB a* and b* for saturated colors.
+		if hunter.A*lab.A < 0 || hunter.B*lab.B < 0 {
+			t.Errorf("%v: Hunter %v and CIELAB %v opponent axes disagree", c, hunter, lab)
+		}
+	}
+}
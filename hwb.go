@@ -0,0 +1,81 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+// HWB is the Hue-Whiteness-Blackness representation of sRGB colors introduced by Alvy Ray Smith and
+// exposed by CSS Color 4 as hwb(). A color is described as its pure hue mixed with an amount of white
+// and black, which many find more intuitive than [HSV] or [HSL]. Whenever W+B is 1 or more the
+// color is the gray W/(W+B); see [HWB.Normalize].
+type HWB struct {
+	H float32 // Hue in degrees in [0,360). Same as for [HSV].
+	W float32 // Whiteness in [0,1].
+	B float32 // Blackness in [0,1].
+}
+
+func (c HWB) vec() ms3.Vec      { return ms3.Vec{X: c.H, Y: c.W, Z: c.B} }
+func (c HWB) Array() [3]float32 { return c.vec().Array() }
+
+// HWB converts the sRGB color to HWB. Grays have hue 0.
+func (c SRGB) HWB() HWB { return c.HSV().HWB() }
+
+// SRGB converts the HWB color to sRGB following CSS Color 4: whiteness and blackness
+// summing 1 or more produce a gray.
+func (c HWB) SRGB() SRGB { return c.HSV().SRGB() }
+
+// HWB converts the HSV color to HWB.
+func (c HSV) HWB() HWB {
+	return HWB{H: c.H, W: (1 - c.S) * c.V, B: 1 - c.V}
+}
+
+// HSV converts the HWB color to HSV normalizing it first. See [HWB.Normalize].
+func (c HWB) HSV() HSV {
+	c = c.Normalize()
+	v := 1 - c.B
+	var s float32
+	if v > 0 {
+		s = 1 - c.W/v
+	}
+	return HSV{H: c.H, S: s, V: v}
+}
+
+// Normalize wraps the hue of c to [0,360), clamps whiteness and blackness to [0,1] and applies the CSS Color 4 rule:
+// if W+B is 1 or more both are scaled down proportionally so they add to 1, yielding the achromatic color.
+func (c HWB) Normalize() HWB {
+	c.H = normalizeHue(c.H)
+	c.W, c.B = ms1.Clamp(c.W, 0, 1), ms1.Clamp(c.B, 0, 1)
+	if sum := c.W + c.B; sum >= 1 {
+		c.W /= sum
+		c.B /= sum
+	}
+	return c
+}
+
+// LerpHWB interpolates in HWB (hue, whiteness, blackness) along the shortest hue arc
+// as CSS gradients with "in hwb" do.
+func LerpHWB(c1, c2 color.Color, v float32) color.Color {
+	return ColorToSRGB(c1).HWB().Lerp(ColorToSRGB(c2).HWB(), v).SRGB()
+}
+
+// Lerp interpolates between two HWB colors along the shortest hue arc. The hue of an
+// achromatic color, with W+B of 1 or more, is taken from the other color.
+func (from HWB) Lerp(to HWB, v float32) HWB {
+	return lerpHWB(from, to, v, HueShorter)
+}
+
+func lerpHWB(from, to HWB, v float32, method HueInterpolation) HWB {
+	if from.W+from.B >= 1 {
+		from.H = to.H
+	} else if to.W+to.B >= 1 {
+		to.H = from.H
+	}
+	return HWB{
+		H: InterpHue(from.H, to.H, v, method),
+		W: ms1.Interp(from.W, to.W, v),
+		B: ms1.Interp(from.B, to.B, v),
+	}
+}
@@ -0,0 +1,39 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestHWB(t *testing.T) {
+	colors := []SRGB{
+		{R: 1}, {G: 1}, {B: 1}, {R: 1, G: 1}, {G: 1, B: 1}, {R: 1, B: 1},
+		{R: 0.2, G: 0.4, B: 0.6}, {R: 0.9, G: 0.5, B: 0.1}, {R: 0.5, G: 0.5, B: 0.5}, {R: 1, G: 1, B: 1}, {},
+	}
+	for _, c := range colors {
+		hwb := c.HWB()
+		if got := hwb.SRGB(); sqdist(got.vec(), c.vec()) > 1e-10 {
+			t.Errorf("HWB round trip of %+v via %+v gave %+v", c, hwb, got)
+		}
+		// Whiteness is the smallest channel and blackness one minus the largest.
+		if w, b := math32.Min(c.R, math32.Min(c.G, c.B)), 1-math32.Max(c.R, math32.Max(c.G, c.B)); math32.Abs(hwb.W-w) > 1e-6 || math32.Abs(hwb.B-b) > 1e-6 {
+			t.Errorf("%+v HWB %+v, want W=%v B=%v", c, hwb, w, b)
+		}
+	}
+	// W+B >= 1 is the gray W/(W+B) regardless of hue.
+	for _, h := range []float32{0, 120, 250} {
+		got := HWB{H: h, W: 0.6, B: 0.6}.SRGB()
+		if want := (SRGB{R: 0.5, G: 0.5, B: 0.5}); sqdist(got.vec(), want.vec()) > 1e-10 {
+			t.Errorf("hwb(%v 60%% 60%%) = %+v, want %+v", h, got, want)
+		}
+	}
+	if got := (HWB{H: -30, W: 0.8, B: 0.4}).Normalize(); got.H != 330 || math32.Abs(got.W+got.B-1) > 1e-6 {
+		t.Errorf("Normalize gave %+v", got)
+	}
+	// Interpolating from white keeps the hue of the other color.
+	mid := ColorToSRGB(LerpHWB(SRGB{R: 1, G: 1, B: 1}, SRGB{B: 1}, 0.5)).HWB()
+	if math32.Abs(mid.H-240) > 1e-3 || math32.Abs(mid.W-0.5) > 1e-3 || mid.B > 1e-3 {
+		t.Errorf("white to blue midpoint %+v", mid)
+	}
+}
@@ -0,0 +1,132 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"runtime"
+	"sync"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+// HyperspectralCube is a hyperspectral image: a spectral reflectance sample per band for each pixel,
+// as captured by remote sensing and laboratory imaging spectrometers.
+type HyperspectralCube struct {
+	Width, Height int
+	// Wavelengths are the band center wavelengths in nanometers in increasing order.
+	Wavelengths []float32
+	// Data holds the samples in band interleaved by pixel (BIP) order: the sample of band k
+	// at (x,y) is Data[(y*Width+x)*len(Wavelengths)+k]. Reflectances are expected in [0,1].
+	Data []float32
+}
+
+// SpectralWeights returns the tristimulus weights of the bands at the given wavelengths viewed under illuminant
+// for the CIE 1931 2° standard observer: the XYZ of a pixel is the sum of its band samples multiplied by the
+// band weights. The spectrum between band centers is linearly interpolated and is zero outside the bands.
+// Weights are normalized so a perfect reflector has Y=1, matching [ReflectanceXYZ].
+func SpectralWeights(wavelengths []float32, illuminant Spectrum) []CIEXYZ {
+	weights := make([]ms3.Vec, len(wavelengths))
+	var white float32
+	// Integrate in 1nm steps so narrow bands are not skipped by the 10nm color matching function table.
+	for λ := float32(cmfStart); λ <= cmfStart+cmfStep*float32(len(cmf1931)-1); λ++ {
+		cmf := ms3.Scale(illuminant.At(λ), cmfAt(λ))
+		white += cmf.Y
+		k := bandIndex(wavelengths, λ)
+		switch {
+		case k < 0:
+			continue
+		case k == len(wavelengths)-1:
+			weights[k] = ms3.Add(weights[k], cmf)
+			continue
+		}
+		frac := (λ - wavelengths[k]) / (wavelengths[k+1] - wavelengths[k])
+		weights[k] = ms3.Add(weights[k], ms3.Scale(1-frac, cmf))
+		weights[k+1] = ms3.Add(weights[k+1], ms3.Scale(frac, cmf))
+	}
+	xyz := make([]CIEXYZ, len(weights))
+	if white == 0 {
+		return xyz
+	}
+	for k, w := range weights {
+		w = ms3.Scale(1/white, w)
+		xyz[k] = CIEXYZ{X: w.X, Y: w.Y, Z: w.Z}
+	}
+	return xyz
+}
+
+// bandIndex returns the index of the band whose center is the greatest not exceeding λ, or -1 if λ is outside the bands.
+func bandIndex(wavelengths []float32, λ float32) int {
+	n := len(wavelengths)
+	if n == 0 || λ < wavelengths[0] || λ > wavelengths[n-1] {
+		return -1
+	}
+	lo, hi := 0, n-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if wavelengths[mid] <= λ {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
+
+// cmfAt returns the CIE 1931 color matching functions at wavelength λ by linear interpolation.
+func cmfAt(λ float32) ms3.Vec {
+	pos := (λ - cmfStart) / cmfStep
+	if pos < 0 || pos > float32(len(cmf1931)-1) {
+		return ms3.Vec{}
+	}
+	i := int(pos)
+	a := ms3.Vec{X: cmf1931[i][0], Y: cmf1931[i][1], Z: cmf1931[i][2]}
+	if i == len(cmf1931)-1 {
+		return a
+	}
+	b := ms3.Vec{X: cmf1931[i+1][0], Y: cmf1931[i+1][1], Z: cmf1931[i+1][2]}
+	frac := pos - float32(i)
+	return ms3.Add(ms3.Scale(1-frac, a), ms3.Scale(frac, b))
+}
+
+// RenderHyperspectral renders the cube to sRGB as seen under illuminant, i.e: [SpectrumD65] for daylight.
+// The illuminant white is adapted to the D65 white of sRGB with the Bradford transform so neutral
+// surfaces render gray. Out of gamut colors are clipped. Rows are rendered in parallel.
+// It panics if the length of cube.Data does not match its dimensions.
+func RenderHyperspectral(cube *HyperspectralCube, illuminant Spectrum) *image.RGBA64 {
+	bands := len(cube.Wavelengths)
+	if cube.Width < 0 || cube.Height < 0 || len(cube.Data) != cube.Width*cube.Height*bands {
+		panic("hyperspectral cube data length does not match dimensions")
+	}
+	toLinSRGB := ms3.MulMat3(xyzToLinSRGB, bradfordMatrix(IlluminantXYZ(illuminant).vec(), d65))
+	weights := SpectralWeights(cube.Wavelengths, illuminant)
+	rgbWeights := make([]ms3.Vec, bands)
+	for k, w := range weights {
+		rgbWeights[k] = ms3.MulMatVec(toLinSRGB, w.vec())
+	}
+	dst := image.NewRGBA64(image.Rect(0, 0, cube.Width, cube.Height))
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := range rows {
+				for x := 0; x < cube.Width; x++ {
+					samples := cube.Data[(y*cube.Width+x)*bands:][:bands]
+					var v ms3.Vec
+					for k, s := range samples {
+						v = ms3.Add(v, ms3.Scale(s, rgbWeights[k]))
+					}
+					r, g, b, _ := LSRGB{R: v.X, G: v.Y, B: v.Z}.ClipToGamut().SRGB().RGBA()
+					dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: 0xffff})
+				}
+			}
+		}()
+	}
+	for y := 0; y < cube.Height; y++ {
+		rows <- y
+	}
+	close(rows)
+	wg.Wait()
+	return dst
+}
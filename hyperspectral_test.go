@@ -0,0 +1,62 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestRenderHyperspectral(t *testing.T) {
+	// 31 bands from 400nm to 700nm as common in hyperspectral datasets.
+	wavelengths := make([]float32, 31)
+	for i := range wavelengths {
+		wavelengths[i] = 400 + 10*float32(i)
+	}
+	daylight := SpectrumD65()
+	weights := SpectralWeights(wavelengths, daylight)
+	var sum CIEXYZ
+	for _, w := range weights {
+		sum.X += w.X
+		sum.Y += w.Y
+		sum.Z += w.Z
+	}
+	// A perfect reflector is nearly the illuminant white; bands miss the tails of the color matching functions.
+	if want := IlluminantXYZ(daylight); math32.Abs(sum.Y-1) > 0.01 || sqdist(sum.vec(), want.vec()) > 1e-3 {
+		t.Errorf("perfect reflector XYZ %+v, want %+v", sum, want)
+	}
+
+	cube := &HyperspectralCube{Width: 3, Height: 2, Wavelengths: wavelengths, Data: make([]float32, 3*2*31)}
+	red := Spectrum{Start: 380, Step: 10, Values: make([]float32, 41)}
+	for i := range red.Values {
+		if i >= 24 {
+			red.Values[i] = 0.8
+		}
+	}
+	for k, λ := range wavelengths {
+		cube.Data[k] = 1              // White at (0,0).
+		cube.Data[31+k] = 0.18        // Gray at (1,0).
+		cube.Data[2*31+k] = red.At(λ) // Red at (2,0).
+	}
+	for i, illum := range []Spectrum{daylight, SpectrumA()} {
+		img := RenderHyperspectral(cube, illum)
+		white := ColorToSRGB(img.At(0, 0))
+		gray := ColorToSRGB(img.At(1, 0))
+		if white.R < 0.97 || white.G < 0.97 || white.B < 0.97 {
+			t.Errorf("white reflector rendered %+v", white)
+		}
+		if math32.Abs(gray.R-gray.B) > 0.02 || math32.Abs(gray.R-gray.G) > 0.02 {
+			t.Errorf("gray reflector not neutral: %+v", gray)
+		}
+		got := ColorToSRGB(img.At(2, 0))
+		want := ReflectanceXYZ(red, daylight).LSRGB().ClipToGamut().SRGB()
+		if got.R < 0.5 || got.G > 0.4 || got.B > 0.4 {
+			t.Errorf("red reflector rendered %+v", got)
+		}
+		if i == 0 && sqdist(got.vec(), want.vec()) > 1e-3 {
+			t.Errorf("red reflector rendered %+v, want %+v", got, want)
+		}
+		if black := ColorToSRGB(img.At(1, 1)); black != (SRGB{}) {
+			t.Errorf("zero reflectance rendered %+v", black)
+		}
+	}
+}
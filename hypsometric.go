@@ -0,0 +1,113 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// HypsometricTint colors terrain by elevation for map rendering with separate scales for bathymetry and land,
+// so the coastline is a sharp color discontinuity regardless of how close elevations are to sea level.
+type HypsometricTint struct {
+	// SeaLevel is the elevation separating Water from Land.
+	SeaLevel float32
+	// Water colors elevations below SeaLevel. Its Max should be SeaLevel.
+	Water Scale
+	// Land colors elevations at or above SeaLevel. Its Min should be SeaLevel.
+	Land Scale
+	// SlopeShading in [0,1] darkens steep terrain: linear light is multiplied by 1-SlopeShading*sin(slope).
+	// Zero disables slope shading.
+	SlopeShading float32
+}
+
+// NewHypsometricTint returns a conventional atlas style tint for elevations in [minElevation, maxElevation]
+// with sea level at 0: blues getting darker with depth, and land from green lowlands through
+// yellow and brown to white peaks. Slope shading is set to 0.5.
+func NewHypsometricTint(minElevation, maxElevation float32) HypsometricTint {
+	return HypsometricTint{
+		Water: Scale{
+			Min: minElevation,
+			Max: 0,
+			Colormap: oklchGradient(
+				OKLCH{L: 0.40, C: 0.10, H: 255},
+				OKLCH{L: 0.65, C: 0.09, H: 240},
+				OKLCH{L: 0.85, C: 0.05, H: 225},
+			),
+		},
+		Land: Scale{
+			Min: 0,
+			Max: maxElevation,
+			Colormap: oklchGradient(
+				OKLCH{L: 0.62, C: 0.12, H: 145},
+				OKLCH{L: 0.80, C: 0.12, H: 105},
+				OKLCH{L: 0.65, C: 0.09, H: 65},
+				OKLCH{L: 0.55, C: 0.05, H: 50},
+				OKLCH{L: 0.97, C: 0, H: 0},
+			),
+		},
+		SlopeShading: 0.5,
+	}
+}
+
+// At returns the tint of elevation without slope shading.
+func (h HypsometricTint) At(elevation float32) color.Color {
+	if elevation < h.SeaLevel {
+		return h.Water.At(elevation)
+	}
+	return h.Land.At(elevation)
+}
+
+// AtSlope returns the tint of elevation shaded for terrain with the given slope in degrees.
+// Shading is applied in linear light so it darkens colors evenly.
+func (h HypsometricTint) AtSlope(elevation, slope float32) SRGB {
+	c := ColorToSRGB(h.At(elevation))
+	if h.SlopeShading <= 0 {
+		return c
+	}
+	shade := 1 - clamp01(h.SlopeShading)*math32.Abs(math32.Sin(slope*math32.Pi/180))
+	return c.LSRGB().ScaleBrightness(shade).SRGB()
+}
+
+// Render returns the tinted image of the digital elevation model dem whose samples are spaced
+// cellSize apart horizontally, in the same unit as elevations. Slopes are computed
+// with central differences.
+func (h HypsometricTint) Render(dem *Plane, cellSize float32) *image.RGBA64 {
+	bounds := dem.Rect
+	dst := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dzdx, dzdy := demGradient(dem, x, y, cellSize)
+			slope := math32.Atan(math32.Hypot(dzdx, dzdy)) * 180 / math32.Pi
+			r, g, b, _ := h.AtSlope(dem.Value(x, y), slope).RGBA()
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: 0xffff})
+		}
+	}
+	return dst
+}
+
+// demGradient returns the elevation gradient of dem at (x,y) with central differences,
+// or one-sided differences at the edges. y increases southwards as in images.
+func demGradient(dem *Plane, x, y int, cellSize float32) (dzdx, dzdy float32) {
+	r := dem.Rect
+	x0, x1, y0, y1 := x-1, x+1, y-1, y+1
+	if x0 < r.Min.X {
+		x0 = x
+	}
+	if x1 >= r.Max.X {
+		x1 = x
+	}
+	if y0 < r.Min.Y {
+		y0 = y
+	}
+	if y1 >= r.Max.Y {
+		y1 = y
+	}
+	if x1 > x0 {
+		dzdx = (dem.Value(x1, y) - dem.Value(x0, y)) / (float32(x1-x0) * cellSize)
+	}
+	if y1 > y0 {
+		dzdy = (dem.Value(x, y1) - dem.Value(x, y0)) / (float32(y1-y0) * cellSize)
+	}
+	return dzdx, dzdy
+}
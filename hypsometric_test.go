@@ -0,0 +1,44 @@
+package colorspace
+
+import (
+	"image"
+	"testing"
+)
+
+func TestHypsometricTint(t *testing.T) {
+	h := NewHypsometricTint(-5000, 4000)
+	shallow, coast := colorToOKLCH(h.At(-1)), colorToOKLCH(h.At(0))
+	if !(shallow.H > 200 && shallow.H < 280) || !(coast.H > 120 && coast.H < 170) {
+		t.Errorf("sea level discontinuity missing: shallow water hue %v, coast hue %v", shallow.H, coast.H)
+	}
+	if deep := colorToOKLCH(h.At(-4000)); deep.L >= shallow.L {
+		t.Errorf("deep water %v not darker than shallow water %v", deep.L, shallow.L)
+	}
+	if peak := ColorToSRGB(h.At(4000)); peak.R < 0.9 || peak.G < 0.9 || peak.B < 0.9 {
+		t.Errorf("peak is %+v, want white", peak)
+	}
+
+	flat, steep := h.AtSlope(1000, 0), h.AtSlope(1000, 60)
+	if flat != ColorToSRGB(h.At(1000)) {
+		t.Error("flat terrain should not be shaded")
+	}
+	want := flat.LSRGB().ScaleBrightness(1 - 0.5*0.8660254)
+	if sqdist(steep.LSRGB().vec(), want.vec()) > 1e-8 {
+		t.Errorf("steep terrain shaded %+v, want %+v", steep.LSRGB(), want)
+	}
+
+	// A ramp rising 1 unit per cell in x has a 45° slope.
+	dem := NewPlane(image.Rect(0, 0, 4, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			dem.SetValue(x, y, float32(1000+x))
+		}
+	}
+	img := h.Render(dem, 1)
+	for _, x := range []int{0, 2, 3} {
+		got := ColorToSRGB(img.At(x, 1))
+		if want := h.AtSlope(float32(1000+x), 45); sqdist(got.vec(), want.vec()) > 1e-6 {
+			t.Errorf("rendered (%d,1) = %+v, want %+v", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package colorspace
+
+import (
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	errICCProfile     = errors.New("invalid ICC profile")
+	errICCUnsupported = errors.New("unsupported ICC profile: only RGB matrix/TRC profiles are supported")
+)
+
+// ICCProfile is an RGB matrix/TRC ICC color profile as embedded in most JPEG and PNG files,
+// i.e: Adobe RGB (1998), Display P3 or sRGB profiles. Lookup table based profiles are not supported.
+type ICCProfile struct {
+	// Description is the profile's human readable name, if present.
+	Description string
+	// Red, Green and Blue are the colorants: the XYZ of each primary relative to the D50 profile connection space.
+	Red, Green, Blue CIEXYZ
+	// TRC are the tone reproduction curves of the red, green and blue channels.
+	TRC [3]TransferFunction
+}
+
+// ParseICCProfile parses an ICC version 2 or 4 RGB matrix/TRC profile.
+func ParseICCProfile(data []byte) (*ICCProfile, error) {
+	if len(data) < 132 || string(data[36:40]) != "acsp" {
+		return nil, errICCProfile
+	}
+	if string(data[16:20]) != "RGB " || string(data[20:24]) != "XYZ " {
+		return nil, errICCUnsupported
+	}
+	be := binary.BigEndian
+	count := int(be.Uint32(data[128:]))
+	if count > (len(data)-132)/12 {
+		return nil, errICCProfile
+	}
+	tags := make(map[string][]byte, count)
+	for i := 0; i < count; i++ {
+		entry := data[132+12*i:]
+		off, size := be.Uint32(entry[4:]), be.Uint32(entry[8:])
+		if uint64(off)+uint64(size) > uint64(len(data)) || size < 8 {
+			return nil, errICCProfile
+		}
+		tags[string(entry[:4])] = data[off : off+size]
+	}
+	p := &ICCProfile{Description: iccText(tags["desc"])}
+	var ok bool
+	colorants := [3]*CIEXYZ{&p.Red, &p.Green, &p.Blue}
+	for i, sig := range [3]string{"rXYZ", "gXYZ", "bXYZ"} {
+		*colorants[i], ok = iccXYZ(tags[sig])
+		if !ok {
+			return nil, errICCUnsupported
+		}
+	}
+	var err error
+	for i, sig := range [3]string{"rTRC", "gTRC", "bTRC"} {
+		p.TRC[i], err = iccCurve(tags[sig])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// CIEXYZ converts the encoded channel values in [0,1] of a color in the profile's space to XYZ relative to D65.
+func (p *ICCProfile) CIEXYZ(r, g, b float32) CIEXYZ {
+	return p.linearToXYZD50(ms3.Vec{X: p.TRC[0].ToLinear(r), Y: p.TRC[1].ToLinear(g), Z: p.TRC[2].ToLinear(b)}).d50ToD65()
+}
+
+func (p *ICCProfile) linearToXYZD50(lin ms3.Vec) CIEXYZ {
+	v := ms3.Add(ms3.Add(ms3.Scale(lin.X, p.Red.vec()), ms3.Scale(lin.Y, p.Green.vec())), ms3.Scale(lin.Z, p.Blue.vec()))
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// iccXYZ parses an XYZType tag.
+func iccXYZ(tag []byte) (CIEXYZ, bool) {
+	if len(tag) < 20 || string(tag[:4]) != "XYZ " {
+		return CIEXYZ{}, false
+	}
+	return CIEXYZ{X: iccFixed(tag[8:]), Y: iccFixed(tag[12:]), Z: iccFixed(tag[16:])}, true
+}
+
+// iccFixed decodes an s15Fixed16Number.
+func iccFixed(b []byte) float32 {
+	return float32(int32(binary.BigEndian.Uint32(b))) / 65536
+}
+
+// iccCurve parses a curveType or parametricCurveType tag.
+func iccCurve(tag []byte) (TransferFunction, error) {
+	if len(tag) < 12 {
+		return TransferFunction{}, errICCUnsupported
+	}
+	be := binary.BigEndian
+	switch string(tag[:4]) {
+	case "curv":
+		n := int(be.Uint32(tag[8:]))
+		if len(tag) < 12+2*n {
+			return TransferFunction{}, errICCProfile
+		}
+		switch n {
+		case 0:
+			return TransferLinear, nil
+		case 1:
+			return TransferGamma(float32(be.Uint16(tag[12:])) / 256), nil
+		}
+		table := make([]float32, n)
+		for i := range table {
+			table[i] = float32(be.Uint16(tag[12+2*i:])) / 0xffff
+		}
+		return monotoneTransfer(func(v float32) float32 {
+			pos := clamp01(v) * float32(n-1)
+			i := int(pos)
+			if i >= n-1 {
+				return table[n-1]
+			}
+			frac := pos - float32(i)
+			return table[i] + frac*(table[i+1]-table[i])
+		}), nil
+	case "para":
+		fn := be.Uint16(tag[8:])
+		nparams := [5]int{1, 3, 4, 5, 7}
+		if fn > 4 || len(tag) < 12+4*nparams[fn] {
+			return TransferFunction{}, errICCUnsupported
+		}
+		var p [7]float32
+		for i := 0; i < nparams[fn]; i++ {
+			p[i] = iccFixed(tag[12+4*i:])
+		}
+		g, a, b, c, d, e, f := p[0], p[1], p[2], p[3], p[4], p[5], p[6]
+		pow := func(x float32) float32 { return math32.Pow(math32.Max(x, 0), g) }
+		var toLinear func(float32) float32
+		switch fn {
+		case 0:
+			toLinear = func(x float32) float32 { return pow(x) }
+		case 1:
+			toLinear = func(x float32) float32 { return pow(a*x + b) }
+		case 2:
+			toLinear = func(x float32) float32 { return pow(a*x+b) + c }
+		case 3:
+			toLinear = func(x float32) float32 {
+				if x >= d {
+					return pow(a*x + b)
+				}
+				return c * x
+			}
+		case 4:
+			toLinear = func(x float32) float32 {
+				if x >= d {
+					return pow(a*x+b) + e
+				}
+				return c*x + f
+			}
+		}
+		return monotoneTransfer(toLinear), nil
+	}
+	return TransferFunction{}, errICCUnsupported
+}
+
+// monotoneTransfer returns the transfer function of the monotonic curve toLinear over [0,1]
+// inverting it by bisection.
+func monotoneTransfer(toLinear func(float32) float32) TransferFunction {
+	return TransferFunction{
+		ToLinear: toLinear,
+		FromLinear: func(linear float32) float32 {
+			lo, hi := float32(0), float32(1)
+			for i := 0; i < 32; i++ {
+				mid := 0.5 * (lo + hi)
+				if toLinear(mid) < linear {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			return 0.5 * (lo + hi)
+		},
+	}
+}
+
+// iccText decodes a textDescriptionType (v2) or the first record of a multiLocalizedUnicodeType (v4) tag.
+func iccText(tag []byte) string {
+	be := binary.BigEndian
+	switch {
+	case len(tag) >= 12 && string(tag[:4]) == "desc":
+		n := int(be.Uint32(tag[8:]))
+		if n == 0 || len(tag) < 12+n {
+			return ""
+		}
+		return string(tag[12 : 12+n-1]) // Strip NUL terminator.
+	case len(tag) >= 28 && string(tag[:4]) == "mluc":
+		if be.Uint32(tag[8:]) == 0 {
+			return ""
+		}
+		n, off := int(be.Uint32(tag[20:])), int(be.Uint32(tag[24:]))
+		if off+n > len(tag) {
+			return ""
+		}
+		u := make([]uint16, n/2)
+		for i := range u {
+			u[i] = be.Uint16(tag[off+2*i:])
+		}
+		return string(utf16.Decode(u))
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// hdrReferenceWhite is the luminance in cd/m² of diffuse white (Y=1) when converting
+// relative colors to absolute HDR spaces as recommended by ITU-R BT.2408.
+const hdrReferenceWhite = 203
+
+// SMPTE ST 2084 (PQ) constants.
+const (
+	pqM1 = 2610. / 16384
+	pqM2 = 2523. / 4096 * 128
+	pqC1 = 3424. / 4096
+	pqC2 = 2413. / 4096 * 32
+	pqC3 = 2392. / 4096 * 32
+)
+
+// TransferPQ is the SMPTE ST 2084 perceptual quantizer transfer function of HDR10 and Dolby Vision.
+// Linear values are absolute luminance normalized so 1 is 10000 cd/m². Negative values are mirrored.
+var TransferPQ = TransferFunction{
+	ToLinear:   func(v float32) float32 { return pqToLinear(v, pqM2) },
+	FromLinear: func(v float32) float32 { return pqFromLinear(v, pqM2) },
+}
+
+var (
+	// Linear BT.2020 to LMS matrix of BT.2100 including the crosstalk.
+	linRec2020ToICtCpLMS = ms3.NewMat3([]float32{
+		1688. / 4096, 2146. / 4096, 262. / 4096,
+		683. / 4096, 2951. / 4096, 462. / 4096,
+		99. / 4096, 309. / 4096, 3688. / 4096,
+	})
+	ictcpLMSToLinRec2020 = linRec2020ToICtCpLMS.Inverse()
+	// PQ encoded LMS to ICtCp.
+	ictcpLMSToICtCp = ms3.NewMat3([]float32{
+		0.5, 0.5, 0,
+		6610. / 4096, -13613. / 4096, 7003. / 4096,
+		17933. / 4096, -17390. / 4096, -543. / 4096,
+	})
+	ictcpToLMS = ictcpLMSToICtCp.Inverse()
+)
+
+// ICtCp is the ITU-R BT.2100 color representation for HDR and wide color gamut video. It is designed
+// for constant hue lines and perceptually uniform intensity over the whole PQ luminance range up to 10000 cd/m².
+// Conversions from relative colors map diffuse white (Y=1) to 203 cd/m².
+// Use [ICtCp.DeltaEITP] to compare colors.
+type ICtCp struct {
+	I  float32 // Intensity. Diffuse white is 0.58.
+	Ct float32 // Tritan (blue-yellow) axis.
+	Cp float32 // Protan (red-green) axis.
+}
+
+func (c ICtCp) vec() ms3.Vec      { return ms3.Vec{X: c.I, Y: c.Ct, Z: c.Cp} }
+func (c ICtCp) Array() [3]float32 { return c.vec().Array() }
+
+// ICtCp converts the linear Rec.2020 color, where 1 is diffuse white, to ICtCp.
+func (c LinearRec2020) ICtCp() ICtCp {
+	lms := ms3.MulMatVec(linRec2020ToICtCpLMS, ms3.Scale(hdrReferenceWhite/10000., c.vec()))
+	lms = ms3.Vec{X: pqFromLinear(lms.X, pqM2), Y: pqFromLinear(lms.Y, pqM2), Z: pqFromLinear(lms.Z, pqM2)}
+	v := ms3.MulMatVec(ictcpLMSToICtCp, lms)
+	return ICtCp{I: v.X, Ct: v.Y, Cp: v.Z}
+}
+
+// LinearRec2020 converts the ICtCp color to linear Rec.2020 where 1 is diffuse white.
+func (c ICtCp) LinearRec2020() LinearRec2020 {
+	lms := ms3.MulMatVec(ictcpToLMS, c.vec())
+	lms = ms3.Vec{X: pqToLinear(lms.X, pqM2), Y: pqToLinear(lms.Y, pqM2), Z: pqToLinear(lms.Z, pqM2)}
+	v := ms3.Scale(10000./hdrReferenceWhite, ms3.MulMatVec(ictcpLMSToLinRec2020, lms))
+	return LinearRec2020{R: v.X, G: v.Y, B: v.Z}
+}
+
+// ICtCp converts D65 relative XYZ to ICtCp.
+func (c CIEXYZ) ICtCp() ICtCp { return c.LinearRec2020().ICtCp() }
+
+// CIEXYZ converts the ICtCp color to D65 relative XYZ.
+func (c ICtCp) CIEXYZ() CIEXYZ { return c.LinearRec2020().CIEXYZ() }
+
+// DeltaEITP returns the ΔE ITP color difference of ITU-R BT.2124 between two ICtCp colors.
+// A difference of 1 is about the smallest perceptible.
+func (c ICtCp) DeltaEITP(other ICtCp) float32 {
+	dI, dT, dP := c.I-other.I, 0.5*(c.Ct-other.Ct), c.Cp-other.Cp
+	return 720 * math32.Sqrt(dI*dI+dT*dT+dP*dP)
+}
+
+// pqFromLinear is the PQ inverse EOTF with exponent m2. Negative values are mirrored.
+func pqFromLinear(v, m2 float32) float32 {
+	p := math32.Pow(math32.Abs(v), pqM1)
+	return math32.Copysign(math32.Pow((pqC1+pqC2*p)/(1+pqC3*p), m2), v)
+}
+
+// pqToLinear is the PQ EOTF with exponent m2. Negative values are mirrored.
+func pqToLinear(v, m2 float32) float32 {
+	p := math32.Pow(math32.Abs(v), 1/m2)
+	return math32.Copysign(math32.Pow(math32.Max(p-pqC1, 0)/(pqC2-pqC3*p), 1/pqM1), v)
+}
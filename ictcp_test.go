@@ -0,0 +1,35 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestICtCp(t *testing.T) {
+	// PQ code values of 100 and 10000 cd/m².
+	if got := TransferPQ.FromLinear(0.01); math32.Abs(got-0.5081) > 1e-4 {
+		t.Errorf("PQ(100 nits) = %v, want 0.5081", got)
+	}
+	if got := TransferPQ.FromLinear(1); math32.Abs(got-1) > 1e-5 {
+		t.Errorf("PQ(10000 nits) = %v, want 1", got)
+	}
+	if got := TransferPQ.ToLinear(TransferPQ.FromLinear(0.0203)); math32.Abs(got-0.0203) > 1e-6 {
+		t.Errorf("PQ round trip gave %v", got)
+	}
+	white := SRGB{R: 1, G: 1, B: 1}.LSRGB().CIEXYZ().ICtCp()
+	if math32.Abs(white.I-0.5807) > 1e-3 || math32.Abs(white.Ct) > 1e-3 || math32.Abs(white.Cp) > 1e-3 {
+		t.Errorf("diffuse white ICtCp %+v, want I=0.5807 and no chroma", white)
+	}
+	for _, c := range []SRGB{{R: 1}, {G: 1}, {B: 1}, {R: 0.2, G: 0.5, B: 0.8}, {R: 0.05, G: 0.04, B: 0.03}} {
+		xyz := c.LSRGB().CIEXYZ()
+		ictcp := xyz.ICtCp()
+		if got := ictcp.CIEXYZ(); sqdist(got.vec(), xyz.vec()) > 1e-8 {
+			t.Errorf("ICtCp round trip of %+v via %+v gave %+v", xyz, ictcp, got)
+		}
+	}
+	red, orange := SRGB{R: 1}.LSRGB().CIEXYZ().ICtCp(), SRGB{R: 1, G: 0.5}.LSRGB().CIEXYZ().ICtCp()
+	if d1, d2 := red.DeltaEITP(orange), orange.DeltaEITP(red); d1 != d2 || d1 < 10 {
+		t.Errorf("DeltaEITP between red and orange: %v, %v", d1, d2)
+	}
+}
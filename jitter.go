@@ -0,0 +1,81 @@
+package colorspace
+
+import (
+	"image"
+	"math/rand"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// ColorJitter configures random color augmentation for machine learning training data.
+// Each field is the maximum magnitude of the corresponding perturbation, sampled uniformly
+// in [-max, max]. The zero value applies no augmentation. Perturbations are applied in [OKLCH].
+type ColorJitter struct {
+	// Hue is the maximum hue rotation in degrees.
+	Hue float32
+	// Chroma is the maximum relative chroma change, i.e: 0.2 scales chroma by a factor in [0.8,1.2].
+	Chroma float32
+	// Lightness is the maximum OKLCH lightness offset.
+	Lightness float32
+	// WhiteBalance is the maximum white balance shift along the Planckian locus in mireds (1e6/kelvin)
+	// from D65, simulating photos taken under warmer or cooler light. 30 mireds is a moderate shift.
+	WhiteBalance float32
+}
+
+// JitterParams is a sampled color perturbation. See [ColorJitter.Sample].
+type JitterParams struct {
+	Hue         float32 // Hue rotation in degrees.
+	ChromaScale float32 // Chroma multiplier.
+	Lightness   float32 // Lightness offset.
+	// Kelvin is the color temperature of the simulated illuminant. Zero means no white balance shift.
+	Kelvin float32
+}
+
+// Sample draws a perturbation from rng. Use a seeded [rand.Rand] for reproducible augmentation.
+func (j ColorJitter) Sample(rng *rand.Rand) JitterParams {
+	uniform := func(max float32) float32 { return (2*rng.Float32() - 1) * max }
+	p := JitterParams{
+		Hue:         uniform(j.Hue),
+		ChromaScale: 1 + uniform(j.Chroma),
+		Lightness:   uniform(j.Lightness),
+	}
+	if j.WhiteBalance != 0 {
+		const d65Mired = 1e6 / 6504.
+		p.Kelvin = 1e6 / math32.Max(d65Mired+uniform(j.WhiteBalance), 1)
+	}
+	return p
+}
+
+// AugmentImage returns a copy of img with a perturbation drawn deterministically from seed applied.
+// The same seed always produces the same result.
+func (j ColorJitter) AugmentImage(img image.Image, seed int64) *image.RGBA64 {
+	return j.Sample(rand.New(rand.NewSource(seed))).ApplyImage(img)
+}
+
+// Apply perturbs the color c. The white balance shift is applied first in linear light followed by the
+// hue, chroma and lightness perturbations in OKLCH. The result is gamut mapped to sRGB.
+func (p JitterParams) Apply(c SRGB) SRGB {
+	return p.transform()(c)
+}
+
+// ApplyImage returns a copy of img with the perturbation applied to every pixel. Alpha is preserved.
+func (p JitterParams) ApplyImage(img image.Image) *image.RGBA64 {
+	return mapImageSRGB(img, p.transform())
+}
+
+// transform returns the perturbation as a function, computing the adaptation matrix once.
+func (p JitterParams) transform() func(SRGB) SRGB {
+	adapt := ms3.IdentityMat3()
+	if p.Kelvin > 0 {
+		adapt = bradfordMatrix(d65, IlluminantXYZ(BlackbodySpectrum(p.Kelvin)).vec())
+	}
+	return func(c SRGB) SRGB {
+		v := ms3.MulMatVec(adapt, c.LSRGB().CIEXYZ().vec())
+		lch := CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}.OKLAB().OKLCH()
+		lch.H = wrapHue(lch.H + p.Hue)
+		lch.C = math32.Max(lch.C*p.ChromaScale, 0)
+		lch.L = clamp01(lch.L + p.Lightness)
+		return oklchToSRGB(lch)
+	}
+}
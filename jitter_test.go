@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+func TestColorJitter(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 37)
+	}
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 255
+	}
+	j := ColorJitter{Hue: 20, Chroma: 0.2, Lightness: 0.05, WhiteBalance: 30}
+	a := j.AugmentImage(img, 42)
+	b := j.AugmentImage(img, 42)
+	c := j.AugmentImage(img, 43)
+	same, differ := true, false
+	for i := range a.Pix {
+		same = same && a.Pix[i] == b.Pix[i]
+		differ = differ || a.Pix[i] != c.Pix[i]
+	}
+	if !same || !differ {
+		t.Errorf("augmentation must be deterministic per seed: same=%v differ=%v", same, differ)
+	}
+
+	// Zero perturbation leaves colors unchanged.
+	identity := ColorJitter{}.Sample(rand.New(rand.NewSource(1)))
+	in := SRGB{R: 0.8, G: 0.4, B: 0.2}
+	if out := identity.Apply(in); sqdist(out.vec(), in.vec()) > 1e-6 {
+		t.Errorf("identity jitter changed %+v to %+v", in, out)
+	}
+	// Lower color temperature warms a neutral gray.
+	warm := JitterParams{ChromaScale: 1, Kelvin: 4000}.Apply(ColorToSRGB(color.Gray{Y: 128}))
+	if warm.R <= warm.B {
+		t.Errorf("4000K white balance should warm gray, got %+v", warm)
+	}
+}
@@ -0,0 +1,144 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+// Jzazbz constants from Safdar et al. 2017.
+const (
+	jzB  = 1.15
+	jzG  = 0.66
+	jzD  = -0.56
+	jzD0 = 1.6295499532821566e-11
+	jzP  = 1.7 * 2523. / 32 // PQ exponent m2 of Jzazbz.
+)
+
+var (
+	jzXYZToLMS = ms3.NewMat3([]float32{
+		0.41478972, 0.579999, 0.0146480,
+		-0.2015100, 1.120649, 0.0531008,
+		-0.0166008, 0.264800, 0.6684799,
+	})
+	jzLMSToXYZ = jzXYZToLMS.Inverse()
+	jzLMSToIab = ms3.NewMat3([]float32{
+		0.5, 0.5, 0,
+		3.524000, -4.066708, 0.542708,
+		0.199076, 1.096799, -1.295875,
+	})
+	jzIabToLMS = jzLMSToIab.Inverse()
+)
+
+// Jzazbz is the perceptually uniform color space of Safdar et al. designed for high dynamic range and
+// wide gamut imagery where [OKLAB] and [CIELAB], fit to SDR surface colors, break down. Jz is lightness
+// and az, bz the red-green and yellow-blue opponent axes. Conversions from relative colors map
+// diffuse white (Y=1) to 203 cd/m², for which Jz is about 0.22. See [JzCzhz] for the cylindrical form.
+type Jzazbz struct {
+	Jz float32 // Lightness.
+	Az float32 // Red-green axis.
+	Bz float32 // Yellow-blue axis.
+}
+
+// JzCzhz is the cylindrical (lightness, chroma, hue) representation of [Jzazbz].
+type JzCzhz struct {
+	Jz float32 // Lightness. Same as for [Jzazbz].
+	Cz float32 // Chroma.
+	Hz float32 // Hue in degrees in [0,360).
+}
+
+func (c Jzazbz) vec() ms3.Vec      { return ms3.Vec{X: c.Jz, Y: c.Az, Z: c.Bz} }
+func (c JzCzhz) vec() ms3.Vec      { return ms3.Vec{X: c.Jz, Y: c.Cz, Z: c.Hz} }
+func (c Jzazbz) Array() [3]float32 { return c.vec().Array() }
+func (c JzCzhz) Array() [3]float32 { return c.vec().Array() }
+
+// Jzazbz converts D65 relative XYZ to Jzazbz.
+func (c CIEXYZ) Jzazbz() Jzazbz {
+	abs := ms3.Scale(hdrReferenceWhite, c.vec())
+	xp := jzB*abs.X - (jzB-1)*abs.Z
+	yp := jzG*abs.Y - (jzG-1)*abs.X
+	lms := ms3.MulMatVec(jzXYZToLMS, ms3.Vec{X: xp, Y: yp, Z: abs.Z})
+	lms = ms3.Vec{X: pqFromLinear(lms.X/10000, jzP), Y: pqFromLinear(lms.Y/10000, jzP), Z: pqFromLinear(lms.Z/10000, jzP)}
+	iab := ms3.MulMatVec(jzLMSToIab, lms)
+	return Jzazbz{
+		Jz: (1+jzD)*iab.X/(1+jzD*iab.X) - jzD0,
+		Az: iab.Y,
+		Bz: iab.Z,
+	}
+}
+
+// CIEXYZ converts the Jzazbz color to D65 relative XYZ.
+func (c Jzazbz) CIEXYZ() CIEXYZ {
+	jz := c.Jz + jzD0
+	iz := jz / (1 + jzD - jzD*jz)
+	lms := ms3.MulMatVec(jzIabToLMS, ms3.Vec{X: iz, Y: c.Az, Z: c.Bz})
+	lms = ms3.Scale(10000, ms3.Vec{X: pqToLinear(lms.X, jzP), Y: pqToLinear(lms.Y, jzP), Z: pqToLinear(lms.Z, jzP)})
+	p := ms3.MulMatVec(jzLMSToXYZ, lms)
+	x := (p.X + (jzB-1)*p.Z) / jzB
+	y := (p.Y + (jzG-1)*x) / jzG
+	return CIEXYZ{X: x / hdrReferenceWhite, Y: y / hdrReferenceWhite, Z: p.Z / hdrReferenceWhite}
+}
+
+// JzCzhz converts the Jzazbz color to its cylindrical representation. Achromatic colors have hue 0.
+func (c Jzazbz) JzCzhz() JzCzhz {
+	return JzCzhz{Jz: c.Jz, Cz: math32.Hypot(c.Az, c.Bz), Hz: hueAngle(c.Bz, c.Az)}
+}
+
+// Jzazbz converts the JzCzhz color to Jzazbz.
+func (c JzCzhz) Jzazbz() Jzazbz {
+	sin, cos := math32.Sincos(c.Hz * math32.Pi / 180)
+	return Jzazbz{Jz: c.Jz, Az: c.Cz * cos, Bz: c.Cz * sin}
+}
+
+// Lerp interpolates linearly between two Jzazbz colors.
+func (from Jzazbz) Lerp(to Jzazbz, v float32) Jzazbz {
+	return Jzazbz{
+		Jz: ms1.Interp(from.Jz, to.Jz, v),
+		Az: ms1.Interp(from.Az, to.Az, v),
+		Bz: ms1.Interp(from.Bz, to.Bz, v),
+	}
+}
+
+// Lerp interpolates between two JzCzhz colors along the shortest hue arc. The hue of an
+// achromatic color is taken from the other color.
+func (from JzCzhz) Lerp(to JzCzhz, v float32) JzCzhz {
+	c := CIELCH{L: from.Jz, C: from.Cz, H: from.Hz}.Lerp(CIELCH{L: to.Jz, C: to.Cz, H: to.Hz}, v)
+	return JzCzhz{Jz: c.L, Cz: c.C, Hz: c.H}
+}
+
+// GamutMappedLSRGB converts the color to linear sRGB reducing its chroma at constant lightness and hue
+// until it lies inside the sRGB gamut.
+func (c JzCzhz) GamutMappedLSRGB() LSRGB {
+	lin := c.Jzazbz().CIEXYZ().LSRGB()
+	if lin.InGamut() {
+		return lin
+	}
+	lo, hi := float32(0), c.Cz
+	for i := 0; i < 24; i++ {
+		c.Cz = 0.5 * (lo + hi)
+		if c.Jzazbz().CIEXYZ().LSRGB().InGamut() {
+			lo = c.Cz
+		} else {
+			hi = c.Cz
+		}
+	}
+	c.Cz = lo
+	return c.Jzazbz().CIEXYZ().LSRGB().ClipToGamut()
+}
+
+// LerpJzazbz interpolates in Jzazbz (lightness, red-green, yellow-blue).
+func LerpJzazbz(c1, c2 color.Color, v float32) color.Color {
+	o1 := ColorToSRGB(c1).LSRGB().CIEXYZ().Jzazbz()
+	o2 := ColorToSRGB(c2).LSRGB().CIEXYZ().Jzazbz()
+	return o1.Lerp(o2, v).CIEXYZ().LSRGB().ClipToGamut().SRGB()
+}
+
+// LerpJzCzhz interpolates in JzCzhz (lightness, chroma, hue) along the shortest hue arc.
+// Result is gamut mapped by reducing chroma at constant lightness and hue.
+func LerpJzCzhz(c1, c2 color.Color, v float32) color.Color {
+	o1 := ColorToSRGB(c1).LSRGB().CIEXYZ().Jzazbz().JzCzhz()
+	o2 := ColorToSRGB(c2).LSRGB().CIEXYZ().Jzazbz().JzCzhz()
+	return o1.Lerp(o2, v).GamutMappedLSRGB().SRGB()
+}
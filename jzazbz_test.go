@@ -0,0 +1,36 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestJzazbz(t *testing.T) {
+	white := SRGB{R: 1, G: 1, B: 1}.LSRGB().CIEXYZ().Jzazbz()
+	if math32.Abs(white.Jz-0.2221) > 1e-3 || math32.Abs(white.Az) > 1e-3 || math32.Abs(white.Bz) > 1e-3 {
+		t.Errorf("diffuse white Jzazbz %+v, want Jz=0.2221 and no chroma", white)
+	}
+	if black := (CIEXYZ{}).Jzazbz(); math32.Abs(black.Jz) > 1e-6 {
+		t.Errorf("black Jz = %v", black.Jz)
+	}
+	for _, c := range []SRGB{{R: 1}, {G: 1}, {B: 1}, {R: 0.2, G: 0.5, B: 0.8}, {R: 0.05, G: 0.04, B: 0.03}} {
+		xyz := c.LSRGB().CIEXYZ()
+		jab := xyz.Jzazbz()
+		if got := jab.CIEXYZ(); sqdist(got.vec(), xyz.vec()) > 1e-8 {
+			t.Errorf("Jzazbz round trip of %+v via %+v gave %+v", xyz, jab, got)
+		}
+		if got := jab.JzCzhz().Jzazbz(); sqdist(got.vec(), jab.vec()) > 1e-12 {
+			t.Errorf("JzCzhz round trip of %+v gave %+v", jab, got)
+		}
+	}
+	// Lerp endpoints and hue path.
+	red, blue := SRGB{R: 1}, SRGB{B: 1}
+	if got := ColorToSRGB(LerpJzazbz(red, blue, 0)); sqdist(got.vec(), red.vec()) > 1e-6 {
+		t.Errorf("LerpJzazbz start %+v", got)
+	}
+	mid := ColorToSRGB(LerpJzCzhz(red, blue, 0.5))
+	if !mid.LSRGB().InGamut() || mid.G > mid.R || mid.G > mid.B {
+		t.Errorf("red-blue JzCzhz midpoint should be in gamut purple, got %+v", mid)
+	}
+}
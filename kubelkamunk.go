@@ -0,0 +1,64 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// minReflectance avoids the Kubelka-Munk singularity at zero reflectance.
+const minReflectance = 1e-4
+
+// KubelkaMunkKS returns the ratio of absorption to scattering coefficients K/S of an opaque
+// layer with reflectance r in (0,1] using the single-constant Kubelka-Munk model.
+func KubelkaMunkKS(r float32) float32 {
+	r = math32.Max(math32.Min(r, 1), minReflectance)
+	return (1 - r) * (1 - r) / (2 * r)
+}
+
+// KubelkaMunkReflectance returns the reflectance of an opaque layer with absorption
+// to scattering ratio ks. It is the inverse of [KubelkaMunkKS].
+func KubelkaMunkReflectance(ks float32) float32 {
+	if ks <= 0 {
+		return 1
+	}
+	return 1 + ks - math32.Sqrt(ks*ks+2*ks)
+}
+
+// KS returns the spectral K/S of a reflectance spectrum. See [KubelkaMunkKS].
+func (s Spectrum) KS() Spectrum {
+	ks := Spectrum{Start: s.Start, Step: s.Step, Values: make([]float32, len(s.Values))}
+	for i, r := range s.Values {
+		ks.Values[i] = KubelkaMunkKS(r)
+	}
+	return ks
+}
+
+// MixPigments predicts the reflectance of an opaque mixture of pigments given the reflectance of
+// each pigment masstone and its relative concentration. K/S is additive in concentration under the
+// single-constant Kubelka-Munk model, so blue and yellow pigments mix to green as real paint does.
+// Concentrations are normalized to sum to 1. The result is sampled from 380nm to 780nm in 10nm steps.
+func MixPigments(reflectances []Spectrum, concentrations []float32) Spectrum {
+	if len(reflectances) != len(concentrations) {
+		panic("reflectances and concentrations length mismatch")
+	}
+	var total float32
+	for _, c := range concentrations {
+		total += c
+	}
+	mix := Spectrum{Start: cmfStart, Step: cmfStep, Values: make([]float32, len(cmf1931))}
+	if total <= 0 {
+		return mix
+	}
+	for i := range mix.Values {
+		λ := cmfStart + cmfStep*float32(i)
+		var ks float32
+		for j, r := range reflectances {
+			ks += concentrations[j] / total * KubelkaMunkKS(r.At(λ))
+		}
+		mix.Values[i] = KubelkaMunkReflectance(ks)
+	}
+	return mix
+}
+
+// ReflectanceSRGB returns the sRGB color of a surface with the given spectral reflectance
+// viewed under illuminant D65. Out of gamut colors are clipped.
+func ReflectanceSRGB(reflectance Spectrum) SRGB {
+	return ReflectanceXYZ(reflectance, SpectrumD65()).LSRGB().ClipToGamut().SRGB()
+}
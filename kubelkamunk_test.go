@@ -0,0 +1,43 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestKubelkaMunk(t *testing.T) {
+	for _, r := range []float32{0.01, 0.2, 0.5, 0.9, 1} {
+		got := KubelkaMunkReflectance(KubelkaMunkKS(r))
+		if math32.Abs(got-r) > 1e-4 {
+			t.Errorf("round trip of reflectance %v got %v", r, got)
+		}
+	}
+}
+
+func TestMixPigments(t *testing.T) {
+	band := func(lo, hi float32) Spectrum {
+		s := SpectrumE()
+		for i := range s.Values {
+			λ := s.Start + s.Step*float32(i)
+			s.Values[i] = 0.05
+			if λ >= lo && λ <= hi {
+				s.Values[i] = 0.85
+			}
+		}
+		return s
+	}
+	blue, yellow := band(400, 540), band(500, 700)
+	mix := MixPigments([]Spectrum{blue, yellow}, []float32{1, 1})
+	green := ReflectanceSRGB(mix)
+	if green.G <= green.R || green.G <= green.B {
+		t.Errorf("blue and yellow pigments should mix to green, got %+v", green)
+	}
+	// A single pigment is returned unchanged.
+	same := MixPigments([]Spectrum{yellow}, []float32{3})
+	for i, v := range same.Values {
+		if math32.Abs(v-yellow.Values[i]) > 1e-4 {
+			t.Fatalf("single pigment mix differs at %d: %v != %v", i, v, yellow.Values[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package colorspace
+
+// Lightness returns the perceptual lightness of c normalized to [0,1] for comparison with [OKLAB].
+func (c CIELAB) Lightness() float32 { return c.L / 100 }
+
+// Lightness returns the perceptual lightness of c normalized to [0,1] for comparison with [OKLCH].
+func (c CIELCH) Lightness() float32 { return c.L / 100 }
+
+// Lightness returns the perceptual lightness of c in [0,1]. Provided for symmetry with [CIELAB.Lightness].
+func (c OKLAB) Lightness() float32 { return c.L }
+
+// Lightness returns the perceptual lightness of c in [0,1]. Provided for symmetry with [CIELCH.Lightness].
+func (c OKLCH) Lightness() float32 { return c.L }
+
+// OKLAB converts the D50 relative CIELAB color to OKLab, which is defined relative to D65.
+// White maps to white: L=100 maps to L=1.
+func (c CIELAB) OKLAB() OKLAB {
+	return c.CIEXYZ().d50ToD65().OKLAB()
+}
+
+// CIELAB converts the OKLab color to D50 relative CIELAB. L is scaled from [0,1] to [0,100].
+func (c OKLAB) CIELAB() CIELAB {
+	return c.CIEXYZ().d65ToD50().CIELAB()
+}
+
+// CIELCH converts the OKLCH color to D50 relative CIELCH. Hue angles differ between the two spaces.
+func (c OKLCH) CIELCH() CIELCH {
+	return c.OKLAB().CIELAB().CIELCH()
+}
+
+// OKLCH converts the D50 relative CIELCH color to OKLCH. See [CIELAB.OKLAB].
+func (c CIELCH) OKLCH() OKLCH {
+	return c.CIELAB().OKLAB().OKLCH()
+}
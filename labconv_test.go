@@ -0,0 +1,43 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestCIELABOKLAB(t *testing.T) {
+	white := OKLAB{L: 1}.CIELAB()
+	if math32.Abs(white.L-100) > 0.05 || math32.Abs(white.A) > 0.05 || math32.Abs(white.B) > 0.05 {
+		t.Errorf("OKLab white mapped to %+v, want CIELAB white", white)
+	}
+	if l := white.Lightness(); math32.Abs(l-1) > 5e-4 {
+		t.Errorf("normalized lightness %v, want 1", l)
+	}
+	for _, c := range []SRGB{{R: 1}, {G: 0.5, B: 0.2}, {R: 0.3, G: 0.3, B: 0.9}} {
+		want := colorToCIELAB(c)
+		got := c.LSRGB().CIEXYZ().OKLAB().CIELAB()
+		if d := want.DeltaE2000(got); d > 0.05 {
+			t.Errorf("%+v: direct conversion off by ΔE %v", c, d)
+		}
+		back := got.OKLAB().CIEXYZ().LSRGB().SRGB()
+		if sqdist(back.vec(), c.vec()) > 1e-6 {
+			t.Errorf("%+v: round trip gave %+v", c, back)
+		}
+	}
+}
+
+func TestCIELCHOKLCH(t *testing.T) {
+	for _, c := range []SRGB{{R: 1}, {G: 0.5, B: 0.2}, {R: 0.3, G: 0.3, B: 0.9}} {
+		lch := c.LSRGB().CIEXYZ().OKLAB().OKLCH()
+		want := colorToCIELAB(c).CIELCH()
+		got := lch.CIELCH()
+		if d := want.CIELAB().DeltaE2000(got.CIELAB()); d > 0.05 {
+			t.Errorf("%+v: direct conversion off by ΔE %v", c, d)
+		}
+		back := got.OKLCH()
+		if math32.Abs(back.L-lch.L) > 1e-4 || math32.Abs(back.C-lch.C) > 1e-4 || math32.Abs(back.H-lch.H) > 0.05 {
+			t.Errorf("%+v: round trip gave %+v, want %+v", c, back, lch)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// PWM8 returns 8-bit PWM duty cycles for driving an RGB LED with the color scaled by brightness in [0,1].
+// LED light output is proportional to duty cycle so duties are linear light values and not
+// gamma-encoded like [SRGB.RGB8]. Channels are clipped to [0,1] and rounded to nearest.
+func (c LSRGB) PWM8(brightness float32) (r, g, b uint8) {
+	c = c.ScaleBrightness(brightness).ClipToGamut()
+	r = uint8(c.R*0xff + 0.5)
+	g = uint8(c.G*0xff + 0.5)
+	b = uint8(c.B*0xff + 0.5)
+	return r, g, b
+}
+
+// PWM8 returns 8-bit PWM duty cycles for driving an RGB LED with the color gamut mapped to sRGB
+// and scaled by brightness in [0,1]. See [LSRGB.PWM8].
+func (c OKLCH) PWM8(brightness float32) (r, g, b uint8) {
+	return c.GamutMappedLSRGB().OKLAB().CIEXYZ().LSRGB().PWM8(brightness)
+}
+
+// ScaleBrightness scales the color's light output by brightness. Since linear sRGB
+// is proportional to light, channels are multiplied directly preserving chromaticity.
+func (c LSRGB) ScaleBrightness(brightness float32) LSRGB {
+	return LSRGB{R: c.R * brightness, G: c.G * brightness, B: c.B * brightness}
+}
+
+// ScaleBrightness scales the color's light output by brightness in linear light
+// rather than scaling the gamma-encoded channels, which would shift hue and saturation.
+func (c SRGB) ScaleBrightness(brightness float32) SRGB {
+	return c.LSRGB().ScaleBrightness(brightness).SRGB()
+}
+
+// BlackbodyLSRGB returns the linear sRGB color of a Planckian radiator at the given temperature in
+// kelvin normalized so the largest channel is 1. Channels are clipped to gamut, which is only needed
+// below about 1500K and above 10000K.
+func BlackbodyLSRGB(kelvin float32) LSRGB {
+	c := IlluminantXYZ(BlackbodySpectrum(kelvin)).LSRGB().ClipToGamut()
+	max := c.vec().Max()
+	if max <= 0 {
+		return LSRGB{}
+	}
+	return c.ScaleBrightness(1 / max)
+}
+
+// IncandescentDimmingCCT returns the correlated color temperature of an incandescent lamp of
+// color temperature fullCCT dimmed to relative light output brightness in (0,1]. It follows the empirical
+// lamp laws where light output varies as voltage^3.4 and color temperature as voltage^0.42,
+// so a dimmed 2850K lamp at 10% output glows at around 2150K. Use it to emulate dim-to-warm behavior.
+func IncandescentDimmingCCT(brightness, fullCCT float32) float32 {
+	if brightness <= 0 {
+		return 0
+	}
+	return fullCCT * math32.Pow(math32.Min(brightness, 1), 0.42/3.4)
+}
+
+// CandleDimmingCCT returns the color temperature of a dim-to-warm "candle" curve which starts at
+// 2700K at full brightness and warms towards the 1800K of a candle flame as brightness approaches 0.
+// The temperature varies linearly with perceived lightness rather than light output so warming is even across the dimming range.
+func CandleDimmingCCT(brightness float32) float32 {
+	const full, candle = 2700, 1800
+	brightness = math32.Max(math32.Min(brightness, 1), 0)
+	// CIE lightness in [0,1] of the relative luminance.
+	l := CIEXYZ{Y: brightness}.CIELAB().Lightness()
+	return candle + (full-candle)*l
+}
+
+// DimmedWhite returns the linear sRGB color of a warm white LED dimmed to brightness in [0,1]
+// following the given dimming curve, i.e: [CandleDimmingCCT]. Use [LSRGB.PWM8] to obtain duty cycles.
+func DimmedWhite(brightness float32, curve func(brightness float32) float32) LSRGB {
+	if brightness <= 0 {
+		return LSRGB{}
+	}
+	return BlackbodyLSRGB(curve(brightness)).ScaleBrightness(brightness)
+}
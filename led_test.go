@@ -0,0 +1,45 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestPWM8(t *testing.T) {
+	mid := SRGB{R: 0.5, G: 0.5, B: 0.5}
+	r, g, b := mid.LSRGB().PWM8(1)
+	// Mid-grey in sRGB emits around 21% of full light output.
+	if r != 55 || g != r || b != r {
+		t.Errorf("got duty (%d,%d,%d), want 55", r, g, b)
+	}
+	red := SRGB{R: 1}.LSRGB().CIEXYZ().OKLAB().OKLCH()
+	r, g, b = red.PWM8(0.5)
+	if r < 126 || r > 129 || g != 0 || b != 0 {
+		t.Errorf("half brightness red got duty (%d,%d,%d)", r, g, b)
+	}
+	scaled := mid.ScaleBrightness(0.5).LSRGB()
+	if want := 0.5 * mid.LSRGB().R; math32.Abs(scaled.R-want) > 1e-5 {
+		t.Errorf("brightness scaling not linear: got %v, want %v", scaled.R, want)
+	}
+}
+
+func TestDimming(t *testing.T) {
+	if cct := IncandescentDimmingCCT(0.1, 2850); math32.Abs(cct-2150) > 30 {
+		t.Errorf("dimmed incandescent got %vK", cct)
+	}
+	if cct := CandleDimmingCCT(1); cct != 2700 {
+		t.Errorf("full brightness candle got %vK", cct)
+	}
+	prev := DimmedWhite(1, CandleDimmingCCT)
+	if prev.R != 1 {
+		t.Errorf("full brightness warm white should saturate red, got %+v", prev)
+	}
+	for _, bright := range []float32{0.5, 0.1, 0.01} {
+		c := DimmedWhite(bright, CandleDimmingCCT)
+		if c.B/c.R >= prev.B/prev.R {
+			t.Errorf("dimming to %v should warm the white: %+v vs %+v", bright, c, prev)
+		}
+		prev = c
+	}
+}
@@ -0,0 +1,69 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// sdcmUVPrime is the approximate radius of a one step MacAdam ellipse in the CIE 1976 u′v′ diagram.
+const sdcmUVPrime = 0.0011
+
+// MacAdamEllipse is a one step MacAdam ellipse, or standard deviation of color matching (SDCM),
+// in the CIE 1931 xy diagram. Chromaticities on its boundary are one step away from Center,
+// which is just noticeable to the average observer.
+type MacAdamEllipse struct {
+	// CCT is the nominal correlated color temperature of the center in kelvin.
+	CCT    float32
+	Center CIExy
+	// A and B are the semi-major and semi-minor axes of the ellipse.
+	A, B float32
+	// Theta is the angle of the major axis to the x axis in degrees.
+	Theta float32
+}
+
+// macAdamEllipses are the IEC 60081 one step ellipses for standard lamp color points.
+var macAdamEllipses = [...]MacAdamEllipse{
+	{CCT: 2700, Center: CIExy{X: 0.4630, Y: 0.4200}, A: 0.00258, B: 0.00137, Theta: 57.28},
+	{CCT: 3000, Center: CIExy{X: 0.4400, Y: 0.4030}, A: 0.00278, B: 0.00136, Theta: 53.43},
+	{CCT: 3500, Center: CIExy{X: 0.4090, Y: 0.3940}, A: 0.00317, B: 0.00139, Theta: 52.13},
+	{CCT: 4000, Center: CIExy{X: 0.3800, Y: 0.3800}, A: 0.00313, B: 0.00134, Theta: 54.00},
+	{CCT: 5000, Center: CIExy{X: 0.3460, Y: 0.3590}, A: 0.00274, B: 0.00118, Theta: 59.62},
+	{CCT: 6500, Center: CIExy{X: 0.3130, Y: 0.3370}, A: 0.00223, B: 0.00095, Theta: 58.23},
+}
+
+// MacAdamEllipses returns the IEC 60081 one step MacAdam ellipses of standard
+// white light sources (2700K, 3000K, 3500K, 4000K, 5000K and 6500K) in ascending CCT.
+func MacAdamEllipses() []MacAdamEllipse {
+	return append([]MacAdamEllipse{}, macAdamEllipses[:]...)
+}
+
+// MacAdamEllipseCCT returns the standard one step MacAdam ellipse with CCT
+// closest to the given color temperature in kelvin.
+func MacAdamEllipseCCT(kelvin float32) MacAdamEllipse {
+	best := macAdamEllipses[0]
+	for _, e := range macAdamEllipses[1:] {
+		if math32.Abs(e.CCT-kelvin) < math32.Abs(best.CCT-kelvin) {
+			best = e
+		}
+	}
+	return best
+}
+
+// Steps returns the number of MacAdam steps (SDCM) chromaticity c lies from the ellipse center.
+// Lighting specifications commonly require lamps to lie within 3 to 7 steps of the target.
+func (e MacAdamEllipse) Steps(c CIExy) float32 {
+	sin, cos := math32.Sincos(e.Theta * math32.Pi / 180)
+	dx, dy := c.X-e.Center.X, c.Y-e.Center.Y
+	major := (dx*cos + dy*sin) / e.A
+	minor := (-dx*sin + dy*cos) / e.B
+	return math32.Hypot(major, minor)
+}
+
+// Contains reports whether c lies within the n step ellipse.
+func (e MacAdamEllipse) Contains(c CIExy, steps float32) bool {
+	return e.Steps(c) <= steps
+}
+
+// SDCM approximates the number of MacAdam steps between two chromaticities of arbitrary
+// color points by treating one step as a circle of radius 0.0011 in the u′v′ diagram.
+// Prefer [MacAdamEllipse.Steps] when the target is a standard lamp color point.
+func SDCM(c, target UVPrime) float32 {
+	return math32.Hypot(c.U-target.U, c.V-target.V) / sdcmUVPrime
+}
@@ -0,0 +1,44 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestMacAdamEllipse(t *testing.T) {
+	e := MacAdamEllipseCCT(3100)
+	if e.CCT != 3000 {
+		t.Fatalf("expected 3000K ellipse, got %v", e.CCT)
+	}
+	if steps := e.Steps(e.Center); steps != 0 {
+		t.Errorf("center should be zero steps away, got %v", steps)
+	}
+	sin, cos := math32.Sincos(e.Theta * math32.Pi / 180)
+	for _, test := range []struct {
+		c    CIExy
+		want float32
+	}{
+		{c: CIExy{X: e.Center.X + 3*e.A*cos, Y: e.Center.Y + 3*e.A*sin}, want: 3},
+		{c: CIExy{X: e.Center.X - 2*e.B*sin, Y: e.Center.Y + 2*e.B*cos}, want: 2},
+	} {
+		got := e.Steps(test.c)
+		if math32.Abs(got-test.want) > 1e-3 {
+			t.Errorf("got %v steps, want %v", got, test.want)
+		}
+	}
+	if !e.Contains(CIExy{X: e.Center.X + e.A*cos, Y: e.Center.Y + e.A*sin}, 1.01) {
+		t.Error("expected point on one step ellipse to be contained")
+	}
+}
+
+func TestSDCM(t *testing.T) {
+	white := IlluminantD65(1).UVPrime()
+	if got := SDCM(UVPrime{U: white.U + 0.0033, V: white.V}, white); math32.Abs(got-3) > 1e-3 {
+		t.Errorf("got %v steps, want 3", got)
+	}
+	xy := white.CIExy()
+	if back := xy.UVPrime(); math32.Abs(back.U-white.U) > 1e-6 || math32.Abs(back.V-white.V) > 1e-6 {
+		t.Errorf("xy round trip mismatch: %+v != %+v", back, white)
+	}
+}
@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/soypat/geometry/ms1"
+)
+
+// ChromaKeyMatte returns a soft matte (alpha mask) of img for chroma keying against key,
+// a building block for green-screen tooling. Pixels closer than inner to the key color in [OKLAB]
+// are fully transparent (alpha 0), pixels further than outer are fully opaque (alpha 0xffff)
+// and distances in between are smoothly blended. Typical values are inner=0.05 and outer=0.15.
+// The returned matte has the same bounds as img.
+func ChromaKeyMatte(img image.Image, key color.Color, inner, outer float32) *image.Alpha16 {
+	if inner > outer {
+		panic("inner threshold must not exceed outer threshold")
+	}
+	keyLab := ColorToSRGB(key).LSRGB().CIEXYZ().OKLAB()
+	bounds := img.Bounds()
+	matte := image.NewAlpha16(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			lab := ColorToSRGB(img.At(x, y)).LSRGB().CIEXYZ().OKLAB()
+			a := keyMatteAlpha(lab.DeltaE(keyLab), inner, outer)
+			matte.SetAlpha16(x, y, color.Alpha16{A: uint16(a*0xffff + 0.5)})
+		}
+	}
+	return matte
+}
+
+// keyMatteAlpha returns the opacity in [0,1] of a pixel at distance d from the key color
+// using a smoothstep between the inner and outer thresholds.
+func keyMatteAlpha(d, inner, outer float32) float32 {
+	if d <= inner {
+		return 0
+	} else if d >= outer {
+		return 1
+	}
+	t := ms1.Clamp((d-inner)/(outer-inner), 0, 1)
+	return t * t * (3 - 2*t)
+}
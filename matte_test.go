@@ -0,0 +1,25 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestChromaKeyMatte(t *testing.T) {
+	green := color.RGBA{G: 255, A: 255}
+	img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	img.Set(0, 0, green)
+	img.Set(1, 0, color.RGBA{R: 20, G: 240, B: 20, A: 255})
+	img.Set(2, 0, color.RGBA{R: 255, G: 200, B: 180, A: 255})
+	matte := ChromaKeyMatte(img, green, 0.05, 0.15)
+	if a := matte.Alpha16At(0, 0).A; a != 0 {
+		t.Errorf("expected key color to be transparent, got alpha %d", a)
+	}
+	if a := matte.Alpha16At(2, 0).A; a != 0xffff {
+		t.Errorf("expected foreground to be opaque, got alpha %d", a)
+	}
+	if a := matte.Alpha16At(1, 0).A; a == 0xffff {
+		t.Errorf("expected near-key color to be at least partially transparent")
+	}
+}
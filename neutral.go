@@ -0,0 +1,44 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// IsNeutral reports whether the color lies within tolerance of the achromatic (gray) axis,
+// that is, whether its chroma sqrt(A²+B²) is at most tolerance. A tolerance of 0.02
+// catches grays that look neutral to most observers.
+func (c OKLAB) IsNeutral(tolerance float32) bool {
+	return math32.Hypot(c.A, c.B) <= tolerance
+}
+
+// NearestNeutral returns the gray of the same lightness, the closest point on the achromatic axis.
+func (c OKLAB) NearestNeutral() OKLAB {
+	return OKLAB{L: c.L}
+}
+
+// IsNeutral reports whether the color lies within tolerance of the achromatic (gray) axis,
+// that is, whether its chroma sqrt(A²+B²) is at most tolerance. A tolerance of 2
+// is close to the just noticeable difference.
+func (c CIELAB) IsNeutral(tolerance float32) bool {
+	return math32.Hypot(c.A, c.B) <= tolerance
+}
+
+// NearestNeutral returns the gray of the same lightness, the closest point on the achromatic axis.
+func (c CIELAB) NearestNeutral() CIELAB {
+	return CIELAB{L: c.L}
+}
+
+// IsNeutral reports whether c is within tolerance of the achromatic axis in OKLAB. See [OKLAB.IsNeutral].
+func IsNeutral(c color.Color, tolerance float32) bool {
+	return ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB().IsNeutral(tolerance)
+}
+
+// NearestNeutral returns the sRGB gray of the same OKLAB lightness as c.
+func NearestNeutral(c color.Color) SRGB {
+	l := ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB().L
+	// Grays in OKLAB have linear light equal to the cube of their lightness.
+	y := clamp01(l * l * l)
+	return LSRGB{R: y, G: y, B: y}.SRGB()
+}
@@ -0,0 +1,31 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestNeutral(t *testing.T) {
+	for _, test := range []struct {
+		c    color.Color
+		want bool
+	}{
+		{c: color.White, want: true},
+		{c: color.Gray{Y: 100}, want: true},
+		{c: color.RGBA{R: 130, G: 128, B: 126, A: 255}, want: true},
+		{c: color.RGBA{R: 160, G: 128, B: 100, A: 255}, want: false},
+		{c: color.RGBA{B: 255, A: 255}, want: false},
+	} {
+		if got := IsNeutral(test.c, 0.02); got != test.want {
+			t.Errorf("%v: got neutral=%v, want %v", test.c, got, test.want)
+		}
+	}
+	gray := NearestNeutral(color.RGBA{R: 160, G: 128, B: 100, A: 255})
+	if gray.R != gray.G || gray.G != gray.B {
+		t.Errorf("nearest neutral is not gray: %+v", gray)
+	}
+	lab := CIELAB{L: 50, A: 1, B: -1}
+	if !lab.IsNeutral(2) || lab.NearestNeutral() != (CIELAB{L: 50}) {
+		t.Errorf("unexpected CIELAB neutral result for %+v", lab)
+	}
+}
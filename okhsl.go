@@ -0,0 +1,364 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// Okhsl is Björn Ottosson's perceptual alternative to [HSL] built on [OKLAB]: hue is the OKLCH hue,
+// lightness is a remapped OKLAB lightness and saturation is relative to the sRGB gamut boundary,
+// so every Okhsl color with components in range is representable in sRGB. Unlike HSL, colors of equal
+// lightness have equal perceived lightness. Okhsl is well suited for color pickers.
+// See https://bottosson.github.io/posts/colorpicker/.
+type Okhsl struct {
+	H float32 // Hue in degrees in [0,360).
+	S float32 // Saturation in [0,1].
+	L float32 // Lightness in [0,1].
+}
+
+// Okhsv is the perceptual alternative to [HSV] built on [OKLAB]. See [Okhsl].
+// Value 1 with saturation 1 is the most saturated color of the hue in sRGB.
+type Okhsv struct {
+	H float32 // Hue in degrees in [0,360).
+	S float32 // Saturation in [0,1].
+	V float32 // Value in [0,1].
+}
+
+func (c Okhsl) vec() ms3.Vec      { return ms3.Vec{X: c.H, Y: c.S, Z: c.L} }
+func (c Okhsv) vec() ms3.Vec      { return ms3.Vec{X: c.H, Y: c.S, Z: c.V} }
+func (c Okhsl) Array() [3]float32 { return c.vec().Array() }
+func (c Okhsv) Array() [3]float32 { return c.vec().Array() }
+
+// LerpOkhsl interpolates in Okhsl (hue, saturation, lightness) along the shortest hue arc.
+// Results are always inside the sRGB gamut.
+func LerpOkhsl(c1, c2 color.Color, v float32) color.Color {
+	return ColorToSRGB(c1).Okhsl().Lerp(ColorToSRGB(c2).Okhsl(), v).SRGB()
+}
+
+// LerpOkhsv interpolates in Okhsv (hue, saturation, value) along the shortest hue arc.
+// Results are always inside the sRGB gamut.
+func LerpOkhsv(c1, c2 color.Color, v float32) color.Color {
+	return ColorToSRGB(c1).Okhsv().Lerp(ColorToSRGB(c2).Okhsv(), v).SRGB()
+}
+
+// Lerp interpolates between two Okhsl colors along the shortest hue arc.
+func (from Okhsl) Lerp(to Okhsl, v float32) Okhsl {
+	lch := CIELCH{L: from.L, C: from.S, H: from.H}.Lerp(CIELCH{L: to.L, C: to.S, H: to.H}, v)
+	return Okhsl{H: lch.H, S: lch.C, L: lch.L}
+}
+
+// Lerp interpolates between two Okhsv colors along the shortest hue arc.
+func (from Okhsv) Lerp(to Okhsv, v float32) Okhsv {
+	lch := CIELCH{L: from.V, C: from.S, H: from.H}.Lerp(CIELCH{L: to.V, C: to.S, H: to.H}, v)
+	return Okhsv{H: lch.H, S: lch.C, V: lch.L}
+}
+
+// Okhsl converts the sRGB color to Okhsl.
+func (c SRGB) Okhsl() Okhsl {
+	lab := linearSRGBToOklab(c.LSRGB())
+	C := math32.Hypot(lab.A, lab.B)
+	l := okToe(lab.L)
+	if C < 1e-6 || lab.L <= 0 || lab.L >= 1 {
+		return Okhsl{L: clamp01(l)}
+	}
+	h, a, b := okHue(lab.A, lab.B)
+	c0, cMid, cMax := okhslChromas(lab.L, a, b)
+	const mid, midInv = 0.8, 1.25
+	var s float32
+	if C < cMid {
+		k1 := mid * c0
+		k2 := 1 - k1/cMid
+		s = mid * C / (k1 + k2*C)
+	} else {
+		k1 := (1 - mid) * cMid * cMid * midInv * midInv / c0
+		k2 := 1 - k1/(cMax-cMid)
+		t := (C - cMid) / (k1 + k2*(C-cMid))
+		s = mid + (1-mid)*t
+	}
+	return Okhsl{H: h, S: s, L: l}
+}
+
+// SRGB converts the Okhsl color to sRGB.
+func (c Okhsl) SRGB() SRGB {
+	if c.L >= 1 {
+		return SRGB{R: 1, G: 1, B: 1}
+	} else if c.L <= 0 {
+		return SRGB{}
+	}
+	sin, cos := math32.Sincos(c.H * math32.Pi / 180)
+	L := okToeInv(c.L)
+	c0, cMid, cMax := okhslChromas(L, cos, sin)
+	const mid, midInv = 0.8, 1.25
+	var C float32
+	if c.S < mid {
+		t := midInv * c.S
+		k1 := mid * c0
+		k2 := 1 - k1/cMid
+		C = t * k1 / (1 - k2*t)
+	} else {
+		t := (c.S - mid) / (1 - mid)
+		k1 := (1 - mid) * cMid * cMid * midInv * midInv / c0
+		k2 := 1 - k1/(cMax-cMid)
+		C = cMid + t*k1/(1-k2*t)
+	}
+	return oklabToLinearSRGB(OKLAB{L: L, A: C * cos, B: C * sin}).ClipToGamut().SRGB()
+}
+
+// Okhsv converts the sRGB color to Okhsv.
+func (c SRGB) Okhsv() Okhsv {
+	lab := linearSRGBToOklab(c.LSRGB())
+	C := math32.Hypot(lab.A, lab.B)
+	if lab.L <= 0 {
+		return Okhsv{}
+	} else if C < 1e-6 {
+		return Okhsv{V: clamp01(okToe(lab.L))}
+	}
+	h, a, b := okHue(lab.A, lab.B)
+	sMax, tMax := okCuspST(a, b)
+	const s0 = 0.5
+	k := 1 - s0/sMax
+	// Project to the triangle spanned by black, white and the cusp.
+	t := tMax / (C + lab.L*tMax)
+	Lv, Cv := t*lab.L, t*C
+	L := okToe(lab.L / okScaleL(Lv, Cv, a, b))
+	return Okhsv{
+		H: h,
+		S: (s0 + tMax) * Cv / (tMax*s0 + tMax*k*Cv),
+		V: L / Lv,
+	}
+}
+
+// SRGB converts the Okhsv color to sRGB.
+func (c Okhsv) SRGB() SRGB {
+	if c.V <= 0 {
+		return SRGB{}
+	}
+	sin, cos := math32.Sincos(c.H * math32.Pi / 180)
+	sMax, tMax := okCuspST(cos, sin)
+	const s0 = 0.5
+	k := 1 - s0/sMax
+	denom := s0 + tMax - tMax*k*c.S
+	Lv := 1 - c.S*s0/denom
+	Cv := c.S * tMax * s0 / denom
+	L, C := c.V*Lv, c.V*Cv
+	Lnew := okToeInv(L)
+	C = C * Lnew / L
+	L = Lnew
+	scale := okScaleL(Lv, Cv, cos, sin)
+	L, C = L*scale, C*scale
+	return oklabToLinearSRGB(OKLAB{L: L, A: C * cos, B: C * sin}).ClipToGamut().SRGB()
+}
+
+// okScaleL returns the factor compensating the toe applied to lightness so the
+// value of the Okhsv triangle at (Lv, Cv) stays on the sRGB gamut boundary.
+func okScaleL(Lv, Cv, a, b float32) float32 {
+	Lvt := okToeInv(Lv)
+	Cvt := Cv * Lvt / Lv
+	rgb := oklabToLinearSRGB(OKLAB{L: Lvt, A: a * Cvt, B: b * Cvt})
+	return math32.Cbrt(1 / math32.Max(math32.Max(rgb.R, rgb.G), math32.Max(rgb.B, 0)))
+}
+
+// okHue returns the hue in degrees of the OKLAB chromatic components (a, b) and the unit hue direction
+// computed back from it. Using the same direction as the inverse conversion avoids round trip errors
+// near the boundaries of the gamut approximation regions, i.e: at the sRGB blue primary.
+func okHue(a, b float32) (h, dira, dirb float32) {
+	h = math32.Atan2(b, a) * 180 / math32.Pi
+	if h < 0 {
+		h += 360
+	}
+	dirb, dira = math32.Sincos(h * math32.Pi / 180)
+	return h, dira, dirb
+}
+
+// Okhsl lightness toe constants.
+const (
+	okToeK1 = 0.206
+	okToeK2 = 0.03
+	okToeK3 = (1 + okToeK1) / (1 + okToeK2)
+)
+
+// okToe maps OKLAB lightness to Okhsl lightness, which matches CIELAB lightness more closely near black.
+func okToe(x float32) float32 {
+	y := okToeK3*x - okToeK1
+	return 0.5 * (y + math32.Sqrt(y*y+4*okToeK2*okToeK3*x))
+}
+
+// okToeInv is the inverse of okToe.
+func okToeInv(x float32) float32 {
+	return (x*x + okToeK1*x) / (okToeK3 * (x + okToeK2))
+}
+
+// okMaxSaturation returns the maximum saturation S = C/L in the sRGB gamut for the normalized OKLAB hue
+// direction (a, b) with a polynomial approximation refined with Halley's method.
+func okMaxSaturation(a, b float32) float32 {
+	// The regions where each sRGB channel is the first to reach zero.
+	redCond := -1.88170328*a - 0.80936493*b
+	greenCond := 1.81444104*a - 1.19445276*b
+	switch {
+	case math32.Abs(redCond-1) < 1e-4 || math32.Abs(greenCond-1) < 1e-4:
+		// Where regions meet channels reach zero at nearly the same saturation, but rounding may select
+		// a channel dipping slightly below zero first, making the sRGB primary unreachable. Use the larger root.
+		S := float32(0)
+		for ch := 0; ch < 3; ch++ {
+			if ch == 0 && redCond > 1-1e-4 || ch == 1 && greenCond > 1-1e-4 || ch == 2 && redCond < 1+1e-4 && greenCond < 1+1e-4 {
+				S = math32.Max(S, okChannelZero(a, b, ch))
+			}
+		}
+		return S
+	case redCond > 1:
+		return okChannelZero(a, b, 0)
+	case greenCond > 1:
+		return okChannelZero(a, b, 1)
+	}
+	return okChannelZero(a, b, 2)
+}
+
+// okChannelZero returns the saturation at which the sRGB channel ch (0 red, 1 green, 2 blue) reaches zero
+// in the normalized OKLAB hue direction (a, b) at OKLAB lightness 1.
+func okChannelZero(a, b float32, ch int) float32 {
+	// Coefficients of the polynomial fit and the channel's row of the LMS to linear sRGB matrix.
+	var k0, k1, k2, k3, k4, wl, wm, ws float32
+	switch ch {
+	case 0:
+		k0, k1, k2, k3, k4 = 1.19086277, 1.76576728, 0.59662641, 0.75515197, 0.56771245
+		wl, wm, ws = 4.0767416621, -3.3077115913, 0.2309699292
+	case 1:
+		k0, k1, k2, k3, k4 = 0.73956515, -0.45954404, 0.08285427, 0.12541070, 0.14503204
+		wl, wm, ws = -1.2684380046, 2.6097574011, -0.3413193965
+	default:
+		k0, k1, k2, k3, k4 = 1.35733652, -0.00915799, -1.15130210, -0.50559606, 0.00692167
+		wl, wm, ws = -0.0041960863, -0.7034186147, 1.7076147010
+	}
+	S := k0 + k1*a + k2*b + k3*a*a + k4*a*b
+	kl := 0.3963377774*a + 0.2158037573*b
+	km := -0.1055613458*a - 0.0638541728*b
+	ks := -0.0894841775*a - 1.2914855480*b
+	// The reference implementation takes a single step which is enough within the channel's region.
+	// Iterating converges to the root near region boundaries.
+	for i := 0; i < 4; i++ {
+		l_, m_, s_ := 1+S*kl, 1+S*km, 1+S*ks
+		l, m, s := l_*l_*l_, m_*m_*m_, s_*s_*s_
+		ldS, mdS, sdS := 3*kl*l_*l_, 3*km*m_*m_, 3*ks*s_*s_
+		ldS2, mdS2, sdS2 := 6*kl*kl*l_, 6*km*km*m_, 6*ks*ks*s_
+		f := wl*l + wm*m + ws*s
+		f1 := wl*ldS + wm*mdS + ws*sdS
+		f2 := wl*ldS2 + wm*mdS2 + ws*sdS2
+		step := f * f1 / (f1*f1 - 0.5*f*f2)
+		S -= step
+		if math32.Abs(step) < 1e-6 {
+			break
+		}
+	}
+	return S
+}
+
+// okCusp returns the lightness and chroma of the most saturated sRGB color of the normalized OKLAB hue direction (a, b).
+func okCusp(a, b float32) (L, C float32) {
+	S := okMaxSaturation(a, b)
+	rgb := oklabToLinearSRGB(OKLAB{L: 1, A: S * a, B: S * b})
+	L = math32.Cbrt(1 / math32.Max(math32.Max(rgb.R, rgb.G), rgb.B))
+	return L, L * S
+}
+
+// okCuspST returns the slopes C/L and C/(1-L) of the lines from black and white to the hue's cusp.
+func okCuspST(a, b float32) (S, T float32) {
+	L, C := okCusp(a, b)
+	return C / L, C / (1 - L)
+}
+
+// okGamutIntersection returns the chroma of the sRGB gamut boundary at lightness L in the normalized
+// OKLAB hue direction (a, b) with cusp (cuspL, cuspC).
+func okGamutIntersection(a, b, L, cuspL, cuspC float32) float32 {
+	if L <= cuspL {
+		// Lower half: intersection with the line from black to the cusp is exact.
+		return cuspC * L / cuspL
+	}
+	// Upper half: start at the line from white to the cusp and refine with a Halley step on each channel.
+	t := cuspC * (L - 1) / (cuspL - 1)
+	kl := 0.3963377774*a + 0.2158037573*b
+	km := -0.1055613458*a - 0.0638541728*b
+	ks := -0.0894841775*a - 1.2914855480*b
+	l_, m_, s_ := L+t*kl, L+t*km, L+t*ks
+	l, m, s := l_*l_*l_, m_*m_*m_, s_*s_*s_
+	ldt, mdt, sdt := 3*kl*l_*l_, 3*km*m_*m_, 3*ks*s_*s_
+	ldt2, mdt2, sdt2 := 6*kl*kl*l_, 6*km*km*m_, 6*ks*ks*s_
+	step := func(wl, wm, ws float32) float32 {
+		f := wl*l + wm*m + ws*s - 1
+		f1 := wl*ldt + wm*mdt + ws*sdt
+		f2 := wl*ldt2 + wm*mdt2 + ws*sdt2
+		u := f1 / (f1*f1 - 0.5*f*f2)
+		if u < 0 {
+			return math32.MaxFloat32
+		}
+		return -f * u
+	}
+	tr := step(4.0767416621, -3.3077115913, 0.2309699292)
+	tg := step(-1.2684380046, 2.6097574011, -0.3413193965)
+	tb := step(-0.0041960863, -0.7034186147, 1.7076147010)
+	return t + math32.Min(tr, math32.Min(tg, tb))
+}
+
+// okhslChromas returns the chromas at Okhsl saturation 0 (slope), 0.8 and 1 for OKLAB lightness L
+// in the normalized hue direction (a, b).
+func okhslChromas(L, a, b float32) (c0, cMid, cMax float32) {
+	cuspL, cuspC := okCusp(a, b)
+	cMax = okGamutIntersection(a, b, L, cuspL, cuspC)
+	sMax, tMax := cuspC/cuspL, cuspC/(1-cuspL)
+	k := cMax / math32.Min(L*sMax, (1-L)*tMax)
+
+	// Smooth approximation of the gamut boundary's S and T slopes.
+	sMid := 0.11516993 + 1/(7.44778970+4.15901240*b+
+		a*(-2.19557347+1.75198401*b+
+			a*(-2.13704948-10.02301043*b+
+				a*(-4.24894561+5.38770819*b+4.69891013*a))))
+	tMid := 0.11239642 + 1/(1.61320320-0.68124379*b+
+		a*(0.40370612+0.90148123*b+
+			a*(-0.27087943+0.61223990*b+
+				a*(0.00299215-0.45399568*b-0.14661872*a))))
+	ca, cb := L*sMid, (1-L)*tMid
+	cMid = 0.9 * k * math32.Sqrt(math32.Sqrt(1/(1/(ca*ca*ca*ca)+1/(cb*cb*cb*cb))))
+
+	ca, cb = L*0.4, (1-L)*0.8
+	c0 = math32.Sqrt(1 / (1/(ca*ca) + 1/(cb*cb)))
+	return c0, cMid, cMax
+}
+
+// Direct linear sRGB to OKLAB conversion of the reference Okhsl and Okhsv implementation. The gamut
+// approximations above are fitted to it; the slightly different CIEXYZ based [OKLAB] conversion places
+// the sRGB primaries on the wrong side of the approximations' region boundaries.
+var (
+	linSRGBToOkLMS = ms3.NewMat3([]float32{
+		0.4122214708, 0.5363325363, 0.0514459929,
+		0.2119034982, 0.6806995451, 0.1073969566,
+		0.0883024619, 0.2817188376, 0.6299787005,
+	})
+	okLMSToLab = ms3.NewMat3([]float32{
+		0.2104542553, 0.7936177850, -0.0040720468,
+		1.9779984951, -2.4285922050, 0.4505937099,
+		0.0259040371, 0.7827717662, -0.8086757660,
+	})
+	okLabToLMS = ms3.NewMat3([]float32{
+		1, 0.3963377774, 0.2158037573,
+		1, -0.1055613458, -0.0638541728,
+		1, -0.0894841775, -1.2914855480,
+	})
+	okLMSToLinSRGB = ms3.NewMat3([]float32{
+		4.0767416621, -3.3077115913, 0.2309699292,
+		-1.2684380046, 2.6097574011, -0.3413193965,
+		-0.0041960863, -0.7034186147, 1.7076147010,
+	})
+)
+
+func linearSRGBToOklab(c LSRGB) OKLAB {
+	lms := ms3.MulMatVec(linSRGBToOkLMS, c.vec())
+	v := ms3.MulMatVec(okLMSToLab, ms3.Vec{X: math32.Cbrt(lms.X), Y: math32.Cbrt(lms.Y), Z: math32.Cbrt(lms.Z)})
+	return OKLAB{L: v.X, A: v.Y, B: v.Z}
+}
+
+func oklabToLinearSRGB(c OKLAB) LSRGB {
+	lms := ms3.MulMatVec(okLabToLMS, c.vec())
+	v := ms3.MulMatVec(okLMSToLinSRGB, ms3.Vec{X: lms.X * lms.X * lms.X, Y: lms.Y * lms.Y * lms.Y, Z: lms.Z * lms.Z * lms.Z})
+	return LSRGB{R: v.X, G: v.Y, B: v.Z}
+}
@@ -0,0 +1,56 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestOkhslOkhsv(t *testing.T) {
+	colors := []SRGB{
+		{R: 1}, {G: 1}, {B: 1}, {R: 1, G: 1}, {G: 1, B: 1}, {R: 1, B: 1},
+		{R: 0.2, G: 0.4, B: 0.6}, {R: 0.9, G: 0.5, B: 0.1}, {R: 0.05, G: 0.02, B: 0.01},
+		{R: 0.5, G: 0.5, B: 0.5}, {R: 1, G: 1, B: 1}, {},
+	}
+	for _, c := range colors {
+		hsl := c.Okhsl()
+		if got := hsl.SRGB(); sqdist(got.vec(), c.vec()) > 1e-6 {
+			t.Errorf("Okhsl round trip of %+v via %+v gave %+v", c, hsl, got)
+		}
+		hsv := c.Okhsv()
+		if got := hsv.SRGB(); sqdist(got.vec(), c.vec()) > 1e-6 {
+			t.Errorf("Okhsv round trip of %+v via %+v gave %+v", c, hsv, got)
+		}
+	}
+	// Primaries and secondaries are at the gamut boundary: full Okhsv saturation and value.
+	for _, c := range colors[:6] {
+		hsv := c.Okhsv()
+		if math32.Abs(hsv.S-1) > 2e-3 || math32.Abs(hsv.V-1) > 2e-3 {
+			t.Errorf("%+v Okhsv %+v, want full saturation and value", c, hsv)
+		}
+		if hsl := c.Okhsl(); math32.Abs(hsl.S-1) > 2e-3 {
+			t.Errorf("%+v Okhsl %+v, want full saturation", c, hsl)
+		}
+	}
+	// Okhsl hue is OKLCH hue and grays have no saturation.
+	red := SRGB{R: 1}
+	if h, want := red.Okhsl().H, red.LSRGB().CIEXYZ().OKLAB().OKLCH().H; math32.Abs(h-want) > 0.1 {
+		t.Errorf("Okhsl hue %v, want OKLCH hue %v", h, want)
+	}
+	if gray := (SRGB{R: 0.5, G: 0.5, B: 0.5}).Okhsl(); gray.S != 0 {
+		t.Errorf("gray has saturation %v", gray.S)
+	}
+	// Okhsl colors are always in gamut.
+	for h := float32(0); h < 360; h += 30 {
+		for _, l := range []float32{0.1, 0.5, 0.9} {
+			c := Okhsl{H: h, S: 1, L: l}
+			lin := c.SRGB().LSRGB()
+			if !lin.InGamut() {
+				t.Errorf("%+v out of gamut: %+v", c, lin)
+			}
+		}
+	}
+	if got := ColorToSRGB(LerpOkhsl(SRGB{R: 1}, SRGB{B: 1}, 0.5)).Okhsl(); math32.Abs(got.S-1) > 0.01 {
+		t.Errorf("Okhsl lerp between saturated colors lost saturation: %+v", got)
+	}
+}
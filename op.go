@@ -0,0 +1,252 @@
+package colorspace
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	errUnknownOp     = errors.New("unknown operation name")
+	errUnmarshalOp   = errors.New("operation must be a JSON object with an \"op\" name")
+	errNilOp         = errors.New("cannot marshal nil operation")
+	errDuplicateName = errors.New("operation name already registered")
+)
+
+// Op is a color operation: a value that can be stored, composed into a [Pipeline] and
+// undone by applying its inverse, so editors can implement non-destructive editing.
+// Ops must be serializable with encoding/json. Ops of other packages must be registered
+// with [RegisterOp] for pipelines containing them to be decoded.
+type Op interface {
+	// Name identifies the kind of operation in serialized pipelines, i.e: "exposure".
+	Name() string
+	// Apply applies the operation to the color c.
+	Apply(c SRGB) SRGB
+	// Invert returns the operation undoing this one and true, or false if the operation is not
+	// invertible. Inverses are exact in linear light but not for colors clipped or gamut mapped by Apply.
+	Invert() (Op, bool)
+}
+
+var opRegistry = map[string]func(params []byte) (Op, error){
+	"exposure": func(params []byte) (Op, error) {
+		var op ExposureOp
+		err := json.Unmarshal(params, &op)
+		return op, err
+	},
+	"hueRotate": func(params []byte) (Op, error) {
+		var op HueRotateOp
+		err := json.Unmarshal(params, &op)
+		return op, err
+	},
+	"chromaScale": func(params []byte) (Op, error) {
+		var op ChromaScaleOp
+		err := json.Unmarshal(params, &op)
+		return op, err
+	},
+	"whiteBalance": func(params []byte) (Op, error) {
+		var op WhiteBalanceOp
+		err := json.Unmarshal(params, &op)
+		return op, err
+	},
+	"grade": func(params []byte) (Op, error) {
+		var op Grade
+		err := json.Unmarshal(params, &op)
+		return op, err
+	},
+	"pipeline": func(params []byte) (Op, error) {
+		var op Pipeline
+		err := json.Unmarshal(params, &op)
+		return op, err
+	},
+}
+
+// RegisterOp makes the operation named name decodable in a [Pipeline]. decode returns the operation
+// encoded as JSON in params. It is not safe for concurrent use and should be called during initialization.
+// Registering a name twice returns an error.
+func RegisterOp(name string, decode func(params []byte) (Op, error)) error {
+	if _, ok := opRegistry[name]; ok {
+		return errDuplicateName
+	}
+	opRegistry[name] = decode
+	return nil
+}
+
+// ApplyOp returns a copy of img with op applied to every pixel. Alpha is preserved.
+func ApplyOp(img image.Image, op Op) *image.RGBA64 {
+	return mapImageSRGB(img, op.Apply)
+}
+
+// Pipeline is a sequence of operations applied in order. It is itself an [Op]
+// and encodes to JSON as an array of {"op": name, "params": op} objects.
+type Pipeline []Op
+
+// Name returns "pipeline".
+func (p Pipeline) Name() string { return "pipeline" }
+
+// Apply applies the operations to c in order.
+func (p Pipeline) Apply(c SRGB) SRGB {
+	for _, op := range p {
+		c = op.Apply(c)
+	}
+	return c
+}
+
+// Invert returns the inverses of the operations in reverse order. It returns false
+// if any operation is not invertible.
+func (p Pipeline) Invert() (Op, bool) {
+	inv := make(Pipeline, len(p))
+	for i, op := range p {
+		opInv, ok := op.Invert()
+		if !ok {
+			return nil, false
+		}
+		inv[len(p)-1-i] = opInv
+	}
+	return inv, true
+}
+
+type serializedOp struct {
+	Op     string          `json:"op"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// MarshalJSON encodes the pipeline as an array of named operations. Nil operations are an error.
+func (p Pipeline) MarshalJSON() ([]byte, error) {
+	ops := make([]serializedOp, len(p))
+	for i, op := range p {
+		if op == nil {
+			return nil, errNilOp
+		}
+		params, err := json.Marshal(op)
+		if err != nil {
+			return nil, err
+		}
+		ops[i] = serializedOp{Op: op.Name(), Params: params}
+	}
+	return json.Marshal(ops)
+}
+
+// UnmarshalJSON decodes a pipeline encoded by [Pipeline.MarshalJSON]. Operations are decoded by
+// the built-in decoders and those passed to [RegisterOp].
+func (p *Pipeline) UnmarshalJSON(data []byte) error {
+	var ops []serializedOp
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return err
+	}
+	decoded := make(Pipeline, len(ops))
+	for i, s := range ops {
+		if s.Op == "" {
+			return errUnmarshalOp
+		}
+		decode, ok := opRegistry[s.Op]
+		if !ok {
+			return errUnknownOp
+		}
+		params := []byte(s.Params)
+		if len(params) == 0 {
+			params = []byte("{}")
+		}
+		op, err := decode(params)
+		if err != nil {
+			return err
+		}
+		decoded[i] = op
+	}
+	*p = decoded
+	return nil
+}
+
+// ExposureOp scales linear light by 2^Stops.
+type ExposureOp struct {
+	Stops float32 `json:"stops"`
+}
+
+// Name returns "exposure".
+func (op ExposureOp) Name() string { return "exposure" }
+
+// Apply changes the exposure of c. Results brighter than white are clipped.
+func (op ExposureOp) Apply(c SRGB) SRGB {
+	lin := c.LSRGB()
+	s := math32.Exp2(op.Stops)
+	return LSRGB{R: lin.R * s, G: lin.G * s, B: lin.B * s}.ClipToGamut().SRGB()
+}
+
+// Invert returns the opposite exposure change.
+func (op ExposureOp) Invert() (Op, bool) { return ExposureOp{Stops: -op.Stops}, true }
+
+// HueRotateOp rotates the [OKLCH] hue by Degrees, keeping lightness and chroma.
+type HueRotateOp struct {
+	Degrees float32 `json:"degrees"`
+}
+
+// Name returns "hueRotate".
+func (op HueRotateOp) Name() string { return "hueRotate" }
+
+// Apply rotates the hue of c. The result is gamut mapped by reducing chroma.
+func (op HueRotateOp) Apply(c SRGB) SRGB {
+	lch := colorToOKLCH(c)
+	lch.H = normalizeHue(lch.H + op.Degrees)
+	return oklchToSRGB(lch)
+}
+
+// Invert returns the opposite rotation.
+func (op HueRotateOp) Invert() (Op, bool) { return HueRotateOp{Degrees: -op.Degrees}, true }
+
+// ChromaScaleOp multiplies the [OKLCH] chroma by Scale, keeping lightness and hue.
+type ChromaScaleOp struct {
+	Scale float32 `json:"scale"`
+}
+
+// Name returns "chromaScale".
+func (op ChromaScaleOp) Name() string { return "chromaScale" }
+
+// Apply scales the chroma of c. The result is gamut mapped by reducing chroma.
+func (op ChromaScaleOp) Apply(c SRGB) SRGB {
+	lch := colorToOKLCH(c)
+	lch.C = math32.Max(lch.C*op.Scale, 0)
+	return oklchToSRGB(lch)
+}
+
+// Invert returns the reciprocal scale. A non-positive scale is not invertible.
+func (op ChromaScaleOp) Invert() (Op, bool) {
+	if op.Scale <= 0 {
+		return nil, false
+	}
+	return ChromaScaleOp{Scale: 1 / op.Scale}, true
+}
+
+// WhiteBalanceOp adapts colors seen under light of color temperature From to how they would be seen
+// under light of temperature To using the Bradford transform, i.e: From 3200 To 0 neutralizes
+// tungsten light as [Grade.Kelvin] does. Temperatures are in kelvin of a blackbody; zero stands for D65,
+// the neutral white of sRGB, which differs slightly from a 6504K blackbody.
+type WhiteBalanceOp struct {
+	From float32 `json:"from"`
+	To   float32 `json:"to"`
+}
+
+// Name returns "whiteBalance".
+func (op WhiteBalanceOp) Name() string { return "whiteBalance" }
+
+// Apply white balances c. Results outside the sRGB gamut are clipped.
+func (op WhiteBalanceOp) Apply(c SRGB) SRGB {
+	white := func(kelvin float32) ms3.Vec {
+		if kelvin <= 0 {
+			return d65
+		}
+		return IlluminantXYZ(BlackbodySpectrum(kelvin)).vec()
+	}
+	v := ms3.MulMatVec(bradfordMatrix(white(op.From), white(op.To)), c.LSRGB().CIEXYZ().vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}.LSRGB().ClipToGamut().SRGB()
+}
+
+// Invert returns the adaptation in the opposite direction.
+func (op WhiteBalanceOp) Invert() (Op, bool) { return WhiteBalanceOp{From: op.To, To: op.From}, true }
+
+// Name returns "grade".
+func (g Grade) Name() string { return "grade" }
+
+// Invert returns false: grades are not invertible in general.
+func (g Grade) Invert() (Op, bool) { return nil, false }
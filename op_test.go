@@ -0,0 +1,87 @@
+package colorspace
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestPipelineInvert(t *testing.T) {
+	p := Pipeline{
+		ExposureOp{Stops: -0.5},
+		WhiteBalanceOp{From: 4000},
+		HueRotateOp{Degrees: 20},
+		ChromaScaleOp{Scale: 0.8},
+	}
+	inv, ok := p.Invert()
+	if !ok {
+		t.Fatal("pipeline of invertible operations not invertible")
+	}
+	// Colors are unsaturated so no operation clips them.
+	for _, c := range []SRGB{{R: 0.6, G: 0.4, B: 0.3}, {R: 0.4, G: 0.5, B: 0.45}, {R: 0.5, G: 0.5, B: 0.5}} {
+		got := inv.Apply(p.Apply(c))
+		if d := colorToOKLAB(got).DeltaE(colorToOKLAB(c)); d > 2e-3 {
+			t.Errorf("undo of %v = %v, differs by %v", c, got, d)
+		}
+	}
+	if _, ok := append(p, Grade{Chroma: 0.1}).Invert(); ok {
+		t.Error("pipeline with a grade reported invertible")
+	}
+	if _, ok := (ChromaScaleOp{}).Invert(); ok {
+		t.Error("zero chroma scale reported invertible")
+	}
+}
+
+func TestPipelineJSON(t *testing.T) {
+	p := Pipeline{
+		ExposureOp{Stops: 1},
+		Pipeline{HueRotateOp{Degrees: -30}, ChromaScaleOp{Scale: 1.2}},
+		Grade{Shadows: SplitTone{Hue: 200, Amount: 0.5}},
+		WhiteBalanceOp{From: 3200},
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Pipeline
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("round trip of %v = %v", p, got)
+	}
+	for _, bad := range []string{`[{"op":"sharpen"}]`, `[{"params":{}}]`, `[{"op":"grade","params":{"toneCurve":[{"in":2,"out":0}]}}]`} {
+		if err := json.Unmarshal([]byte(bad), &got); err == nil {
+			t.Errorf("invalid pipeline %s accepted", bad)
+		}
+	}
+	if _, err := json.Marshal(Pipeline{ExposureOp{}, Pipeline{nil}}); err == nil {
+		t.Error("pipeline with nil operation marshalled")
+	}
+	if err := RegisterOp("exposure", nil); err == nil {
+		t.Error("duplicate registration accepted")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff})
+	out := ApplyOp(img, ExposureOp{Stops: 1})
+	if want := (ExposureOp{Stops: 1}).Apply(ColorToSRGB(img.At(0, 0))); colorToOKLAB(out.At(0, 0)).DeltaE(colorToOKLAB(want)) > 1e-3 {
+		t.Errorf("ApplyOp = %v, want %v", out.At(0, 0), want)
+	}
+	if _, _, _, a := out.At(1, 0).RGBA(); a != 0 {
+		t.Error("transparent pixel became opaque")
+	}
+}
+
+func TestWhiteBalanceOpMatchesGrade(t *testing.T) {
+	// Both white balance operations adapt to the same neutral.
+	for _, c := range []SRGB{{R: 0.6, G: 0.4, B: 0.3}, {R: 0.5, G: 0.5, B: 0.5}} {
+		op := WhiteBalanceOp{From: 3200}.Apply(c)
+		grade := Grade{Kelvin: 3200}.Apply(c)
+		if d := colorToOKLAB(op).DeltaE(colorToOKLAB(grade)); d > 2e-3 {
+			t.Errorf("white balance of %v: op %v, grade %v", c, op, grade)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package colorspace
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+var errOverlapStyles = errors.New("cannot keep all series overlaps distinguishable: use fewer series or a smaller difference")
+
+// OverlapStyles suggests the opacity and chroma of translucent series drawn in order over background,
+// as in dense scatterplots, so every pairwise overlap stays distinguishable. For each pair of series the
+// region where they overlap must differ by at least minDeltaE (OKLAB Euclidean distance, see [OKLAB.DeltaE])
+// from both series drawn alone, and each series alone must differ by minDeltaE from the background.
+// Compositing is done in linear light as with [Flatten].
+//
+// Series start at the given alpha and are adjusted one at a time, preferring the smallest change: the alpha
+// of a series is moved in steps of 0.05 within [0.15,0.9] and its OKLCH chroma boosted by up to 2x.
+// If the difference cannot be achieved the best found styles are returned with a non-nil error.
+func OverlapStyles(colors []color.Color, background color.Color, alpha, minDeltaE float32) ([]SRGBA, error) {
+	const (
+		minAlpha, maxAlpha, alphaStep = 0.15, 0.9, 0.05
+		sweeps                        = 8
+	)
+	chromaScales := [...]float32{1, 1.2, 1.4, 1.7, 2}
+	bg := ColorToSRGB(background)
+	base := make([]OKLCH, len(colors))
+	styles := make([]SRGBA, len(colors))
+	for i, c := range colors {
+		base[i] = colorToOKLCH(c)
+		styles[i] = SRGBA{SRGB: ColorToSRGB(c), Alpha: alpha}
+	}
+	var alphas []float32
+	for a := float32(minAlpha); a <= maxAlpha+1e-3; a += alphaStep {
+		alphas = append(alphas, a)
+	}
+	// score returns the capped worst margin and the deviation from the requested style.
+	score := func(chromaScale float32, s SRGBA) (float32, float32) {
+		return math32.Min(overlapMargin(styles, bg), minDeltaE), math32.Abs(s.Alpha-alpha) + 0.5*(chromaScale-1)
+	}
+	for sweep := 0; sweep < sweeps; sweep++ {
+		if overlapMargin(styles, bg) >= minDeltaE {
+			return styles, nil
+		}
+		for i := range styles {
+			best := styles[i]
+			bestMargin, bestDeviation := float32(-1), float32(0)
+			for _, k := range chromaScales {
+				lch := base[i]
+				lch.C *= k
+				c := oklchToSRGB(lch)
+				for _, a := range alphas {
+					styles[i] = SRGBA{SRGB: c, Alpha: a}
+					margin, deviation := score(k, styles[i])
+					if margin > bestMargin || (margin == bestMargin && deviation < bestDeviation) {
+						best, bestMargin, bestDeviation = styles[i], margin, deviation
+					}
+				}
+			}
+			styles[i] = best
+		}
+	}
+	if overlapMargin(styles, bg) < minDeltaE {
+		return styles, errOverlapStyles
+	}
+	return styles, nil
+}
+
+// overlapMargin returns the smallest OKLAB difference between each series drawn alone over bg
+// and bg, and between each pairwise overlap and the two series drawn alone.
+func overlapMargin(styles []SRGBA, bg SRGB) float32 {
+	alone := make([]OKLAB, len(styles))
+	bgLab := colorToOKLAB(bg)
+	margin := math32.Inf(1)
+	for i, s := range styles {
+		alone[i] = colorToOKLAB(flattenLinear(s.SRGB, s.Alpha, bg))
+		margin = math32.Min(margin, alone[i].DeltaE(bgLab))
+	}
+	for i, below := range styles {
+		under := flattenLinear(below.SRGB, below.Alpha, bg)
+		for j := i + 1; j < len(styles); j++ {
+			above := styles[j]
+			overlap := colorToOKLAB(flattenLinear(above.SRGB, above.Alpha, under))
+			margin = math32.Min(margin, math32.Min(overlap.DeltaE(alone[i]), overlap.DeltaE(alone[j])))
+		}
+	}
+	return margin
+}
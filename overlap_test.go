@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestOverlapStyles(t *testing.T) {
+	white := SRGB{R: 1, G: 1, B: 1}
+	colors := []color.Color{SRGB{R: 0.85, G: 0.2, B: 0.2}, SRGB{R: 0.2, G: 0.4, B: 0.85}, SRGB{R: 0.3, G: 0.7, B: 0.3}}
+	// Nearly opaque series hide the series below them.
+	if m := overlapMargin([]SRGBA{{SRGB: colors[0].(SRGB), Alpha: 0.98}, {SRGB: colors[1].(SRGB), Alpha: 0.98}}, white); m > 0.02 {
+		t.Fatalf("expected opaque overlap to be indistinguishable, margin %v", m)
+	}
+	const minDeltaE = 0.05
+	styles, err := OverlapStyles(colors, white, 0.98, minDeltaE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := overlapMargin(styles, white); m < minDeltaE {
+		t.Errorf("margin %v below %v for %+v", m, minDeltaE, styles)
+	}
+	for i, s := range styles {
+		if s.Alpha >= 0.98 {
+			t.Errorf("series %d alpha not reduced: %+v", i, s)
+		}
+	}
+	// Already distinguishable styles are left untouched.
+	styles, err = OverlapStyles(colors, white, 0.5, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range styles {
+		if s.Alpha != 0.5 || sqdist(s.vec(), colors[i].(SRGB).vec()) > 1e-8 {
+			t.Errorf("series %d changed to %+v", i, s)
+		}
+	}
+	gray := SRGB{R: 0.5, G: 0.5, B: 0.5}
+	if _, err := OverlapStyles([]color.Color{gray, gray}, gray, 0.5, 0.2); err == nil {
+		t.Error("expected error for series matching the background")
+	}
+}
@@ -0,0 +1,96 @@
+package colorspace
+
+import (
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	// Linear Display P3 to D65 XYZ as defined by CSS Color 4.
+	linP3ToXYZ = ms3.NewMat3([]float32{
+		608311. / 1250200, 189793. / 714400, 198249. / 1000160,
+		35783. / 156275, 247089. / 357200, 198249. / 2500400,
+		0, 32229. / 714400, 5220557. / 5000800,
+	})
+	xyzToLinP3 = linP3ToXYZ.Inverse()
+)
+
+// DisplayP3 is the wide gamut RGB color space of modern Apple displays and CSS color(display-p3 ...).
+// It uses the DCI-P3 primaries with a D65 white point and the sRGB transfer function.
+// Its gamut is about 25% larger than sRGB's, mostly in saturated greens and reds.
+type DisplayP3 struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+// LinearP3 is the linear-light (un-companded) representation of [DisplayP3].
+type LinearP3 struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+func (c DisplayP3) vec() ms3.Vec      { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c LinearP3) vec() ms3.Vec       { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c DisplayP3) Array() [3]float32 { return c.vec().Array() }
+func (c LinearP3) Array() [3]float32  { return c.vec().Array() }
+
+// LinearP3 decodes the gamma-encoded Display P3 color into linear light.
+func (c DisplayP3) LinearP3() LinearP3 {
+	return LinearP3{R: transferFunc(c.R), G: transferFunc(c.G), B: transferFunc(c.B)}
+}
+
+// DisplayP3 encodes the linear-light color with the sRGB transfer function.
+func (c LinearP3) DisplayP3() DisplayP3 {
+	return DisplayP3{R: invTransferFunc(c.R), G: invTransferFunc(c.G), B: invTransferFunc(c.B)}
+}
+
+// CIEXYZ converts the linear P3 color to CIE XYZ relative to D65.
+func (c LinearP3) CIEXYZ() CIEXYZ {
+	v := ms3.MulMatVec(linP3ToXYZ, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// LinearP3 converts D65 relative XYZ to linear P3. The result may be out of gamut.
+func (c CIEXYZ) LinearP3() LinearP3 {
+	v := ms3.MulMatVec(xyzToLinP3, c.vec())
+	return LinearP3{R: v.X, G: v.Y, B: v.Z}
+}
+
+// DisplayP3 converts D65 relative XYZ to Display P3. The result may be out of gamut.
+func (c CIEXYZ) DisplayP3() DisplayP3 { return c.LinearP3().DisplayP3() }
+
+// CIEXYZ converts the Display P3 color to CIE XYZ relative to D65.
+func (c DisplayP3) CIEXYZ() CIEXYZ { return c.LinearP3().CIEXYZ() }
+
+// DisplayP3 converts the sRGB color to Display P3. Every sRGB color is inside the P3 gamut.
+func (c SRGB) DisplayP3() DisplayP3 { return c.LSRGB().CIEXYZ().DisplayP3() }
+
+// SRGB converts the Display P3 color to sRGB. Colors outside the sRGB gamut have channels outside [0,1];
+// use [SRGB.ClipToGamut] or gamut map in [OKLCH] with [DisplayP3.SRGBMapped].
+func (c DisplayP3) SRGB() SRGB { return c.CIEXYZ().LSRGB().SRGB() }
+
+// SRGBMapped converts the Display P3 color to sRGB gamut mapping in [OKLCH] if necessary,
+// the same way CSS renders P3 colors on sRGB displays.
+func (c DisplayP3) SRGBMapped() SRGB { return xyzToSRGBMapped(c.CIEXYZ()) }
+
+// InGamut reports whether the linear-light color lies inside the P3 gamut.
+func (c LinearP3) InGamut() bool {
+	return c.R <= 1 && c.G <= 1 && c.B <= 1 && c.R >= 0 && c.G >= 0 && c.B >= 0
+}
+
+// InGamut reports whether the gamma-encoded color lies inside the P3 gamut.
+func (c DisplayP3) InGamut() bool {
+	return c.R <= 1 && c.G <= 1 && c.B <= 1 && c.R >= 0 && c.G >= 0 && c.B >= 0
+}
+
+// ClipToGamut clamps each channel of the linear-light P3 color to [0,1].
+func (c LinearP3) ClipToGamut() LinearP3 {
+	return LinearP3{R: ms1.Clamp(c.R, 0, 1), G: ms1.Clamp(c.G, 0, 1), B: ms1.Clamp(c.B, 0, 1)}
+}
+
+// ClipToGamut clamps each channel of the gamma-encoded P3 color to [0,1].
+func (c DisplayP3) ClipToGamut() DisplayP3 {
+	return DisplayP3{R: ms1.Clamp(c.R, 0, 1), G: ms1.Clamp(c.G, 0, 1), B: ms1.Clamp(c.B, 0, 1)}
+}
@@ -0,0 +1,28 @@
+package colorspace
+
+import "testing"
+
+func TestDisplayP3(t *testing.T) {
+	// sRGB red in Display P3 as computed by CSS Color 4.
+	red := SRGB{R: 1}.DisplayP3()
+	if want := (DisplayP3{R: 0.9175, G: 0.2003, B: 0.1386}); sqdist(red.vec(), want.vec()) > 1e-6 {
+		t.Errorf("sRGB red in P3 = %+v, want %+v", red, want)
+	}
+	if !red.InGamut() {
+		t.Error("sRGB red should be inside the P3 gamut")
+	}
+	white := DisplayP3{R: 1, G: 1, B: 1}.SRGB()
+	if sqdist(white.vec(), SRGB{R: 1, G: 1, B: 1}.vec()) > 1e-8 {
+		t.Errorf("P3 white is not sRGB white: %+v", white)
+	}
+	green := DisplayP3{G: 1}
+	if green.SRGB().InGamut() {
+		t.Error("P3 green should be outside the sRGB gamut")
+	}
+	if mapped := green.SRGBMapped(); !mapped.InGamut() || mapped.G < 0.9 {
+		t.Errorf("unexpected gamut mapped P3 green %+v", mapped)
+	}
+	if got := (LinearP3{R: 1.5, G: -0.2, B: 0.5}).ClipToGamut(); got != (LinearP3{R: 1, B: 0.5}) {
+		t.Errorf("unexpected clipped color %+v", got)
+	}
+}
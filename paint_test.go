@@ -0,0 +1,30 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestLerpPaint(t *testing.T) {
+	blue := color.RGBA{B: 255, A: 255}
+	yellow := color.RGBA{R: 255, G: 255, A: 255}
+	for _, v := range []float32{0, 1} {
+		want := ColorToSRGB(blue)
+		if v == 1 {
+			want = ColorToSRGB(yellow)
+		}
+		got := ColorToSRGB(LerpPaint(blue, yellow, v))
+		if sqdist(got.vec(), want.vec()) > 1e-6 {
+			t.Errorf("v=%v: got %+v, want %+v", v, got, want)
+		}
+	}
+	mid := ColorToSRGB(LerpPaint(blue, yellow, 0.5))
+	hue := mid.LSRGB().CIEXYZ().OKLAB().OKLCH().H
+	if mid.G <= mid.R || mid.G <= mid.B || hue < 120 || hue > 200 {
+		t.Errorf("blue and yellow paint should mix to green, got %+v (hue %v)", mid, hue)
+	}
+	grey := ColorToSRGB(LerpPaint(color.White, color.Black, 0.5))
+	if grey.R <= 0 || grey.R >= 0.5 {
+		t.Errorf("white and black paint should mix to a dark grey, got %+v", grey)
+	}
+}
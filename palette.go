@@ -0,0 +1,139 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+)
+
+// DistanceMetric selects the color difference formula used to find nearest colors.
+type DistanceMetric uint8
+
+const (
+	// DistanceOKLAB is the Euclidean distance in [OKLAB]. Fast and perceptually uniform.
+	DistanceOKLAB DistanceMetric = iota
+	// DistanceCIEDE2000 is the CIEDE2000 difference in [CIELAB]. See [CIELAB.DeltaE2000].
+	DistanceCIEDE2000
+)
+
+// maxPaletteCache is the number of cached color lookups after which a PerceptualPalette's cache is reset.
+const maxPaletteCache = 1 << 16
+
+// PerceptualPalette is a palette of colors whose Convert and Index methods
+// find the nearest color using a perceptual color difference instead of the squared
+// RGB distance used by [color.Palette]. Results are cached, which greatly speeds up
+// quantizing images with few unique colors. Opacity/alpha is ignored when matching.
+//
+// PerceptualPalette implements [draw.Drawer] and [draw.Quantizer] so it can be used
+// as the Drawer and Quantizer fields of [gif.Options]. It is safe for concurrent use.
+type PerceptualPalette struct {
+	palette color.Palette
+	metric  DistanceMetric
+	oklab   []OKLAB
+	lab     []CIELAB
+	mu      sync.Mutex
+	cache   map[uint64]int
+}
+
+// NewPerceptualPalette returns a PerceptualPalette for p using the given distance metric.
+// p must not be modified after the call.
+func NewPerceptualPalette(p color.Palette, metric DistanceMetric) *PerceptualPalette {
+	if metric > DistanceCIEDE2000 {
+		panic("invalid distance metric")
+	}
+	pp := &PerceptualPalette{
+		palette: p,
+		metric:  metric,
+		cache:   make(map[uint64]int),
+	}
+	for _, c := range p {
+		switch metric {
+		case DistanceOKLAB:
+			pp.oklab = append(pp.oklab, ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB())
+		case DistanceCIEDE2000:
+			pp.lab = append(pp.lab, colorToCIELAB(c))
+		}
+	}
+	return pp
+}
+
+// Palette returns the underlying palette.
+func (pp *PerceptualPalette) Palette() color.Palette { return pp.palette }
+
+// Convert returns the palette color perceptually closest to c.
+func (pp *PerceptualPalette) Convert(c color.Color) color.Color {
+	if len(pp.palette) == 0 {
+		return nil
+	}
+	return pp.palette[pp.Index(c)]
+}
+
+// Index returns the index of the palette color perceptually closest to c.
+func (pp *PerceptualPalette) Index(c color.Color) int {
+	r, g, b, _ := c.RGBA()
+	key := uint64(r)<<32 | uint64(g)<<16 | uint64(b)
+	pp.mu.Lock()
+	idx, ok := pp.cache[key]
+	pp.mu.Unlock()
+	if ok {
+		return idx
+	}
+	idx = pp.nearest(c)
+	pp.mu.Lock()
+	if len(pp.cache) >= maxPaletteCache {
+		pp.cache = make(map[uint64]int)
+	}
+	pp.cache[key] = idx
+	pp.mu.Unlock()
+	return idx
+}
+
+func (pp *PerceptualPalette) nearest(c color.Color) int {
+	ret := 0
+	bestDist := float32(-1)
+	switch pp.metric {
+	case DistanceOKLAB:
+		target := ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB()
+		for i, ref := range pp.oklab {
+			if dist := target.DeltaE(ref); bestDist < 0 || dist < bestDist {
+				ret, bestDist = i, dist
+			}
+		}
+	case DistanceCIEDE2000:
+		target := colorToCIELAB(c)
+		for i, ref := range pp.lab {
+			if dist := target.DeltaE2000(ref); bestDist < 0 || dist < bestDist {
+				ret, bestDist = i, dist
+			}
+		}
+	}
+	return ret
+}
+
+// Quantize implements [draw.Quantizer] by appending the palette colors to p.
+// The image is not inspected.
+func (pp *PerceptualPalette) Quantize(p color.Palette, m image.Image) color.Palette {
+	return append(p, pp.palette...)
+}
+
+// Draw implements [draw.Drawer] by setting each pixel of dst in r to the
+// palette color perceptually closest to the corresponding pixel of src. No dithering is performed.
+// When dst is a [*image.Paletted] its palette must be the PerceptualPalette's palette.
+func (pp *PerceptualPalette) Draw(dst draw.Image, r image.Rectangle, src image.Image, sp image.Point) {
+	clipped := r.Intersect(dst.Bounds())
+	// Shift the source point by the amount the rectangle was clipped as image/draw does.
+	sp = sp.Add(clipped.Min.Sub(r.Min))
+	r = clipped
+	paletted, isPaletted := dst.(*image.Paletted)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := src.At(sp.X+x-r.Min.X, sp.Y+y-r.Min.Y)
+			if isPaletted {
+				paletted.SetColorIndex(x, y, uint8(pp.Index(c)))
+			} else {
+				dst.Set(x, y, pp.Convert(c))
+			}
+		}
+	}
+}
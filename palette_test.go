@@ -0,0 +1,38 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestPerceptualPalette(t *testing.T) {
+	for _, metric := range []DistanceMetric{DistanceOKLAB, DistanceCIEDE2000} {
+		pp := NewPerceptualPalette(jet, metric)
+		for i, c := range jet {
+			if got := pp.Index(c); got != i {
+				t.Errorf("metric %d: palette color %d matched index %d", metric, i, got)
+			}
+		}
+	}
+}
+
+func TestPerceptualPaletteDrawClipped(t *testing.T) {
+	palette := color.Palette{color.RGBA{A: 255}, color.RGBA{R: 255, A: 255}, color.RGBA{G: 255, A: 255}, color.RGBA{B: 255, A: 255}}
+	pp := NewPerceptualPalette(palette, DistanceOKLAB)
+	// Each source column has a distinct palette color.
+	src := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		src.Set(x, 0, palette[x])
+	}
+	for _, dst := range []draw.Image{image.NewPaletted(image.Rect(0, 0, 3, 1), palette), image.NewRGBA(image.Rect(0, 0, 3, 1))} {
+		// r starts left of dst so its first column is clipped away.
+		pp.Draw(dst, image.Rect(-1, 0, 3, 1), src, image.Point{})
+		for x := 0; x < 3; x++ {
+			if got, want := color.RGBAModel.Convert(dst.At(x, 0)), color.RGBAModel.Convert(palette[x+1]); got != want {
+				t.Errorf("%T pixel %d = %v, want %v", dst, x, got, want)
+			}
+		}
+	}
+}
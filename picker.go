@@ -0,0 +1,81 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// InGamut reports whether the OKLCH color lies inside the sRGB gamut up to rounding error.
+func (c OKLCH) InGamut() bool {
+	return c.L >= 0 && c.L <= 1 && c.C >= 0 && gamutExcess(c.OKLAB().CIEXYZ().LSRGB()) <= epsUnit
+}
+
+// MaxChroma returns the largest chroma of an sRGB color with the lightness and hue of c, so the
+// valid chroma range for the current lightness and hue of a color picker is [0, MaxChroma].
+// It is zero for lightness outside (0,1). Chroma of c is ignored.
+//
+// Near the blue primary sRGB colors of constant OKLCH hue are not contiguous in chroma:
+// a few more saturated colors are separated from the range by a sliver out of gamut.
+// They are excluded so all chromas up to the result are valid.
+func (c OKLCH) MaxChroma() float32 {
+	const (
+		maxOKLCHChroma = 0.4 // Larger than the chroma of any sRGB color.
+		step           = 0.005
+	)
+	if !(c.L > 0 && c.L < 1) {
+		return 0
+	}
+	inGamut := func(chroma float32) bool { return OKLCH{L: c.L, C: chroma, H: c.H}.InGamut() }
+	// Step out from neutral to bracket the first gamut crossing, then bisect.
+	lo := float32(0)
+	for lo+step < maxOKLCHChroma && inGamut(lo+step) {
+		lo += step
+	}
+	hi := lo + step
+	for i := 0; i < 20; i++ {
+		mid := 0.5 * (lo + hi)
+		if inGamut(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// SnapToGamut returns the sRGB color nearest to c in the plane of constant hue, i.e: to constrain
+// arbitrary input of a color picker. Unlike [OKLCH.GamutMappedLSRGB], which keeps lightness,
+// both lightness and chroma may change. Colors in gamut are returned unchanged and negative
+// chroma is treated as zero.
+func (c OKLCH) SnapToGamut() OKLCH {
+	c.C = math32.Max(c.C, 0)
+	if c.InGamut() {
+		return c
+	} else if c.C == 0 {
+		c.L = clamp01(c.L)
+		return c
+	}
+	// The slice of the sRGB gamut at constant hue is about convex, so the squared distance to its
+	// boundary has a single minimum which is bracketed by sampling and refined by golden section search.
+	boundary := func(l float32) OKLCH { return OKLCH{L: l, C: OKLCH{L: l, H: c.H}.MaxChroma(), H: c.H} }
+	dist := func(l float32) float32 {
+		b := boundary(l)
+		return sq(b.L-c.L) + sq(b.C-c.C)
+	}
+	const samples = 64
+	best, bestDist := float32(0), float32(-1)
+	for i := 0; i <= samples; i++ {
+		l := float32(i) / samples
+		if d := dist(l); bestDist < 0 || d < bestDist {
+			best, bestDist = l, d
+		}
+	}
+	const invPhi = 0.6180339887
+	lo, hi := math32.Max(best-1./samples, 0), math32.Min(best+1./samples, 1)
+	for i := 0; i < 24; i++ {
+		m1, m2 := hi-invPhi*(hi-lo), lo+invPhi*(hi-lo)
+		if dist(m1) < dist(m2) {
+			hi = m2
+		} else {
+			lo = m1
+		}
+	}
+	return boundary(0.5 * (lo + hi))
+}
@@ -0,0 +1,73 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestOKLCHMaxChroma(t *testing.T) {
+	// Chroma of the sRGB primaries and secondaries is the largest at their lightness and hue.
+	// Blue is separated from less saturated colors of its hue by a sliver out of gamut.
+	for _, p := range []SRGB{{R: 1}, {G: 1}, {R: 1, G: 1}, {G: 1, B: 1}, {R: 1, B: 1}} {
+		lch := colorToOKLCH(p)
+		if got := lch.MaxChroma(); math32.Abs(got-lch.C) > 1e-3 {
+			t.Errorf("MaxChroma at %v = %v, want %v", lch, got, lch.C)
+		}
+	}
+	for _, l := range []float32{-0.1, 0, 1, 1.2} {
+		if got := (OKLCH{L: l, H: 30}).MaxChroma(); got != 0 {
+			t.Errorf("MaxChroma at L=%v = %v, want 0", l, got)
+		}
+	}
+	for _, c := range []OKLCH{{L: 0.6, H: 140}, colorToOKLCH(SRGB{B: 1})} {
+		max := c.MaxChroma()
+		for c.C = 0; c.C <= max; c.C += max / 50 {
+			if !c.InGamut() {
+				t.Errorf("%v below MaxChroma %v out of gamut", c, max)
+			}
+		}
+		c.C = max + 1e-3
+		if c.InGamut() {
+			t.Errorf("%v beyond MaxChroma in gamut", c)
+		}
+	}
+}
+
+func TestOKLCHSnapToGamut(t *testing.T) {
+	inGamut := OKLCH{L: 0.5, C: 0.05, H: 200}
+	if got := inGamut.SnapToGamut(); got != inGamut {
+		t.Errorf("in gamut %v snapped to %v", inGamut, got)
+	}
+	if got := (OKLCH{L: 1.3, H: 10}).SnapToGamut(); got.L != 1 || got.C != 0 {
+		t.Errorf("over-white snapped to %v, want white", got)
+	}
+	for _, c := range []OKLCH{
+		{L: 0.9, C: 0.3, H: 264},  // Blue is only saturated when dark.
+		{L: 0.3, C: 0.3, H: 110},  // Yellow is only saturated when light.
+		{L: 0.7, C: 0.5, H: 30},   // Beyond any sRGB chroma.
+		{L: -0.2, C: 0.1, H: 150}, // Below black.
+	} {
+		got := c.SnapToGamut()
+		if !got.InGamut() {
+			t.Errorf("snap of %v = %v out of gamut", c, got)
+		}
+		if got.H != c.H {
+			t.Errorf("snap of %v changed hue to %v", c, got.H)
+		}
+		// No boundary point of the hue slice is closer than the snapped color.
+		d := math32.Hypot(got.L-c.L, got.C-c.C)
+		for l := float32(0.01); l < 1; l += 0.01 {
+			b := OKLCH{L: l, H: c.H}
+			b.C = b.MaxChroma()
+			if db := math32.Hypot(b.L-c.L, b.C-c.C); db < d-1e-4 {
+				t.Errorf("snap of %v = %v at distance %v, but %v is at %v", c, got, d, b, db)
+				break
+			}
+		}
+		// Gamut mapping at constant lightness is never closer than snapping.
+		if mapped := c.GamutMappedLSRGB(); c.L > 0 && c.L < 1 && math32.Hypot(mapped.L-c.L, mapped.C-c.C) < d-1e-3 {
+			t.Errorf("GamutMappedLSRGB of %v = %v is closer than snap %v", c, mapped, got)
+		}
+	}
+}
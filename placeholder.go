@@ -0,0 +1,142 @@
+package colorspace
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+var errPlaceholderLength = errors.New("placeholder data must be 7 bytes long")
+
+// Placeholder is a tiny stand-in for an image shown by progressive loading UIs while the image downloads:
+// a two color gradient between the average colors of two halves of the image. Averages are computed
+// in linear light so they match what a heavily blurred image looks like, and the gradient is interpolated
+// in [OKLAB]. It encodes in 7 bytes. See [ImagePlaceholder].
+type Placeholder struct {
+	// Start and End are the average colors of the left and right halves, or top and bottom halves if Vertical.
+	Start, End SRGB
+	// Vertical is true if the gradient runs from top to bottom and false if it runs from left to right.
+	Vertical bool
+}
+
+// ImagePlaceholder returns the placeholder of img. The image is split in halves along the direction
+// in which the average colors of the halves differ the most. Pixels are weighted by their opacity.
+// Images a single pixel wide or tall are split along their long side and if neither direction has
+// pixels in both halves the average color of the image is used for both ends.
+func ImagePlaceholder(img image.Image) Placeholder {
+	bounds := img.Bounds()
+	midX, midY := (bounds.Min.X+bounds.Max.X)/2, (bounds.Min.Y+bounds.Max.Y)/2
+	// Premultiplied linear light sums of the left, right, top and bottom halves.
+	var sums [4]LinearRGBA
+	add := func(i int, c LSRGB, a float32) {
+		sums[i].R += c.R * a
+		sums[i].G += c.G * a
+		sums[i].B += c.B * a
+		sums[i].A += a
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			lin := c.LSRGB()
+			if x < midX {
+				add(0, lin, a)
+			} else {
+				add(1, lin, a)
+			}
+			if y < midY {
+				add(2, lin, a)
+			} else {
+				add(3, lin, a)
+			}
+		}
+	}
+	var avg [4]SRGB
+	for i, s := range sums {
+		c, _ := s.Unpremultiply()
+		avg[i] = c.ClipToGamut().SRGB()
+	}
+	// A direction is only usable if both of its halves hold pixels, which is not the case along
+	// the single pixel side of narrow images.
+	horizontal := Placeholder{Start: avg[0], End: avg[1]}
+	vertical := Placeholder{Start: avg[2], End: avg[3], Vertical: true}
+	hasH, hasV := sums[0].A > 0 && sums[1].A > 0, sums[2].A > 0 && sums[3].A > 0
+	switch {
+	case hasH && hasV && vertical.contrast() > horizontal.contrast(), hasV && !hasH:
+		return vertical
+	case hasH:
+		return horizontal
+	}
+	total := sums[0]
+	total.R += sums[1].R
+	total.G += sums[1].G
+	total.B += sums[1].B
+	total.A += sums[1].A
+	c, _ := total.Unpremultiply()
+	mean := c.ClipToGamut().SRGB()
+	return Placeholder{Start: mean, End: mean}
+}
+
+// contrast returns the OKLAB distance between the placeholder colors.
+func (p Placeholder) contrast() float32 {
+	return colorToOKLAB(p.Start).DeltaE(colorToOKLAB(p.End))
+}
+
+// Gradient returns the gradient of the placeholder along its direction.
+func (p Placeholder) Gradient() Gradient {
+	return NewGradient(LerpOKLAB, p.Start, p.End)
+}
+
+// Image renders the placeholder over the rectangle r, i.e: the bounds of the image it stands for.
+// The gradient runs from the center of the first half to the center of the second half.
+func (p Placeholder) Image(r image.Rectangle) *image.RGBA64 {
+	dst := image.NewRGBA64(r)
+	g := p.Gradient()
+	n := r.Dx()
+	if p.Vertical {
+		n = r.Dy()
+	}
+	row := make([]color.RGBA64, n)
+	for i := range row {
+		t := 2*(float32(i)+0.5)/float32(n) - 0.5
+		row[i] = color.RGBA64Model.Convert(g.At(t)).(color.RGBA64)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			i := x - r.Min.X
+			if p.Vertical {
+				i = y - r.Min.Y
+			}
+			dst.SetRGBA64(x, y, row[i])
+		}
+	}
+	return dst
+}
+
+// MarshalBinary encodes the placeholder in 7 bytes: the 8-bit sRGB start and end colors and the direction.
+func (p Placeholder) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 7)
+	for _, c := range [2]SRGB{p.Start, p.End} {
+		c = c.ClipToGamut()
+		b = append(b, byte(c.R*255+0.5), byte(c.G*255+0.5), byte(c.B*255+0.5))
+	}
+	var vertical byte
+	if p.Vertical {
+		vertical = 1
+	}
+	return append(b, vertical), nil
+}
+
+// UnmarshalBinary decodes a placeholder encoded by [Placeholder.MarshalBinary].
+func (p *Placeholder) UnmarshalBinary(data []byte) error {
+	if len(data) != 7 {
+		return errPlaceholderLength
+	}
+	decode := func(b []byte) SRGB {
+		return SRGB{R: float32(b[0]) / 255, G: float32(b[1]) / 255, B: float32(b[2]) / 255}
+	}
+	p.Start, p.End, p.Vertical = decode(data[0:3]), decode(data[3:6]), data[6] != 0
+	return nil
+}
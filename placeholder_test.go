@@ -0,0 +1,75 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestImagePlaceholder(t *testing.T) {
+	// Top half red and bottom half alternating black and white pixels.
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if y >= 4 {
+				c = color.RGBA{A: 255}
+				if (x+y)%2 == 0 {
+					c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+				}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	p := ImagePlaceholder(img)
+	if !p.Vertical {
+		t.Fatalf("expected vertical placeholder, got %+v", p)
+	}
+	if sqdist(p.Start.vec(), SRGB{R: 1}.vec()) > 1e-6 {
+		t.Errorf("top average %+v, want red", p.Start)
+	}
+	// Gamma-correct average of black and white is linear 0.5, not sRGB 0.5.
+	want := LSRGB{R: 0.5, G: 0.5, B: 0.5}.SRGB()
+	if sqdist(p.End.vec(), want.vec()) > 1e-6 {
+		t.Errorf("bottom average %+v, want %+v", p.End, want)
+	}
+	data, _ := p.MarshalBinary()
+	var decoded Placeholder
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Vertical || sqdist(decoded.End.vec(), p.End.vec()) > 1e-4 {
+		t.Errorf("decoded %+v, want %+v", decoded, p)
+	}
+	if err := decoded.UnmarshalBinary(data[:6]); err == nil {
+		t.Error("expected length error")
+	}
+	rendered := p.Image(image.Rect(0, 0, 4, 16))
+	top, bottom := ColorToSRGB(rendered.At(2, 0)), ColorToSRGB(rendered.At(2, 15))
+	if sqdist(top.vec(), p.Start.vec()) > 1e-4 || sqdist(bottom.vec(), p.End.vec()) > 1e-4 {
+		t.Errorf("rendered ends %+v, %+v", top, bottom)
+	}
+	if left, right := rendered.At(0, 8), rendered.At(3, 8); left != right {
+		t.Errorf("vertical placeholder varies horizontally: %v, %v", left, right)
+	}
+}
+
+func TestImagePlaceholderNarrow(t *testing.T) {
+	gray := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	want := ColorToSRGB(gray)
+	for _, r := range []image.Rectangle{image.Rect(0, 0, 1, 4), image.Rect(0, 0, 4, 1), image.Rect(3, 5, 4, 6)} {
+		img := image.NewRGBA(r)
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				img.SetRGBA(x, y, gray)
+			}
+		}
+		p := ImagePlaceholder(img)
+		if sqdist(p.Start.vec(), want.vec()) > 1e-6 || sqdist(p.End.vec(), want.vec()) > 1e-6 {
+			t.Errorf("%v uniform gray image placeholder = %+v, want gray at both ends", r, p)
+		}
+		if r.Dx() == 1 && r.Dy() > 1 && !p.Vertical {
+			t.Errorf("%v image placeholder should be vertical", r)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+)
+
+// Plane is a single channel of an image stored as float32 values, i.e: the OKLCH chroma of every pixel.
+// It allows running custom per-channel algorithms using the package's color conversions.
+type Plane struct {
+	// Pix holds the channel values in row-major order.
+	Pix []float32
+	// Stride is the Pix stride between vertically adjacent pixels.
+	Stride int
+	Rect   image.Rectangle
+}
+
+// NewPlane returns a zeroed plane with the given bounds.
+func NewPlane(r image.Rectangle) *Plane {
+	return &Plane{Pix: make([]float32, r.Dx()*r.Dy()), Stride: r.Dx(), Rect: r}
+}
+
+// Bounds returns the domain of the plane.
+func (p *Plane) Bounds() image.Rectangle { return p.Rect }
+
+// PixOffset returns the index of the element of Pix that corresponds to the pixel at (x, y).
+func (p *Plane) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x - p.Rect.Min.X)
+}
+
+// Value returns the channel value at (x, y). Points outside the bounds return zero.
+func (p *Plane) Value(x, y int) float32 {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return 0
+	}
+	return p.Pix[p.PixOffset(x, y)]
+}
+
+// SetValue sets the channel value at (x, y). Points outside the bounds are ignored.
+func (p *Plane) SetValue(x, y int, v float32) {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return
+	}
+	p.Pix[p.PixOffset(x, y)] = v
+}
+
+// SplitOKLCH returns the lightness, chroma and hue planes of img in OKLCH. Alpha is discarded.
+func SplitOKLCH(img image.Image) (l, c, h *Plane) {
+	return splitPlanes(img, func(c SRGB) [3]float32 {
+		return c.LSRGB().CIEXYZ().OKLAB().OKLCH().Array()
+	})
+}
+
+// ChromaWeightMap returns a per-pixel weight in [0,1] which is 1 for pixels with OKLCH chroma below
+// low and falls smoothly to 0 for chroma above high. Multiplying the strength of sharpening by the map
+// avoids color fringing around saturated edges; the complement 1-w modulates chroma denoising.
+// Typical values are low=0.05 and high=0.15.
+func ChromaWeightMap(img image.Image, low, high float32) *Plane {
+	_, chroma, _ := SplitOKLCH(img)
+	for i, c := range chroma.Pix {
+		chroma.Pix[i] = 1 - smoothstep(low, high, c)
+	}
+	return chroma
+}
+
+func smoothstep(edge0, edge1, x float32) float32 {
+	if edge1 <= edge0 {
+		if x < edge0 {
+			return 0
+		}
+		return 1
+	}
+	t := clamp01((x - edge0) / (edge1 - edge0))
+	return t * t * (3 - 2*t)
+}
+
+func splitPlanes(img image.Image, fn func(SRGB) [3]float32) (p0, p1, p2 *Plane) {
+	bounds := img.Bounds()
+	planes := [3]*Plane{NewPlane(bounds), NewPlane(bounds), NewPlane(bounds)}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, _ := unpremultiplied(img.At(x, y))
+			i := planes[0].PixOffset(x, y)
+			for k, v := range fn(c) {
+				planes[k].Pix[i] = v
+			}
+		}
+	}
+	return planes[0], planes[1], planes[2]
+}
+
+// SplitOKLAB returns the L, a and b planes of img in OKLab. Alpha is discarded.
+func SplitOKLAB(img image.Image) (l, a, b *Plane) {
+	return splitPlanes(img, func(c SRGB) [3]float32 {
+		return c.LSRGB().CIEXYZ().OKLAB().Array()
+	})
+}
+
+// SplitHSV returns the hue, saturation and value planes of img. Alpha is discarded.
+func SplitHSV(img image.Image) (h, s, v *Plane) {
+	return splitPlanes(img, func(c SRGB) [3]float32 { return c.HSV().Array() })
+}
+
+// SplitHSL returns the hue, saturation and lightness planes of img. Alpha is discarded.
+func SplitHSL(img image.Image) (h, s, l *Plane) {
+	return splitPlanes(img, func(c SRGB) [3]float32 { return c.HSL().Array() })
+}
+
+// CombineOKLAB is the inverse of [SplitOKLAB] and returns an opaque image. Out of gamut colors are gamut mapped.
+// It panics if the planes' bounds differ.
+func CombineOKLAB(l, a, b *Plane) *image.RGBA64 {
+	return combinePlanes(l, a, b, func(v0, v1, v2 float32) SRGB {
+		return oklchToSRGB(OKLAB{L: v0, A: v1, B: v2}.OKLCH())
+	})
+}
+
+// CombineOKLCH is the inverse of [SplitOKLCH] and returns an opaque image. Out of gamut colors are gamut mapped.
+// It panics if the planes' bounds differ.
+func CombineOKLCH(l, c, h *Plane) *image.RGBA64 {
+	return combinePlanes(l, c, h, func(v0, v1, v2 float32) SRGB {
+		return oklchToSRGB(OKLCH{L: v0, C: v1, H: v2})
+	})
+}
+
+// CombineHSV is the inverse of [SplitHSV] and returns an opaque image. It panics if the planes' bounds differ.
+func CombineHSV(h, s, v *Plane) *image.RGBA64 {
+	return combinePlanes(h, s, v, func(v0, v1, v2 float32) SRGB {
+		return HSV{H: v0, S: v1, V: v2}.SRGB()
+	})
+}
+
+// CombineHSL is the inverse of [SplitHSL] and returns an opaque image. It panics if the planes' bounds differ.
+func CombineHSL(h, s, l *Plane) *image.RGBA64 {
+	return combinePlanes(h, s, l, func(v0, v1, v2 float32) SRGB {
+		return HSL{H: v0, S: v1, L: v2}.SRGB()
+	})
+}
+
+func combinePlanes(p0, p1, p2 *Plane, fn func(v0, v1, v2 float32) SRGB) *image.RGBA64 {
+	bounds := p0.Rect
+	if p1.Rect != bounds || p2.Rect != bounds {
+		panic("plane bounds mismatch")
+	}
+	dst := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := fn(p0.Value(x, y), p1.Value(x, y), p2.Value(x, y)).RGBA()
+			dst.SetRGBA64(x, y, color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: 0xffff})
+		}
+	}
+	return dst
+}
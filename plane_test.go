@@ -0,0 +1,59 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestSplitOKLCH(t *testing.T) {
+	img := image.NewRGBA(image.Rect(2, 3, 4, 4))
+	img.Set(2, 3, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	img.Set(3, 3, color.RGBA{R: 255, A: 255})
+	l, c, h := SplitOKLCH(img)
+	if l.Bounds() != img.Bounds() {
+		t.Fatalf("bounds mismatch %v", l.Bounds())
+	}
+	red := SRGB{R: 1}.LSRGB().CIEXYZ().OKLAB().OKLCH()
+	if l.Value(3, 3) != red.L || c.Value(3, 3) != red.C || h.Value(3, 3) != red.H {
+		t.Errorf("unexpected red planes %v %v %v", l.Value(3, 3), c.Value(3, 3), h.Value(3, 3))
+	}
+	if c.Value(2, 3) > 1e-3 {
+		t.Errorf("gray has chroma %v", c.Value(2, 3))
+	}
+	if l.Value(0, 0) != 0 {
+		t.Error("out of bounds value should be zero")
+	}
+
+	w := ChromaWeightMap(img, 0.05, 0.15)
+	if w.Value(2, 3) != 1 || w.Value(3, 3) != 0 {
+		t.Errorf("unexpected weights gray=%v red=%v", w.Value(2, 3), w.Value(3, 3))
+	}
+	if mid := 1 - smoothstep(0.05, 0.15, 0.1); math32.Abs(mid-0.5) > 1e-6 {
+		t.Errorf("weight at midpoint %v, want 0.5", mid)
+	}
+}
+
+func TestSplitCombine(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	for i, c := range []color.RGBA{{R: 200, G: 30, B: 90, A: 255}, {G: 255, A: 255}, {R: 40, G: 40, B: 40, A: 255}, {R: 250, G: 240, B: 10, A: 255}} {
+		img.Set(i, 0, c)
+	}
+	for name, roundTrip := range map[string]func(image.Image) *image.RGBA64{
+		"OKLAB": func(img image.Image) *image.RGBA64 { return CombineOKLAB(SplitOKLAB(img)) },
+		"OKLCH": func(img image.Image) *image.RGBA64 { return CombineOKLCH(SplitOKLCH(img)) },
+		"HSV":   func(img image.Image) *image.RGBA64 { return CombineHSV(SplitHSV(img)) },
+		"HSL":   func(img image.Image) *image.RGBA64 { return CombineHSL(SplitHSL(img)) },
+	} {
+		got := roundTrip(img)
+		for x := 0; x < 4; x++ {
+			r0, g0, b0, _ := img.At(x, 0).RGBA()
+			r1, g1, b1, _ := got.At(x, 0).RGBA()
+			if absDiffU32(r0, r1) > 0x101 || absDiffU32(g0, g1) > 0x101 || absDiffU32(b0, b1) > 0x101 {
+				t.Errorf("%s: pixel %d round trip %v != %v", name, x, got.At(x, 0), img.At(x, 0))
+			}
+		}
+	}
+}
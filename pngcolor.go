@@ -0,0 +1,157 @@
+package colorspace
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// srgbPrimaries are the chromaticities of the sRGB (BT.709) primaries and white point.
+var srgbPrimaries = [4]CIExy{{X: 0.64, Y: 0.33}, {X: 0.30, Y: 0.60}, {X: 0.15, Y: 0.06}, {X: 0.3127, Y: 0.3290}}
+
+// PNGColorInfo is the color space information stored in the gAMA and cHRM chunks of a PNG file.
+// PNG encoders use them to declare non-sRGB output without embedding an ICC profile.
+type PNGColorInfo struct {
+	// Gamma is the gAMA value: the exponent used to encode linear light, i.e: 1/2.2. Zero when absent.
+	Gamma float32
+	// Chromaticities are the cHRM primaries and white point. All zero when absent.
+	Chromaticities Chromaticities
+}
+
+// HasChromaticities reports whether info holds cHRM chromaticities.
+func (info PNGColorInfo) HasChromaticities() bool {
+	return info.Chromaticities.White != (CIExy{})
+}
+
+// RGBSpace returns the RGB space described by info. Missing chromaticities default to
+// the sRGB primaries and a missing gamma defaults to the sRGB transfer function.
+func (info PNGColorInfo) RGBSpace() *RGBSpace {
+	chroma := RGBSpaceSRGB.Chromaticities()
+	if info.HasChromaticities() {
+		chroma = info.Chromaticities
+	}
+	transfer := TransferSRGB
+	if info.Gamma > 0 {
+		transfer = TransferGamma(1 / info.Gamma)
+	}
+	return NewRGBSpace(chroma, transfer)
+}
+
+// ReadPNGColorInfo returns the gAMA and cHRM information of the encoded PNG data.
+// ok is false if the file has neither chunk.
+func ReadPNGColorInfo(data []byte) (info PNGColorInfo, ok bool, err error) {
+	chunks, err := pngColorChunks(data)
+	if err != nil {
+		return info, false, err
+	}
+	return chunks.info, chunks.gAMA || chunks.cHRM, nil
+}
+
+// EncodePNG writes img to w in PNG format with gAMA and cHRM chunks describing its color space.
+// Fields of info that are zero are not written. Use it when writing non-sRGB output such as
+// linear light or wide gamut images so readers interpret the pixel values correctly.
+func EncodePNG(w io.Writer, img image.Image, info PNGColorInfo) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	encoded := buf.Bytes()
+	// The IHDR chunk always comes first and is 25 bytes long.
+	const ihdrEnd = 8 + 25
+	if _, err := w.Write(encoded[:ihdrEnd]); err != nil {
+		return err
+	}
+	be := binary.BigEndian
+	fixed := func(v float32) uint32 { return uint32(math32.Round(v * 100000)) }
+	if info.HasChromaticities() {
+		var body []byte
+		chroma := info.Chromaticities
+		for _, xy := range [4]CIExy{chroma.White, chroma.Red, chroma.Green, chroma.Blue} {
+			body = be.AppendUint32(be.AppendUint32(body, fixed(xy.X)), fixed(xy.Y))
+		}
+		if err := writePNGChunk(w, "cHRM", body); err != nil {
+			return err
+		}
+	}
+	if info.Gamma > 0 {
+		if err := writePNGChunk(w, "gAMA", be.AppendUint32(nil, fixed(info.Gamma))); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(encoded[ihdrEnd:])
+	return err
+}
+
+func writePNGChunk(w io.Writer, typ string, body []byte) error {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(body)))
+	chunk = append(append(chunk, typ...), body...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	_, err := w.Write(chunk)
+	return err
+}
+
+// pngChunks holds the color related ancillary chunks of a PNG file.
+type pngChunks struct {
+	iccp       []byte // Compressed iCCP chunk body.
+	sRGB       bool
+	gAMA, cHRM bool
+	info       PNGColorInfo
+}
+
+// pngColorChunks parses the chunks preceding the image data.
+func pngColorChunks(data []byte) (pngChunks, error) {
+	var chunks pngChunks
+	if !bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")) {
+		return chunks, errImageFormat
+	}
+	be := binary.BigEndian
+	fixed := func(b []byte) float32 { return float32(be.Uint32(b)) / 100000 }
+	for p := data[8:]; len(p) >= 12; {
+		n := int(be.Uint32(p))
+		if n > len(p)-12 {
+			return chunks, errors.New("truncated PNG chunk")
+		}
+		typ, body := string(p[4:8]), p[8:8+n]
+		switch {
+		case typ == "IDAT":
+			return chunks, nil
+		case typ == "iCCP":
+			chunks.iccp = body
+		case typ == "sRGB":
+			chunks.sRGB = true
+		case typ == "gAMA" && n == 4:
+			chunks.gAMA = true
+			chunks.info.Gamma = fixed(body)
+		case typ == "cHRM" && n == 32:
+			chunks.cHRM = true
+			xy := func(i int) CIExy { return CIExy{X: fixed(body[8*i:]), Y: fixed(body[8*i+4:])} }
+			chunks.info.Chromaticities = Chromaticities{White: xy(0), Red: xy(1), Green: xy(2), Blue: xy(3)}
+		}
+		p = p[12+n:]
+	}
+	return chunks, nil
+}
+
+// primariesMatrix returns the linear RGB to XYZ matrix of the RGB space with the given primary
+// and white point chromaticities. The white point is normalized to Y=1.
+func primariesMatrix(red, green, blue, white CIExy) ms3.Mat3 {
+	r, g, b := red.CIEXYZ(1), green.CIEXYZ(1), blue.CIEXYZ(1)
+	p := ms3.NewMat3([]float32{
+		r.X, g.X, b.X,
+		r.Y, g.Y, b.Y,
+		r.Z, g.Z, b.Z,
+	})
+	s := ms3.MulMatVec(p.Inverse(), white.CIEXYZ(1).vec())
+	return ms3.MulMat3(p, ms3.NewMat3([]float32{
+		s.X, 0, 0,
+		0, s.Y, 0,
+		0, 0, s.Z,
+	}))
+}
@@ -0,0 +1,72 @@
+package colorspace
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+func TestPrimariesMatrix(t *testing.T) {
+	m := primariesMatrix(srgbPrimaries[0], srgbPrimaries[1], srgbPrimaries[2], srgbPrimaries[3])
+	if !ms3.EqualMat3(m, linSRGBToXYZ, 1e-3) {
+		t.Errorf("sRGB primaries matrix %v, want %v", m, linSRGBToXYZ)
+	}
+}
+
+func TestPNGColorInfo(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 128, G: 64, B: 255, A: 255})
+	// Linear light image with Rec.2020 primaries.
+	info := PNGColorInfo{
+		Gamma:          1,
+		Chromaticities: RGBSpaceRec2020.Chromaticities(),
+	}
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img, info); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := ReadPNGColorInfo(buf.Bytes())
+	if err != nil || !ok || got != info {
+		t.Fatalf("read back %+v, %v, %v; want %+v", got, ok, err, info)
+	}
+	lin, err := DecodeImageLinear(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := lin.LinearRGBAAt(0, 0).Unpremultiply()
+	want := LinearRec2020{R: 128. / 255, G: 64. / 255, B: 1}.CIEXYZ().LSRGB()
+	if d := sqdist(c.vec(), want.vec()); d > 1e-5 {
+		t.Errorf("decoded %+v, want %+v", c, want)
+	}
+
+	// A gAMA only image uses sRGB primaries.
+	buf.Reset()
+	EncodePNG(&buf, img, PNGColorInfo{Gamma: 1})
+	lin, _ = DecodeImageLinear(bytes.NewReader(buf.Bytes()))
+	c, _ = lin.LinearRGBAAt(0, 0).Unpremultiply()
+	if math32.Abs(c.R-128./255) > 1e-3 || math32.Abs(c.B-1) > 1e-3 {
+		t.Errorf("linear gAMA image decoded as %+v", c)
+	}
+	if _, ok, _ := ReadPNGColorInfo(buf.Bytes()); !ok {
+		t.Error("gAMA chunk not found")
+	}
+}
+
+func TestPNGColorInfoRGBSpace(t *testing.T) {
+	// Absent chunks describe sRGB.
+	got := PNGColorInfo{}.RGBSpace().ToXYZ(0.5, 0.2, 0.8)
+	want := RGBSpaceSRGB.ToXYZ(0.5, 0.2, 0.8)
+	if d := sqdist(got.vec(), want.vec()); d > 1e-10 {
+		t.Errorf("untagged space gives %+v, want %+v", got, want)
+	}
+	info := PNGColorInfo{Gamma: 1, Chromaticities: RGBSpaceRec2020.Chromaticities()}
+	got = info.RGBSpace().ToXYZ(0.5, 0.2, 0.8)
+	want = LinearRec2020{R: 0.5, G: 0.2, B: 0.8}.CIEXYZ()
+	if d := sqdist(got.vec(), want.vec()); d > 1e-8 {
+		t.Errorf("linear Rec.2020 space gives %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+)
+
+// LinearRGBA is a linear light sRGB color premultiplied by alpha with float precision.
+// Go's [color.Color] values are premultiplied in gamma-encoded space, which is only exact for
+// opaque or fully transparent colors; repeated composites of such values accumulate visible error.
+// LinearRGBA is the correct representation to blend, composite and filter translucent colors.
+type LinearRGBA struct {
+	R, G, B, A float32
+}
+
+// PremultiplyLinear converts c to a linear light color premultiplied in linear space.
+func PremultiplyLinear(c color.Color) LinearRGBA {
+	s, a := unpremultiplied(c)
+	return premultiplyLSRGB(s.LSRGB(), a)
+}
+
+func premultiplyLSRGB(c LSRGB, alpha float32) LinearRGBA {
+	return LinearRGBA{R: c.R * alpha, G: c.G * alpha, B: c.B * alpha, A: alpha}
+}
+
+// Unpremultiply returns the straight linear color and alpha. Fully transparent colors return black.
+func (c LinearRGBA) Unpremultiply() (LSRGB, float32) {
+	if c.A <= 0 {
+		return LSRGB{}, 0
+	}
+	return LSRGB{R: c.R / c.A, G: c.G / c.A, B: c.B / c.A}, c.A
+}
+
+// RGBA implements [color.Color] by encoding the straight color with the sRGB transfer function and
+// premultiplying the result as expected by the standard library. Out of gamut values are clipped.
+func (c LinearRGBA) RGBA() (r, g, b, a uint32) {
+	lin, alpha := c.Unpremultiply()
+	s := lin.ClipToGamut().SRGB()
+	alpha = clamp01(alpha)
+	return uint32(s.R*alpha*0xffff + 0.5), uint32(s.G*alpha*0xffff + 0.5), uint32(s.B*alpha*0xffff + 0.5), uint32(alpha*0xffff + 0.5)
+}
+
+// Over composites c over dst with the Porter-Duff over operator.
+func (c LinearRGBA) Over(dst LinearRGBA) LinearRGBA {
+	k := 1 - c.A
+	return LinearRGBA{R: c.R + k*dst.R, G: c.G + k*dst.G, B: c.B + k*dst.B, A: c.A + k*dst.A}
+}
+
+// LinearRGBAModel converts colors to [LinearRGBA] with [PremultiplyLinear].
+var LinearRGBAModel color.Model = color.ModelFunc(func(c color.Color) color.Color {
+	if lc, ok := c.(LinearRGBA); ok {
+		return lc
+	}
+	return PremultiplyLinear(c)
+})
+
+// LinearRGBAImage is an in-memory image of [LinearRGBA] colors for batch compositing and filtering in linear light.
+type LinearRGBAImage struct {
+	// Pix holds the image's pixels as R, G, B, A float values in row-major order.
+	Pix []float32
+	// Stride is the Pix stride between vertically adjacent pixels.
+	Stride int
+	Rect   image.Rectangle
+}
+
+// NewLinearRGBAImage returns a transparent image with the given bounds.
+func NewLinearRGBAImage(r image.Rectangle) *LinearRGBAImage {
+	return &LinearRGBAImage{Pix: make([]float32, 4*r.Dx()*r.Dy()), Stride: 4 * r.Dx(), Rect: r}
+}
+
+// PremultiplyImageLinear converts img to a linear light premultiplied image. See [PremultiplyLinear].
+func PremultiplyImageLinear(img image.Image) *LinearRGBAImage {
+	bounds := img.Bounds()
+	dst := NewLinearRGBAImage(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dst.SetLinearRGBA(x, y, PremultiplyLinear(img.At(x, y)))
+		}
+	}
+	return dst
+}
+
+// ColorModel implements [image.Image].
+func (p *LinearRGBAImage) ColorModel() color.Model { return LinearRGBAModel }
+
+// Bounds implements [image.Image].
+func (p *LinearRGBAImage) Bounds() image.Rectangle { return p.Rect }
+
+// At implements [image.Image].
+func (p *LinearRGBAImage) At(x, y int) color.Color { return p.LinearRGBAAt(x, y) }
+
+// PixOffset returns the index of the first element of Pix that corresponds to the pixel at (x, y).
+func (p *LinearRGBAImage) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*4
+}
+
+// LinearRGBAAt returns the color of the pixel at (x, y). Pixels outside the bounds are transparent.
+func (p *LinearRGBAImage) LinearRGBAAt(x, y int) LinearRGBA {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return LinearRGBA{}
+	}
+	i := p.PixOffset(x, y)
+	s := p.Pix[i : i+4 : i+4]
+	return LinearRGBA{R: s[0], G: s[1], B: s[2], A: s[3]}
+}
+
+// SetLinearRGBA sets the color of the pixel at (x, y). Pixels outside the bounds are ignored.
+func (p *LinearRGBAImage) SetLinearRGBA(x, y int, c LinearRGBA) {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	s := p.Pix[i : i+4 : i+4]
+	s[0], s[1], s[2], s[3] = c.R, c.G, c.B, c.A
+}
+
+// Set implements [draw.Image] by premultiplying c in linear light.
+func (p *LinearRGBAImage) Set(x, y int, c color.Color) {
+	p.SetLinearRGBA(x, y, LinearRGBAModel.Convert(c).(LinearRGBA))
+}
+
+// NRGBA64 returns the image as non-premultiplied 16-bit sRGB, the lossless format to store translucent images.
+func (p *LinearRGBAImage) NRGBA64() *image.NRGBA64 {
+	dst := image.NewNRGBA64(p.Rect)
+	for y := p.Rect.Min.Y; y < p.Rect.Max.Y; y++ {
+		for x := p.Rect.Min.X; x < p.Rect.Max.X; x++ {
+			lin, a := p.LinearRGBAAt(x, y).Unpremultiply()
+			if a <= 0 {
+				continue
+			}
+			s := lin.ClipToGamut().SRGB()
+			dst.SetNRGBA64(x, y, color.NRGBA64{
+				R: uint16(s.R*0xffff + 0.5),
+				G: uint16(s.G*0xffff + 0.5),
+				B: uint16(s.B*0xffff + 0.5),
+				A: uint16(clamp01(a)*0xffff + 0.5),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,38 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPremultiplyLinear(t *testing.T) {
+	c := color.NRGBA{R: 200, G: 100, B: 50, A: 77}
+	p := PremultiplyLinear(c)
+	lin, a := p.Unpremultiply()
+	want := SRGB{R: 200. / 255, G: 100. / 255, B: 50. / 255}.LSRGB()
+	if sqdist(lin.vec(), want.vec()) > 1e-6 || a != float32(77*0x101)/0xffff {
+		t.Errorf("got %+v alpha %v, want %+v", lin, a, want)
+	}
+	// Compositing many times in linear light does not accumulate error.
+	dst := PremultiplyLinear(color.NRGBA{R: 10, G: 200, B: 90, A: 255})
+	layer := PremultiplyLinear(color.NRGBA{A: 0})
+	for i := 0; i < 100; i++ {
+		dst = layer.Over(dst)
+	}
+	if got := color.NRGBAModel.Convert(dst).(color.NRGBA); got != (color.NRGBA{R: 10, G: 200, B: 90, A: 255}) {
+		t.Errorf("transparent composites changed color to %v", got)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.SetNRGBA(0, 0, c)
+	img.SetNRGBA(1, 0, color.NRGBA{R: 255, A: 255})
+	back := PremultiplyImageLinear(img).NRGBA64()
+	for x := 0; x < 2; x++ {
+		want := color.NRGBA64Model.Convert(img.NRGBAAt(x, 0)).(color.NRGBA64)
+		got := back.NRGBA64At(x, 0)
+		if absDiffU32(uint32(got.R), uint32(want.R)) > 1 || absDiffU32(uint32(got.A), uint32(want.A)) > 1 {
+			t.Errorf("pixel %d round trip: got %v, want %v", x, got, want)
+		}
+	}
+}
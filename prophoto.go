@@ -0,0 +1,122 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	// Linear ProPhoto RGB to D50 XYZ as defined by CSS Color 4.
+	linProPhotoToXYZD50 = ms3.NewMat3([]float32{
+		0.7977666449006423, 0.13518129740053308, 0.0313477341283922,
+		0.2880748288194013, 0.711835234241873, 0.00008993693872564,
+		0, 0, 0.8251046025104602,
+	})
+	xyzD50ToLinProPhoto = linProPhotoToXYZD50.Inverse()
+)
+
+// Linear threshold of the ROMM RGB transfer function.
+const proPhotoEt = 1. / 512
+
+// TransferProPhoto is the ROMM RGB transfer function: a 1.8 gamma power curve with a short linear segment near black.
+var TransferProPhoto = TransferFunction{ToLinear: proPhotoToLinear, FromLinear: proPhotoFromLinear}
+
+// ProPhotoRGB is the ProPhoto (ROMM RGB, ISO 22028-2) color space used by raw photo editors as a working space.
+// Its imaginary primaries enclose nearly all real surface colors. Unlike most RGB spaces its white point is D50;
+// conversions to and from [CIEXYZ], which is relative to D65, apply Bradford chromatic adaptation.
+// Values are encoded with [TransferProPhoto].
+type ProPhotoRGB struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+// LinearProPhotoRGB is the linear-light (un-companded) representation of [ProPhotoRGB].
+type LinearProPhotoRGB struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+func (c ProPhotoRGB) vec() ms3.Vec            { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c LinearProPhotoRGB) vec() ms3.Vec      { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c ProPhotoRGB) Array() [3]float32       { return c.vec().Array() }
+func (c LinearProPhotoRGB) Array() [3]float32 { return c.vec().Array() }
+
+// LinearProPhotoRGB decodes the ProPhoto color into linear light.
+func (c ProPhotoRGB) LinearProPhotoRGB() LinearProPhotoRGB {
+	return LinearProPhotoRGB{R: proPhotoToLinear(c.R), G: proPhotoToLinear(c.G), B: proPhotoToLinear(c.B)}
+}
+
+// ProPhotoRGB encodes the linear-light color with the ROMM RGB transfer function.
+func (c LinearProPhotoRGB) ProPhotoRGB() ProPhotoRGB {
+	return ProPhotoRGB{R: proPhotoFromLinear(c.R), G: proPhotoFromLinear(c.G), B: proPhotoFromLinear(c.B)}
+}
+
+// CIEXYZ converts the linear ProPhoto color to CIE XYZ adapted to D65 white.
+func (c LinearProPhotoRGB) CIEXYZ() CIEXYZ {
+	v := ms3.MulMatVec(linProPhotoToXYZD50, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}.d50ToD65()
+}
+
+// LinearProPhotoRGB converts D65 relative XYZ to linear ProPhoto RGB adapting it to D50 white.
+func (c CIEXYZ) LinearProPhotoRGB() LinearProPhotoRGB {
+	v := ms3.MulMatVec(xyzD50ToLinProPhoto, c.d65ToD50().vec())
+	return LinearProPhotoRGB{R: v.X, G: v.Y, B: v.Z}
+}
+
+// ProPhotoRGB converts D65 relative XYZ to ProPhoto RGB. The result may be out of gamut.
+func (c CIEXYZ) ProPhotoRGB() ProPhotoRGB { return c.LinearProPhotoRGB().ProPhotoRGB() }
+
+// CIEXYZ converts the ProPhoto color to CIE XYZ adapted to D65 white.
+func (c ProPhotoRGB) CIEXYZ() CIEXYZ { return c.LinearProPhotoRGB().CIEXYZ() }
+
+// ProPhotoRGB converts the sRGB color to ProPhoto RGB. sRGB colors are always inside the ProPhoto gamut.
+func (c SRGB) ProPhotoRGB() ProPhotoRGB { return c.LSRGB().CIEXYZ().ProPhotoRGB() }
+
+// SRGB converts the ProPhoto color to sRGB. Most saturated ProPhoto colors are out of the sRGB gamut;
+// use [ProPhotoRGB.SRGBMapped] to map them perceptually.
+func (c ProPhotoRGB) SRGB() SRGB { return c.CIEXYZ().LSRGB().SRGB() }
+
+// SRGBMapped converts the ProPhoto color to sRGB reducing OKLCH chroma of out of gamut colors
+// to fit them in the sRGB gamut as specified by CSS Color 4.
+func (c ProPhotoRGB) SRGBMapped() SRGB { return xyzToSRGBMapped(c.CIEXYZ()) }
+
+// InGamut reports whether the linear-light color lies inside the ProPhoto gamut.
+func (c LinearProPhotoRGB) InGamut() bool {
+	return c.R <= 1 && c.G <= 1 && c.B <= 1 && c.R >= 0 && c.G >= 0 && c.B >= 0
+}
+
+// InGamut reports whether the encoded color lies inside the ProPhoto gamut.
+func (c ProPhotoRGB) InGamut() bool {
+	return c.R <= 1 && c.G <= 1 && c.B <= 1 && c.R >= 0 && c.G >= 0 && c.B >= 0
+}
+
+// ClipToGamut clamps each channel of the linear-light ProPhoto color to [0,1].
+func (c LinearProPhotoRGB) ClipToGamut() LinearProPhotoRGB {
+	return LinearProPhotoRGB{R: ms1.Clamp(c.R, 0, 1), G: ms1.Clamp(c.G, 0, 1), B: ms1.Clamp(c.B, 0, 1)}
+}
+
+// ClipToGamut clamps each channel of the encoded ProPhoto color to [0,1].
+func (c ProPhotoRGB) ClipToGamut() ProPhotoRGB {
+	return ProPhotoRGB{R: ms1.Clamp(c.R, 0, 1), G: ms1.Clamp(c.G, 0, 1), B: ms1.Clamp(c.B, 0, 1)}
+}
+
+// proPhotoToLinear decodes a ROMM RGB value. Negative values are mirrored.
+func proPhotoToLinear(v float32) float32 {
+	abs := math32.Abs(v)
+	if abs < 16*proPhotoEt {
+		return v / 16
+	}
+	return math32.Copysign(math32.Pow(abs, 1.8), v)
+}
+
+// proPhotoFromLinear encodes a linear value with the ROMM RGB transfer function. Negative values are mirrored.
+func proPhotoFromLinear(v float32) float32 {
+	abs := math32.Abs(v)
+	if abs < proPhotoEt {
+		return 16 * v
+	}
+	return math32.Copysign(math32.Pow(abs, 1/1.8), v)
+}
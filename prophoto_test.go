@@ -0,0 +1,45 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestProPhotoRGB(t *testing.T) {
+	for _, v := range []float32{0, 0.001, 1. / 512, 0.03, 0.1, 0.5, 1} {
+		if got := proPhotoToLinear(proPhotoFromLinear(v)); math32.Abs(got-v) > 1e-5 {
+			t.Errorf("transfer round trip of %v gave %v", v, got)
+		}
+	}
+	// Encoded curve is continuous at the linear segment boundary.
+	if d := proPhotoFromLinear(proPhotoEt) - proPhotoFromLinear(proPhotoEt*(1-1e-6)); d > 1e-3 {
+		t.Errorf("transfer function discontinuity %v", d)
+	}
+	// ProPhoto white is D50, which adapts to D65 white.
+	white := ProPhotoRGB{R: 1, G: 1, B: 1}.CIEXYZ().LSRGB()
+	if sqdist(white.vec(), LSRGB{R: 1, G: 1, B: 1}.vec()) > 1e-6 {
+		t.Errorf("ProPhoto white is not sRGB white: %+v", white)
+	}
+	// sRGB red in ProPhoto RGB as computed by CSS Color 4.
+	red := SRGB{R: 1}.ProPhotoRGB()
+	if want := (ProPhotoRGB{R: 0.7022, G: 0.2757, B: 0.1036}); sqdist(red.vec(), want.vec()) > 1e-5 {
+		t.Errorf("sRGB red in ProPhoto = %+v, want %+v", red, want)
+	}
+	for _, c := range []SRGB{{R: 1}, {G: 1}, {B: 1}, {R: 0.2, G: 0.7, B: 0.4}} {
+		p := c.ProPhotoRGB()
+		if !p.InGamut() {
+			t.Errorf("sRGB %+v outside ProPhoto gamut: %+v", c, p)
+		}
+		if back := p.SRGB(); sqdist(back.vec(), c.vec()) > 1e-7 {
+			t.Errorf("round trip of %+v gave %+v", c, back)
+		}
+	}
+	if mapped := (ProPhotoRGB{G: 1}).SRGBMapped(); !mapped.LSRGB().InGamut() {
+		t.Errorf("mapped ProPhoto green out of sRGB gamut: %+v", mapped)
+	}
+	css, err := ParseCSSColor("color(prophoto-rgb 1 1 1)")
+	if err != nil || sqdist(css.vec(), SRGB{R: 1, G: 1, B: 1}.vec()) > 1e-6 {
+		t.Errorf("CSS prophoto-rgb white parsed as %+v, %v", css, err)
+	}
+}
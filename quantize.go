@@ -0,0 +1,189 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+// GIFPalette builds a single palette of at most numColors (max 256) colors shared by all frames of an animation.
+// Colors are quantized jointly across frames with k-means clustering in [OKLAB] so that the palette
+// is perceptually optimal for the animation as a whole and frames do not flicker as they would with per-frame palettes.
+// If transparent is true index 0 of the palette is reserved for the fully transparent color
+// and fully transparent pixels are excluded from quantization.
+//
+// The returned palette is best used with a [PerceptualPalette] to draw the frames.
+func GIFPalette(frames []image.Image, numColors int, transparent bool) color.Palette {
+	if numColors <= 0 || numColors > 256 {
+		panic("numColors must be in [1,256]")
+	}
+	var p color.Palette
+	if transparent {
+		p = append(p, color.RGBA{})
+		numColors--
+	}
+	var h colorHistogram
+	for _, frame := range frames {
+		h.addImage(frame, transparent)
+	}
+	samples, weights := h.samples()
+	for _, c := range kmeansOKLAB(samples, weights, numColors) {
+		p = append(p, c.CIEXYZ().LSRGB().ClipToGamut().SRGB().RGBA8())
+	}
+	return p
+}
+
+// colorHistogram accumulates colors into buckets of 5 bits per sRGB channel.
+type colorHistogram struct {
+	buckets map[uint16]*histBucket
+}
+
+type histBucket struct {
+	sum   ms3.Vec // Sum of OKLAB values.
+	count float32
+}
+
+func (h *colorHistogram) add(c color.Color, weight float32) {
+	if h.buckets == nil {
+		h.buckets = make(map[uint16]*histBucket)
+	}
+	r, g, b, _ := c.RGBA()
+	key := uint16(r>>11)<<10 | uint16(g>>11)<<5 | uint16(b>>11)
+	bucket := h.buckets[key]
+	if bucket == nil {
+		bucket = &histBucket{}
+		h.buckets[key] = bucket
+	}
+	lab := ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB()
+	bucket.sum = ms3.Add(bucket.sum, ms3.Scale(weight, lab.vec()))
+	bucket.count += weight
+}
+
+func (h *colorHistogram) addImage(img image.Image, skipTransparent bool) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.At(x, y)
+			if skipTransparent {
+				if _, _, _, a := c.RGBA(); a == 0 {
+					continue
+				}
+			}
+			h.add(c, 1)
+		}
+	}
+}
+
+// samples returns the mean OKLAB color of each non-empty bucket and its weight
+// in a deterministic order.
+func (h *colorHistogram) samples() (samples []OKLAB, weights []float32) {
+	keys := make([]uint16, 0, len(h.buckets))
+	for key := range h.buckets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, key := range keys {
+		bucket := h.buckets[key]
+		if bucket.count <= 0 {
+			continue
+		}
+		mean := ms3.Scale(1/bucket.count, bucket.sum)
+		samples = append(samples, OKLAB{L: mean.X, A: mean.Y, B: mean.Z})
+		weights = append(weights, bucket.count)
+	}
+	return samples, weights
+}
+
+// kmeansOKLAB clusters the weighted samples into at most k clusters and returns their centroids.
+// It returns no centroids if k is not positive.
+// Initialization is deterministic: the heaviest sample is chosen first and subsequent centroids are
+// chosen by maximizing weighted squared distance to existing centroids.
+func kmeansOKLAB(samples []OKLAB, weights []float32, k int) []OKLAB {
+	if k <= 0 {
+		return nil
+	} else if len(samples) <= k {
+		return append([]OKLAB{}, samples...)
+	}
+	const maxIter = 24
+	centroids := make([]OKLAB, 0, k)
+	minDist := make([]float32, len(samples))
+	first := 0
+	for i := range weights {
+		if weights[i] > weights[first] {
+			first = i
+		}
+	}
+	centroids = append(centroids, samples[first])
+	for i := range samples {
+		minDist[i] = oklabSqDist(samples[i], samples[first])
+	}
+	for len(centroids) < k {
+		next := 0
+		var best float32 = -1
+		for i := range samples {
+			if score := weights[i] * minDist[i]; score > best {
+				next, best = i, score
+			}
+		}
+		if best <= 0 {
+			break // All remaining samples coincide with a centroid.
+		}
+		centroids = append(centroids, samples[next])
+		for i := range samples {
+			if d := oklabSqDist(samples[i], samples[next]); d < minDist[i] {
+				minDist[i] = d
+			}
+		}
+	}
+
+	assign := make([]int, len(samples))
+	sums := make([]ms3.Vec, len(centroids))
+	counts := make([]float32, len(centroids))
+	for iter := 0; iter < maxIter; iter++ {
+		changed := iter == 0
+		for i, s := range samples {
+			if nearest := nearestOKLAB(centroids, s); nearest != assign[i] {
+				assign[i] = nearest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		for j := range sums {
+			sums[j] = ms3.Vec{}
+			counts[j] = 0
+		}
+		for i, s := range samples {
+			j := assign[i]
+			sums[j] = ms3.Add(sums[j], ms3.Scale(weights[i], s.vec()))
+			counts[j] += weights[i]
+		}
+		for j := range centroids {
+			if counts[j] > 0 {
+				mean := ms3.Scale(1/counts[j], sums[j])
+				centroids[j] = OKLAB{L: mean.X, A: mean.Y, B: mean.Z}
+			}
+		}
+	}
+	return centroids
+}
+
+// nearestOKLAB returns the index of the color in palette closest to c.
+func nearestOKLAB(palette []OKLAB, c OKLAB) int {
+	ret := 0
+	best := oklabSqDist(palette[0], c)
+	for i := 1; i < len(palette); i++ {
+		if d := oklabSqDist(palette[i], c); d < best {
+			ret, best = i, d
+		}
+	}
+	return ret
+}
+
+func oklabSqDist(a, b OKLAB) float32 {
+	e := ms3.Sub(a.vec(), b.vec())
+	return ms3.Dot(e, e)
+}
@@ -0,0 +1,58 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestGIFPalette(t *testing.T) {
+	const width = 64
+	var frames []image.Image
+	for f := 0; f < 4; f++ {
+		img := image.NewRGBA(image.Rect(0, 0, width, 1))
+		for x := 0; x < width; x++ {
+			img.Set(x, 0, LerpOKLCH(jet[f], jet[len(jet)-1-f], float32(x)/width))
+		}
+		img.Set(0, 0, color.RGBA{})
+		frames = append(frames, img)
+	}
+	p := GIFPalette(frames, 16, true)
+	if len(p) != 16 {
+		t.Fatalf("expected 16 colors, got %d", len(p))
+	}
+	if _, _, _, a := p[0].RGBA(); a != 0 {
+		t.Errorf("expected transparent color at index 0, got %v", p[0])
+	}
+	// All frame colors should be well approximated by palette.
+	pp := NewPerceptualPalette(p[1:], DistanceOKLAB)
+	for _, frame := range frames {
+		for x := 1; x < width; x++ {
+			c := frame.At(x, 0)
+			got := ColorToSRGB(pp.Convert(c)).LSRGB().CIEXYZ().OKLAB()
+			want := ColorToSRGB(c).LSRGB().CIEXYZ().OKLAB()
+			if d := got.DeltaE(want); d > 0.1 {
+				t.Errorf("color %v poorly approximated by palette, deltaE=%v", c, d)
+			}
+		}
+	}
+}
+
+func TestGIFPaletteSize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.RGBA{R: uint8(60 * x), G: 100, B: 200, A: 255})
+	}
+	for _, test := range []struct {
+		numColors   int
+		transparent bool
+	}{{1, true}, {1, false}, {2, true}, {3, false}, {8, true}} {
+		p := GIFPalette([]image.Image{img}, test.numColors, test.transparent)
+		if len(p) > test.numColors {
+			t.Errorf("GIFPalette(%d, %v) returned %d colors", test.numColors, test.transparent, len(p))
+		}
+	}
+	if got := kmeansOKLAB([]OKLAB{{L: 0.5}}, []float32{1}, 0); len(got) != 0 {
+		t.Errorf("kmeansOKLAB with k=0 returned %d centroids", len(got))
+	}
+}
@@ -0,0 +1,114 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+	"github.com/soypat/geometry/ms3"
+)
+
+var (
+	// Linear ITU-R BT.2020 to D65 XYZ as defined by CSS Color 4.
+	linRec2020ToXYZ = ms3.NewMat3([]float32{
+		63426534. / 99577255, 20160776. / 139408157, 47086771. / 278816314,
+		26158966. / 99577255, 472592308. / 697040785, 8267143. / 139408157,
+		0, 19567812. / 697040785, 295819943. / 278816314,
+	})
+	xyzToLinRec2020 = linRec2020ToXYZ.Inverse()
+)
+
+// BT.2020 transfer function constants for 12-bit precision.
+const (
+	rec2020Alpha = 1.09929682680944
+	rec2020Beta  = 0.018053968510807
+)
+
+// TransferRec2020 is the ITU-R BT.2020 (and BT.709) camera transfer function.
+var TransferRec2020 = TransferFunction{ToLinear: rec2020ToLinear, FromLinear: rec2020FromLinear}
+
+// Rec2020 is the ITU-R BT.2020 RGB color space used for UHD television and as the container of HDR video.
+// It has very saturated primaries on the spectral locus covering most visible surface colors
+// and a D65 white point. Values are encoded with [TransferRec2020].
+// HDR signals use the PQ or HLG transfer functions instead which are not applied by this type.
+type Rec2020 struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+// LinearRec2020 is the linear-light (un-companded) representation of [Rec2020].
+type LinearRec2020 struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+func (c Rec2020) vec() ms3.Vec            { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c LinearRec2020) vec() ms3.Vec      { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c Rec2020) Array() [3]float32       { return c.vec().Array() }
+func (c LinearRec2020) Array() [3]float32 { return c.vec().Array() }
+
+// LinearRec2020 decodes the Rec.2020 color into linear light.
+func (c Rec2020) LinearRec2020() LinearRec2020 {
+	return LinearRec2020{R: rec2020ToLinear(c.R), G: rec2020ToLinear(c.G), B: rec2020ToLinear(c.B)}
+}
+
+// Rec2020 encodes the linear-light color with the BT.2020 transfer function.
+func (c LinearRec2020) Rec2020() Rec2020 {
+	return Rec2020{R: rec2020FromLinear(c.R), G: rec2020FromLinear(c.G), B: rec2020FromLinear(c.B)}
+}
+
+// CIEXYZ converts the linear Rec.2020 color to CIE XYZ relative to D65.
+func (c LinearRec2020) CIEXYZ() CIEXYZ {
+	v := ms3.MulMatVec(linRec2020ToXYZ, c.vec())
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// LinearRec2020 converts D65 relative XYZ to linear Rec.2020. The result may be out of gamut.
+func (c CIEXYZ) LinearRec2020() LinearRec2020 {
+	v := ms3.MulMatVec(xyzToLinRec2020, c.vec())
+	return LinearRec2020{R: v.X, G: v.Y, B: v.Z}
+}
+
+// Rec2020 converts D65 relative XYZ to Rec.2020. The result may be out of gamut.
+func (c CIEXYZ) Rec2020() Rec2020 { return c.LinearRec2020().Rec2020() }
+
+// CIEXYZ converts the Rec.2020 color to CIE XYZ relative to D65.
+func (c Rec2020) CIEXYZ() CIEXYZ { return c.LinearRec2020().CIEXYZ() }
+
+// InGamut reports whether the linear-light color lies inside the Rec.2020 gamut.
+func (c LinearRec2020) InGamut() bool {
+	return c.R <= 1 && c.G <= 1 && c.B <= 1 && c.R >= 0 && c.G >= 0 && c.B >= 0
+}
+
+// InGamut reports whether the encoded color lies inside the Rec.2020 gamut.
+func (c Rec2020) InGamut() bool {
+	return c.R <= 1 && c.G <= 1 && c.B <= 1 && c.R >= 0 && c.G >= 0 && c.B >= 0
+}
+
+// ClipToGamut clamps each channel of the linear-light Rec.2020 color to [0,1].
+func (c LinearRec2020) ClipToGamut() LinearRec2020 {
+	return LinearRec2020{R: ms1.Clamp(c.R, 0, 1), G: ms1.Clamp(c.G, 0, 1), B: ms1.Clamp(c.B, 0, 1)}
+}
+
+// ClipToGamut clamps each channel of the encoded Rec.2020 color to [0,1].
+func (c Rec2020) ClipToGamut() Rec2020 {
+	return Rec2020{R: ms1.Clamp(c.R, 0, 1), G: ms1.Clamp(c.G, 0, 1), B: ms1.Clamp(c.B, 0, 1)}
+}
+
+// rec2020ToLinear is the inverse of the BT.2020 OETF. Negative values are mirrored.
+func rec2020ToLinear(v float32) float32 {
+	abs := math32.Abs(v)
+	if abs < rec2020Beta*4.5 {
+		return v / 4.5
+	}
+	return math32.Copysign(math32.Pow((abs+rec2020Alpha-1)/rec2020Alpha, 1/0.45), v)
+}
+
+// rec2020FromLinear is the BT.2020 OETF. Negative values are mirrored.
+func rec2020FromLinear(v float32) float32 {
+	abs := math32.Abs(v)
+	if abs < rec2020Beta {
+		return 4.5 * v
+	}
+	return math32.Copysign(rec2020Alpha*math32.Pow(abs, 0.45)-(rec2020Alpha-1), v)
+}
@@ -0,0 +1,35 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestRec2020(t *testing.T) {
+	for _, v := range []float32{0, 0.01, 0.018, 0.1, 0.5, 1} {
+		if got := rec2020ToLinear(rec2020FromLinear(v)); math32.Abs(got-v) > 1e-5 {
+			t.Errorf("transfer round trip of %v gave %v", v, got)
+		}
+	}
+	// sRGB red in linear Rec.2020 as computed by CSS Color 4.
+	red := SRGB{R: 1}.LSRGB().CIEXYZ().LinearRec2020()
+	if want := (LinearRec2020{R: 0.6274, G: 0.0691, B: 0.0164}); sqdist(red.vec(), want.vec()) > 1e-6 {
+		t.Errorf("sRGB red in linear Rec.2020 = %+v, want %+v", red, want)
+	}
+	white := Rec2020{R: 1, G: 1, B: 1}.CIEXYZ().LSRGB()
+	if sqdist(white.vec(), LSRGB{R: 1, G: 1, B: 1}.vec()) > 1e-8 {
+		t.Errorf("Rec.2020 white is not sRGB white: %+v", white)
+	}
+	// Display P3 green lies inside Rec.2020 but Rec.2020 green lies outside P3.
+	if !(DisplayP3{G: 1}).CIEXYZ().Rec2020().InGamut() {
+		t.Error("P3 green should be inside Rec.2020")
+	}
+	if (Rec2020{G: 1}).CIEXYZ().LinearP3().InGamut() {
+		t.Error("Rec.2020 green should be outside P3")
+	}
+	css, err := ParseCSSColor("color(rec2020 1 1 1)")
+	if err != nil || sqdist(css.vec(), SRGB{R: 1, G: 1, B: 1}.vec()) > 1e-6 {
+		t.Errorf("CSS rec2020 white parsed as %+v, %v", css, err)
+	}
+}
@@ -0,0 +1,96 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// RecolorOptions configures [Recolor].
+type RecolorOptions struct {
+	// Dither diffuses the difference between each pixel and its palette color to unvisited neighbors
+	// with Floyd-Steinberg weights in OKLAB, which renders gradients as mixtures of palette colors.
+	Dither bool
+	// Softness, when positive, blends palette colors for each pixel instead of picking the nearest one.
+	// Palette colors are weighted by exp(-(d/Softness)²) where d is their OKLAB distance to the pixel,
+	// so shading is preserved while hues are pulled to the palette. Output colors then are not restricted
+	// to the palette and Dither is ignored. Values around 0.05 keep results close to the palette.
+	Softness float32
+}
+
+// Recolor maps every pixel of img to the perceptually nearest color of palette in [OKLAB],
+// i.e: to restrict pixel art or generated images to brand colors. Alpha is preserved
+// and fully transparent pixels are left transparent. palette must not be empty.
+func Recolor(img image.Image, palette color.Palette, opts RecolorOptions) *image.RGBA64 {
+	if len(palette) == 0 {
+		panic("empty palette")
+	}
+	labs := make([]OKLAB, len(palette))
+	srgbs := make([]SRGB, len(palette))
+	for i, c := range palette {
+		srgbs[i], _ = unpremultiplied(c)
+		labs[i] = srgbs[i].LSRGB().CIEXYZ().OKLAB()
+	}
+	if opts.Softness > 0 {
+		return mapImageSRGB(img, func(c SRGB) SRGB {
+			return softRecolor(labs, colorToOKLAB(c), opts.Softness).CIEXYZ().LSRGB().ClipToGamut().SRGB()
+		})
+	}
+	if !opts.Dither {
+		return mapImageSRGB(img, func(c SRGB) SRGB {
+			return srgbs[nearestOKLAB(labs, colorToOKLAB(c))]
+		})
+	}
+	bounds := img.Bounds()
+	dst := image.NewRGBA64(bounds)
+	w := bounds.Dx()
+	// Errors diffused to the current and next rows.
+	errs := [2][]ms3.Vec{make([]ms3.Vec, w+2), make([]ms3.Vec, w+2)}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		cur, next := errs[0], errs[1]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			i := x - bounds.Min.X + 1
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			want := ms3.Add(colorToOKLAB(c).vec(), cur[i])
+			idx := nearestOKLAB(labs, OKLAB{L: want.X, A: want.Y, B: want.Z})
+			e := ms3.Sub(want, labs[idx].vec())
+			cur[i+1] = ms3.Add(cur[i+1], ms3.Scale(7./16, e))
+			next[i-1] = ms3.Add(next[i-1], ms3.Scale(3./16, e))
+			next[i] = ms3.Add(next[i], ms3.Scale(5./16, e))
+			next[i+1] = ms3.Add(next[i+1], ms3.Scale(1./16, e))
+			p := srgbs[idx].ClipToGamut()
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(p.R*a*0xffff + 0.5),
+				G: uint16(p.G*a*0xffff + 0.5),
+				B: uint16(p.B*a*0xffff + 0.5),
+				A: uint16(a*0xffff + 0.5),
+			})
+		}
+		for i := range cur {
+			cur[i] = ms3.Vec{}
+		}
+		errs[0], errs[1] = next, cur
+	}
+	return dst
+}
+
+// softRecolor returns the average of the palette colors weighted by a Gaussian of their distance to c.
+func softRecolor(palette []OKLAB, c OKLAB, softness float32) OKLAB {
+	nearest := palette[nearestOKLAB(palette, c)]
+	// Distances are taken relative to the nearest color so weights do not all underflow.
+	dmin := oklabSqDist(nearest, c)
+	var sum ms3.Vec
+	var wsum float32
+	for _, p := range palette {
+		w := math32.Exp(-(oklabSqDist(p, c) - dmin) / (softness * softness))
+		sum = ms3.Add(sum, ms3.Scale(w, p.vec()))
+		wsum += w
+	}
+	v := ms3.Scale(1/wsum, sum)
+	return OKLAB{L: v.X, A: v.Y, B: v.Z}
+}
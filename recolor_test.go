@@ -0,0 +1,74 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestRecolor(t *testing.T) {
+	palette := color.Palette{color.RGBA{A: 0xff}, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, color.RGBA{R: 0xe0, G: 0x40, B: 0x20, A: 0xff}}
+	// Horizontal gray ramp with a red stripe in the middle row and a transparent corner.
+	img := image.NewRGBA(image.Rect(0, 0, 64, 3))
+	for x := 0; x < 64; x++ {
+		v := uint8(x * 4)
+		img.SetRGBA(x, 0, color.RGBA{R: v, G: v, B: v, A: 0xff})
+		img.SetRGBA(x, 1, color.RGBA{R: 0xd0, G: 0x50, B: 0x30, A: 0xff})
+		img.SetRGBA(x, 2, color.RGBA{R: v, G: v, B: v, A: 0xff})
+	}
+	img.SetRGBA(0, 2, color.RGBA{})
+
+	inPalette := func(c color.Color) bool {
+		r, g, b, a := c.RGBA()
+		for _, p := range palette {
+			pr, pg, pb, _ := p.RGBA()
+			if r == pr && g == pg && b == pb && a == 0xffff {
+				return true
+			}
+		}
+		return false
+	}
+	for _, dither := range []bool{false, true} {
+		got := Recolor(img, palette, RecolorOptions{Dither: dither})
+		var whites int
+		for x := 0; x < 64; x++ {
+			for y := 0; y < 3; y++ {
+				if x == 0 && y == 2 {
+					if _, _, _, a := got.At(x, y).RGBA(); a != 0 {
+						t.Errorf("dither=%v: transparent pixel became opaque", dither)
+					}
+					continue
+				}
+				if !inPalette(got.At(x, y)) {
+					t.Errorf("dither=%v: pixel (%d,%d) = %v not in palette", dither, x, y, got.At(x, y))
+				}
+			}
+			if !dither && got.RGBA64At(x, 1) != color.RGBA64Model.Convert(palette[2]) {
+				t.Errorf("dither=%v: red stripe pixel %d = %v", dither, x, got.At(x, 1))
+			}
+			if r, _, _, _ := got.At(x, 0).RGBA(); r == 0xffff {
+				whites++
+			}
+		}
+		// Without dithering the ramp is split in two; with dithering white density grows along it.
+		if dither {
+			if whites < 20 || whites > 44 {
+				t.Errorf("dithered ramp has %d white pixels, want about half", whites)
+			}
+		} else if r, _, _, _ := got.At(10, 0).RGBA(); r != 0 {
+			t.Errorf("dark end of ramp = %v, want black", got.At(10, 0))
+		}
+	}
+
+	soft := Recolor(img, palette, RecolorOptions{Softness: 0.05})
+	stripe := colorToOKLAB(soft.At(5, 1)).DeltaE(colorToOKLAB(palette[2]))
+	orig := colorToOKLAB(img.At(5, 1)).DeltaE(colorToOKLAB(palette[2]))
+	if stripe >= orig {
+		t.Errorf("soft recolor did not pull stripe towards palette red: %v >= %v", stripe, orig)
+	}
+	// Broad soft assignment keeps the shading of the ramp.
+	soft = Recolor(img, palette, RecolorOptions{Softness: 0.3})
+	if l1, l2 := colorToOKLAB(soft.At(20, 0)).L, colorToOKLAB(soft.At(40, 0)).L; l1 >= l2 {
+		t.Errorf("soft recolor lost ramp ordering: %v >= %v", l1, l2)
+	}
+}
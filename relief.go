@@ -0,0 +1,82 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// ReliefBlend selects how [ShadeRelief] combines a hillshade with colors.
+type ReliefBlend uint8
+
+const (
+	// ReliefLinear multiplies the colors by the hillshade in linear light, as light reflected by terrain would.
+	ReliefLinear ReliefBlend = iota
+	// ReliefOKLAB multiplies [OKLAB] lightness by the hillshade keeping hue and chroma
+	// so colors of shaded slopes stay recognizable. Chroma is reduced if needed to stay in gamut.
+	ReliefOKLAB
+)
+
+// Hillshade returns the illumination in [0,1] of the digital elevation model dem with samples spaced
+// cellSize apart, in the same unit as elevations, lit from the given azimuth (degrees clockwise from north,
+// which is up in the image) and altitude (degrees above the horizon). Conventional values are 315 and 45.
+func Hillshade(dem *Plane, cellSize, azimuth, altitude float32) *Plane {
+	const deg = math32.Pi / 180
+	zenith := (90 - altitude) * deg
+	sunSin, sunCos := math32.Sincos(zenith)
+	// Azimuth in mathematical convention: counterclockwise from east.
+	az := (90 - azimuth) * deg
+	bounds := dem.Rect
+	dst := NewPlane(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dzdx, dzdy := demGradient(dem, x, y, cellSize)
+			slope := math32.Atan(math32.Hypot(dzdx, dzdy))
+			// Direction of steepest descent, with y pointing north.
+			aspect := math32.Atan2(dzdy, -dzdx)
+			slopeSin, slopeCos := math32.Sincos(slope)
+			dst.SetValue(x, y, math32.Max(sunCos*slopeCos+sunSin*slopeSin*math32.Cos(az-aspect), 0))
+		}
+	}
+	return dst
+}
+
+// ShadeRelief returns colors shaded by hillshade, whose values in [0,1] are the illumination of each pixel,
+// i.e: computed with [Hillshade]. Unlike the multiply blend of gamma encoded values common in mapping tools,
+// which washes out and darkens colors unevenly, shading is applied in linear light or OKLAB lightness.
+// Pixels outside the hillshade's bounds are left unshaded. Alpha is preserved.
+func ShadeRelief(colors image.Image, hillshade *Plane, blend ReliefBlend) *image.RGBA64 {
+	bounds := colors.Bounds()
+	dst := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(colors.At(x, y))
+			if a == 0 {
+				continue
+			}
+			if (image.Point{X: x, Y: y}).In(hillshade.Rect) {
+				c = shadeColor(c, clamp01(hillshade.Value(x, y)), blend)
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(c.R*a*0xffff + 0.5),
+				G: uint16(c.G*a*0xffff + 0.5),
+				B: uint16(c.B*a*0xffff + 0.5),
+				A: uint16(a*0xffff + 0.5),
+			})
+		}
+	}
+	return dst
+}
+
+func shadeColor(c SRGB, shade float32, blend ReliefBlend) SRGB {
+	switch blend {
+	case ReliefLinear:
+		return c.LSRGB().ScaleBrightness(shade).SRGB()
+	case ReliefOKLAB:
+		lch := c.LSRGB().CIEXYZ().OKLAB().OKLCH()
+		lch.L *= shade
+		return lch.chromaClippedLSRGB().SRGB()
+	}
+	panic("invalid relief blend")
+}
@@ -0,0 +1,62 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestHillshade(t *testing.T) {
+	// Terrain rising eastwards faces west.
+	dem := NewPlane(image.Rect(0, 0, 3, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			dem.SetValue(x, y, float32(x))
+		}
+	}
+	flat := Hillshade(NewPlane(image.Rect(0, 0, 1, 1)), 1, 315, 45)
+	if v := flat.Value(0, 0); math32.Abs(v-math32.Sqrt2/2) > 1e-5 {
+		t.Errorf("flat terrain shade %v, want sin(45°)", v)
+	}
+	west := Hillshade(dem, 1, 270, 45).Value(1, 1)
+	east := Hillshade(dem, 1, 90, 45).Value(1, 1)
+	if math32.Abs(west-1) > 1e-5 || east > 1e-5 {
+		t.Errorf("west facing 45° slope: lit from west %v (want 1), from east %v (want 0)", west, east)
+	}
+	// Terrain rising southwards (down the image) faces north.
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			dem.SetValue(x, y, float32(y))
+		}
+	}
+	if north := Hillshade(dem, 1, 0, 45).Value(1, 1); math32.Abs(north-1) > 1e-5 {
+		t.Errorf("north facing slope lit from north %v, want 1", north)
+	}
+}
+
+func TestShadeRelief(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	green := color.RGBA{R: 60, G: 160, B: 70, A: 255}
+	img.SetRGBA(0, 0, green)
+	img.SetRGBA(1, 0, green)
+	shade := NewPlane(image.Rect(0, 0, 1, 1))
+	shade.SetValue(0, 0, 0.5)
+
+	linear := ShadeRelief(img, shade, ReliefLinear)
+	got := ColorToSRGB(linear.At(0, 0)).LSRGB()
+	want := ColorToSRGB(green).LSRGB().ScaleBrightness(0.5)
+	if sqdist(got.vec(), want.vec()) > 1e-6 {
+		t.Errorf("linear shading %+v, want %+v", got, want)
+	}
+	if ColorToSRGB(linear.At(1, 0)) != ColorToSRGB(green) {
+		t.Error("pixel outside hillshade should not be shaded")
+	}
+
+	ok := ColorToSRGB(ShadeRelief(img, shade, ReliefOKLAB).At(0, 0)).LSRGB().CIEXYZ().OKLAB().OKLCH()
+	orig := colorToOKLCH(green)
+	if math32.Abs(ok.L-orig.L/2) > 1e-3 || math32.Abs(ok.H-orig.H) > 1 {
+		t.Errorf("OKLAB shading %+v, want lightness %v and hue %v", ok, orig.L/2, orig.H)
+	}
+}
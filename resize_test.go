@@ -0,0 +1,34 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestResizeLinear(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, color.RGBA{A: 255})
+	src.SetRGBA(1, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	// Naive box filter which averages the stored values.
+	box := func(dst draw.Image, src image.Image) {
+		r1, g1, b1, a1 := src.At(0, 0).RGBA()
+		r2, g2, b2, a2 := src.At(1, 0).RGBA()
+		dst.Set(0, 0, color.RGBA64{R: uint16((r1 + r2) / 2), G: uint16((g1 + g2) / 2), B: uint16((b1 + b2) / 2), A: uint16((a1 + a2) / 2)})
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	ResizeLinear(dst, src, box)
+	// Averaging black and white in linear light yields sRGB 188, not 128.
+	if got := dst.RGBAAt(0, 0); got.R != 188 || got.G != 188 || got.B != 188 || got.A != 255 {
+		t.Errorf("got %v, want 188 gray", got)
+	}
+	roundtrip := EncodeLinearImage(LinearizeImage(src))
+	for x := 0; x < 2; x++ {
+		r1, _, _, _ := src.At(x, 0).RGBA()
+		r2, _, _, _ := roundtrip.At(x, 0).RGBA()
+		if absDiffU32(r1, r2) > 1 {
+			t.Errorf("round trip changed pixel %d: %d != %d", x, r1, r2)
+		}
+	}
+}
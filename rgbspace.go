@@ -0,0 +1,111 @@
+package colorspace
+
+import "github.com/soypat/geometry/ms3"
+
+// Chromaticities are the CIE xy coordinates of the primaries and white point of an RGB space,
+// as defined by color space standards and stored in the chromaticities attribute of OpenEXR files.
+type Chromaticities struct {
+	Red, Green, Blue, White CIExy
+}
+
+// matrix returns the matrix converting linear RGB with the chromaticities c to XYZ relative to D65,
+// adapting the white point with the Bradford transform.
+func (c Chromaticities) matrix() ms3.Mat3 {
+	adapt := bradfordMatrix(c.White.CIEXYZ(1).vec(), d65)
+	return ms3.MulMat3(adapt, primariesMatrix(c.Red, c.Green, c.Blue, c.White))
+}
+
+var whiteD65 = CIExy{X: 0.3127, Y: 0.3290}
+
+// Predefined RGB spaces.
+var (
+	// RGBSpaceSRGB is the IEC 61966-2-1 sRGB space. Prefer the [SRGB] type for sRGB colors.
+	RGBSpaceSRGB = NewRGBSpace(Chromaticities{Red: srgbPrimaries[0], Green: srgbPrimaries[1], Blue: srgbPrimaries[2], White: srgbPrimaries[3]}, TransferSRGB)
+	// RGBSpaceDisplayP3 is the space of the [DisplayP3] type.
+	RGBSpaceDisplayP3 = NewRGBSpace(Chromaticities{Red: CIExy{X: 0.680, Y: 0.320}, Green: CIExy{X: 0.265, Y: 0.690}, Blue: CIExy{X: 0.150, Y: 0.060}, White: whiteD65}, TransferSRGB)
+	// RGBSpaceRec2020 is the space of the [Rec2020] type.
+	RGBSpaceRec2020 = NewRGBSpace(Chromaticities{Red: CIExy{X: 0.708, Y: 0.292}, Green: CIExy{X: 0.170, Y: 0.797}, Blue: CIExy{X: 0.131, Y: 0.046}, White: whiteD65}, TransferRec2020)
+	// RGBSpaceAdobeRGB is the Adobe RGB (1998) space.
+	RGBSpaceAdobeRGB = NewRGBSpace(Chromaticities{Red: CIExy{X: 0.64, Y: 0.33}, Green: CIExy{X: 0.21, Y: 0.71}, Blue: CIExy{X: 0.15, Y: 0.06}, White: whiteD65}, TransferGamma(563./256))
+	// RGBSpaceProPhoto is the space of the [ProPhotoRGB] type.
+	RGBSpaceProPhoto = NewRGBSpace(Chromaticities{Red: CIExy{X: 0.7347, Y: 0.2653}, Green: CIExy{X: 0.1596, Y: 0.8404}, Blue: CIExy{X: 0.0366, Y: 0.0001}, White: CIExy{X: 0.3457, Y: 0.3585}}, TransferProPhoto)
+	// RGBSpaceACEScg is the linear space of the [ACEScg] type.
+	RGBSpaceACEScg = NewRGBSpace(acesAP1, TransferLinear)
+)
+
+// RGBSpace is an RGB color space defined by the chromaticities of its primaries and white point and
+// its transfer function, i.e: a camera or monitor working space not provided by this package.
+// Conversions to and from [CIEXYZ], which is relative to D65, adapt the white point with the Bradford transform.
+// RGBSpace must be created with [NewRGBSpace].
+type RGBSpace struct {
+	chroma   Chromaticities
+	transfer TransferFunction
+	// toXYZ and fromXYZ convert between linear RGB and D65 relative XYZ.
+	toXYZ, fromXYZ ms3.Mat3
+}
+
+// NewRGBSpace returns the RGB space with the given primaries and white point whose encoded values are
+// decoded to linear light by transfer. Use [TransferLinear] for linear spaces.
+func NewRGBSpace(chroma Chromaticities, transfer TransferFunction) *RGBSpace {
+	toXYZ := chroma.matrix()
+	return &RGBSpace{chroma: chroma, transfer: transfer, toXYZ: toXYZ, fromXYZ: toXYZ.Inverse()}
+}
+
+// profile returns the space as an ICC matrix/TRC profile with its colorants adapted to the D50 connection space.
+func (s *RGBSpace) profile(description string) *ICCProfile {
+	m := ms3.MulMat3(d65Tod50, s.toXYZ)
+	col := func(j int) CIEXYZ {
+		v := m.VecCol(j)
+		return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+	}
+	return &ICCProfile{
+		Description: description,
+		Red:         col(0),
+		Green:       col(1),
+		Blue:        col(2),
+		TRC:         [3]TransferFunction{s.transfer, s.transfer, s.transfer},
+	}
+}
+
+// Chromaticities returns the primaries and white point of the space.
+func (s *RGBSpace) Chromaticities() Chromaticities { return s.chroma }
+
+// Transfer returns the transfer function of the space.
+func (s *RGBSpace) Transfer() TransferFunction { return s.transfer }
+
+// Matrix returns the linear RGB to XYZ matrix relative to the white point of the space,
+// normalized so the white point has Y=1. This is the matrix usually published with the space's definition.
+func (s *RGBSpace) Matrix() ms3.Mat3 {
+	return primariesMatrix(s.chroma.Red, s.chroma.Green, s.chroma.Blue, s.chroma.White)
+}
+
+// ToXYZ converts the encoded color channels of a color in the space to XYZ relative to D65.
+func (s *RGBSpace) ToXYZ(r, g, b float32) CIEXYZ {
+	return s.LinearToXYZ(s.transfer.ToLinear(r), s.transfer.ToLinear(g), s.transfer.ToLinear(b))
+}
+
+// FromXYZ converts D65 relative XYZ to encoded color channels of the space. The result may be out of gamut.
+func (s *RGBSpace) FromXYZ(c CIEXYZ) (r, g, b float32) {
+	r, g, b = s.LinearFromXYZ(c)
+	return s.transfer.FromLinear(r), s.transfer.FromLinear(g), s.transfer.FromLinear(b)
+}
+
+// LinearToXYZ converts the linear-light channels of a color in the space to XYZ relative to D65.
+func (s *RGBSpace) LinearToXYZ(r, g, b float32) CIEXYZ {
+	v := ms3.MulMatVec(s.toXYZ, ms3.Vec{X: r, Y: g, Z: b})
+	return CIEXYZ{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// LinearFromXYZ converts D65 relative XYZ to linear-light channels of the space. The result may be out of gamut.
+func (s *RGBSpace) LinearFromXYZ(c CIEXYZ) (r, g, b float32) {
+	v := ms3.MulMatVec(s.fromXYZ, c.vec())
+	return v.X, v.Y, v.Z
+}
+
+// InGamut reports whether the D65 relative XYZ color can be represented in the space
+// with channels in [0,1], allowing a small tolerance for rounding.
+func (s *RGBSpace) InGamut(c CIEXYZ) bool {
+	const tol = 1e-5
+	r, g, b := s.LinearFromXYZ(c)
+	return r >= -tol && g >= -tol && b >= -tol && r <= 1+tol && g <= 1+tol && b <= 1+tol
+}
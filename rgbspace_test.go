@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/soypat/geometry/ms3"
+)
+
+func TestRGBSpace(t *testing.T) {
+	if !ms3.EqualMat3(RGBSpaceSRGB.Matrix(), linSRGBToXYZ, 1e-3) {
+		t.Errorf("sRGB matrix %v, want %v", RGBSpaceSRGB.Matrix(), linSRGBToXYZ)
+	}
+	// Predefined spaces agree with the package's color types.
+	colors := [][3]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}, {0.2, 0.6, 0.35}}
+	for _, tc := range []struct {
+		name  string
+		space *RGBSpace
+		xyz   func(r, g, b float32) CIEXYZ
+	}{
+		{"sRGB", RGBSpaceSRGB, func(r, g, b float32) CIEXYZ { return SRGB{R: r, G: g, B: b}.LSRGB().CIEXYZ() }},
+		{"Display P3", RGBSpaceDisplayP3, func(r, g, b float32) CIEXYZ { return DisplayP3{R: r, G: g, B: b}.CIEXYZ() }},
+		{"Rec.2020", RGBSpaceRec2020, func(r, g, b float32) CIEXYZ { return Rec2020{R: r, G: g, B: b}.CIEXYZ() }},
+		{"ProPhoto", RGBSpaceProPhoto, func(r, g, b float32) CIEXYZ { return ProPhotoRGB{R: r, G: g, B: b}.CIEXYZ() }},
+		{"ACEScg", RGBSpaceACEScg, func(r, g, b float32) CIEXYZ { return ACEScg{R: r, G: g, B: b}.CIEXYZ() }},
+		{"Adobe RGB", RGBSpaceAdobeRGB, func(r, g, b float32) CIEXYZ { return adobeRGBProfile().CIEXYZ(r, g, b) }},
+	} {
+		for _, c := range colors {
+			got, want := tc.space.ToXYZ(c[0], c[1], c[2]), tc.xyz(c[0], c[1], c[2])
+			if sqdist(got.vec(), want.vec()) > 1e-6 {
+				t.Errorf("%s %v to XYZ = %+v, want %+v", tc.name, c, got, want)
+			}
+			r, g, b := tc.space.FromXYZ(got)
+			if sqdist(ms3.Vec{X: r, Y: g, Z: b}, ms3.Vec{X: c[0], Y: c[1], Z: c[2]}) > 1e-6 { // Pure gamma curves amplify error near 0.
+				t.Errorf("%s round trip of %v gave %v %v %v", tc.name, c, r, g, b)
+			}
+			if !tc.space.InGamut(got) {
+				t.Errorf("%s %v not in gamut", tc.name, c)
+			}
+		}
+	}
+	p3Green := RGBSpaceDisplayP3.ToXYZ(0, 1, 0)
+	if RGBSpaceSRGB.InGamut(p3Green) || !RGBSpaceRec2020.InGamut(p3Green) {
+		t.Error("P3 green should be outside sRGB and inside Rec.2020")
+	}
+	// A custom working space.
+	custom := NewRGBSpace(Chromaticities{
+		Red:   CIExy{X: 0.69, Y: 0.30},
+		Green: CIExy{X: 0.20, Y: 0.75},
+		Blue:  CIExy{X: 0.14, Y: 0.05},
+		White: CIExy{X: 0.3457, Y: 0.3585},
+	}, TransferGamma(2.2))
+	if white := custom.ToXYZ(1, 1, 1); sqdist(white.vec(), d65) > 1e-6 {
+		t.Errorf("custom space white adapted to %+v, want D65", white)
+	}
+}
@@ -0,0 +1,116 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// RGBW holds the drive levels in [0,1] of an RGBW LED: red, green, blue and a white emitter.
+type RGBW struct {
+	R, G, B, W float32
+}
+
+// RGBWW holds the drive levels in [0,1] of an RGB LED with warm white (WW) and cool white (CW) emitters.
+type RGBWW struct {
+	R, G, B, WW, CW float32
+}
+
+// EmitterLSRGB returns the linear sRGB equivalent of an emitter of the given chromaticity, i.e: a white LED
+// as listed in its datasheet, with luminance relative to the full brightness of the RGB emitters
+// combined. Use it to build the white emitter argument of [LSRGB.RGBW] and [LSRGB.RGBWW].
+func EmitterLSRGB(chromaticity CIExy, luminance float32) LSRGB {
+	return chromaticity.CIEXYZ(luminance).LSRGB()
+}
+
+// RGBW decomposes the target linear color c into RGBW drive levels maximizing white emitter usage,
+// which is more efficient and renders whites with a better spectrum than mixing RGB.
+// white is the linear sRGB output of the white emitter at full drive. The RGB emitters are assumed
+// to have sRGB primaries. Use [LSRGB.ScaleBrightness] on c to set the brightness beforehand.
+// Targets beyond the fixture's capability are clipped.
+func (c LSRGB) RGBW(white LSRGB) RGBW {
+	c = c.ClipToGamut()
+	w := math32.Min(maxEmitterLevel(c, white), 1)
+	return RGBW{
+		R: clamp01(c.R - w*white.R),
+		G: clamp01(c.G - w*white.G),
+		B: clamp01(c.B - w*white.B),
+		W: w,
+	}
+}
+
+// RGBWW decomposes the target linear color c into RGB plus warm and cool white drive levels,
+// maximizing the combined usage of both white emitters. warm and cool are the linear sRGB outputs of
+// the warm and cool white emitters at full drive. See [LSRGB.RGBW].
+func (c LSRGB) RGBWW(warm, cool LSRGB) RGBWW {
+	c = c.ClipToGamut()
+	// Maximize a+b subject to a*warm + b*cool <= c componentwise and a,b in [0,1].
+	// The optimum lies on a vertex of the feasible polygon so candidate vertices are enumerated.
+	// Emitters that emit nothing stay off.
+	maxA := math32.Min(maxEmitterLevel(c, warm), 1)
+	maxB := math32.Min(maxEmitterLevel(c, cool), 1)
+	type line struct{ a, b, rhs float32 } // a*x + b*y = rhs
+	lines := []line{
+		{1, 0, 0}, {1, 0, maxA}, {0, 1, 0}, {0, 1, maxB},
+		{warm.R, cool.R, c.R}, {warm.G, cool.G, c.G}, {warm.B, cool.B, c.B},
+	}
+	feasible := func(x, y float32) bool {
+		const tol = 1e-5
+		return x >= -tol && x <= maxA+tol && y >= -tol && y <= maxB+tol &&
+			x*warm.R+y*cool.R <= c.R+tol &&
+			x*warm.G+y*cool.G <= c.G+tol &&
+			x*warm.B+y*cool.B <= c.B+tol
+	}
+	var bestA, bestB float32
+	best := float32(-1)
+	for i, l1 := range lines {
+		for _, l2 := range lines[i+1:] {
+			det := l1.a*l2.b - l1.b*l2.a
+			if det == 0 {
+				continue
+			}
+			x := (l1.rhs*l2.b - l1.b*l2.rhs) / det
+			y := (l1.a*l2.rhs - l1.rhs*l2.a) / det
+			if feasible(x, y) && x+y > best {
+				best, bestA, bestB = x+y, x, y
+			}
+		}
+	}
+	bestA, bestB = clamp01(bestA), clamp01(bestB)
+	return RGBWW{
+		R:  clamp01(c.R - bestA*warm.R - bestB*cool.R),
+		G:  clamp01(c.G - bestA*warm.G - bestB*cool.G),
+		B:  clamp01(c.B - bestA*warm.B - bestB*cool.B),
+		WW: bestA,
+		CW: bestB,
+	}
+}
+
+// LSRGB returns the linear sRGB color emitted by the RGBW drive levels with the given white emitter.
+func (c RGBW) LSRGB(white LSRGB) LSRGB {
+	return LSRGB{R: c.R + c.W*white.R, G: c.G + c.W*white.G, B: c.B + c.W*white.B}
+}
+
+// LSRGB returns the linear sRGB color emitted by the RGBWW drive levels with the given white emitters.
+func (c RGBWW) LSRGB(warm, cool LSRGB) LSRGB {
+	return LSRGB{
+		R: c.R + c.WW*warm.R + c.CW*cool.R,
+		G: c.G + c.WW*warm.G + c.CW*cool.G,
+		B: c.B + c.WW*warm.B + c.CW*cool.B,
+	}
+}
+
+// maxEmitterLevel returns the largest drive level of emitter that does not exceed target in any channel.
+// It returns zero for an emitter that emits nothing.
+func maxEmitterLevel(target, emitter LSRGB) float32 {
+	if emitter.R <= 0 && emitter.G <= 0 && emitter.B <= 0 {
+		return 0
+	}
+	level := float32(math32.MaxFloat32)
+	for i, e := range emitter.Array() {
+		if e > 0 {
+			level = math32.Min(level, target.Array()[i]/e)
+		}
+	}
+	return math32.Max(level, 0)
+}
+
+func clamp01(v float32) float32 {
+	return math32.Max(math32.Min(v, 1), 0)
+}
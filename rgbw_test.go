@@ -0,0 +1,61 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestRGBW(t *testing.T) {
+	white := EmitterLSRGB(CIExy{X: 0.4578, Y: 0.4101}, 1) // 2700K white LED.
+	for _, target := range []LSRGB{
+		{R: 1, G: 1, B: 1},
+		{R: 0.8, G: 0.5, B: 0.2},
+		{R: 0, G: 0.3, B: 1},
+		white.ScaleBrightness(0.5),
+	} {
+		got := target.RGBW(white)
+		if sqdist(got.LSRGB(white).vec(), target.vec()) > 1e-8 {
+			t.Errorf("%+v: decomposition %+v does not reproduce target", target, got)
+		}
+		if got.R > 1e-5 && got.G > 1e-5 && got.B > 1e-5 {
+			t.Errorf("%+v: white usage not maximized: %+v", target, got)
+		}
+	}
+	if got := white.ScaleBrightness(0.5).RGBW(white); math32.Abs(got.W-0.5) > 1e-5 {
+		t.Errorf("white target should only use white emitter, got %+v", got)
+	}
+	// An emitter that emits nothing stays off.
+	if got := (LSRGB{}).RGBW(LSRGB{}); got != (RGBW{}) {
+		t.Errorf("black with zero emitter gave %+v", got)
+	}
+	if got := (LSRGB{R: 0.5}).RGBW(LSRGB{}); got != (RGBW{R: 0.5}) {
+		t.Errorf("red with zero emitter gave %+v", got)
+	}
+}
+
+func TestRGBWW(t *testing.T) {
+	warm := EmitterLSRGB(CIExy{X: 0.4578, Y: 0.4101}, 0.5)
+	cool := EmitterLSRGB(CIExy{X: 0.3123, Y: 0.3282}, 0.5)
+	for _, target := range []LSRGB{
+		{R: 1, G: 1, B: 1},
+		{R: 0.8, G: 0.5, B: 0.2},
+		{R: 0.2, G: 0.3, B: 0.9},
+		warm.Lerp(cool, 0.3),
+	} {
+		got := target.RGBWW(warm, cool)
+		if sqdist(got.LSRGB(warm, cool).vec(), target.vec()) > 1e-8 {
+			t.Errorf("%+v: decomposition %+v does not reproduce target", target, got)
+		}
+	}
+	mix := warm.Lerp(cool, 0.3).RGBWW(warm, cool)
+	if math32.Abs(mix.WW-0.7) > 1e-3 || math32.Abs(mix.CW-0.3) > 1e-3 {
+		t.Errorf("expected warm/cool mix 0.7/0.3, got %+v", mix)
+	}
+	if got := (LSRGB{}).RGBWW(LSRGB{}, LSRGB{}); got != (RGBWW{}) {
+		t.Errorf("black with zero emitters gave %+v", got)
+	}
+	if got := cool.RGBWW(LSRGB{}, cool); got.WW != 0 || math32.Abs(got.CW-1) > 1e-5 {
+		t.Errorf("cool target with zero warm emitter gave %+v", got)
+	}
+}
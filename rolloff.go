@@ -0,0 +1,53 @@
+package colorspace
+
+import "github.com/chewxy/math32"
+
+// Rolloff compresses the lightness of c above threshold with a smooth exponential shoulder that
+// approaches but never reaches 1, keeping lightness unchanged below threshold. Hue is preserved
+// and chroma is scaled with lightness so bright saturated highlights fade towards white gradually
+// instead of abruptly clipping. threshold is an OKLCH lightness in [0,1), typically 0.8, and is clamped to [0,1].
+func (c OKLCH) Rolloff(threshold float32) OKLCH {
+	threshold = clamp01(threshold)
+	if c.L <= threshold {
+		return c
+	}
+	knee := 1 - threshold
+	if knee <= 0 {
+		return OKLCH{L: 1, H: c.H}
+	}
+	// Shoulder with unit slope at the threshold so the curve is continuous in value and derivative.
+	l := threshold + knee*(-math32.Expm1(-(c.L-threshold)/knee))
+	c.C *= l / c.L
+	c.L = l
+	return c
+}
+
+// Rolloff brings the possibly high dynamic range linear color c into the sRGB gamut by compressing
+// highlights above the OKLCH lightness threshold with [OKLCH.Rolloff] and reducing chroma at constant
+// hue until the result fits the gamut. It is a gentler, hue preserving alternative to [LSRGB.ClipToGamut]
+// for rendered imagery with speculars.
+func (c LSRGB) Rolloff(threshold float32) LSRGB {
+	lch := c.CIEXYZ().OKLAB().OKLCH().Rolloff(threshold)
+	lch.L = clamp01(lch.L)
+	return lch.chromaClippedLSRGB()
+}
+
+// chromaClippedLSRGB converts c to linear sRGB reducing chroma at constant lightness and hue until it fits the gamut.
+func (c OKLCH) chromaClippedLSRGB() LSRGB {
+	toLSRGB := func(lch OKLCH) LSRGB { return lch.OKLAB().CIEXYZ().LSRGB() }
+	if rgb := toLSRGB(c); rgb.InGamut() {
+		return rgb
+	}
+	// Bisect chroma, unlike GamutMappedLSRGB which may clip and shift hue near the gamut cusp.
+	cmin, cmax := float32(0), c.C
+	for cmax-cmin > 1e-4 {
+		c.C = 0.5 * (cmin + cmax)
+		if toLSRGB(c).InGamut() {
+			cmin = c.C
+		} else {
+			cmax = c.C
+		}
+	}
+	c.C = cmin
+	return toLSRGB(c).ClipToGamut()
+}
@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestRolloff(t *testing.T) {
+	const threshold = 0.8
+	below := OKLCH{L: 0.5, C: 0.1, H: 30}
+	if got := below.Rolloff(threshold); got != below {
+		t.Errorf("color below threshold changed: %+v", got)
+	}
+	prev := float32(0)
+	for _, l := range []float32{0.81, 0.9, 1, 1.5, 3} {
+		got := OKLCH{L: l, C: 0.2, H: 120}.Rolloff(threshold)
+		if got.L <= prev || got.L >= 1 {
+			t.Errorf("L=%v: rolled off lightness %v not monotonic in (%v,1)", l, got.L, prev)
+		}
+		if got.H != 120 {
+			t.Errorf("hue not preserved: %v", got.H)
+		}
+		prev = got.L
+	}
+
+	// A bright orange specular keeps its hue rather than clipping to yellow.
+	hdr := LSRGB{R: 4, G: 1.6, B: 0.4}
+	want := hdr.CIEXYZ().OKLAB().OKLCH().H
+	got := hdr.Rolloff(threshold)
+	if !got.InGamut() {
+		t.Fatalf("result out of gamut: %+v", got)
+	}
+	if h := got.CIEXYZ().OKLAB().OKLCH().H; math32.Abs(h-want) > 3 {
+		t.Errorf("hue shifted from %v to %v", want, h)
+	}
+	clippedHue := hdr.ClipToGamut().CIEXYZ().OKLAB().OKLCH().H
+	if math32.Abs(clippedHue-want) < 3 {
+		t.Errorf("expected clipping to shift hue, test color is not discriminating")
+	}
+}
+
+func TestRolloffThresholdRange(t *testing.T) {
+	for _, threshold := range []float32{-0.5, 0, 1, 2} {
+		for _, c := range []OKLCH{{}, {L: 0.5, C: 0.1, H: 30}, {L: 2, C: 0.2, H: 120}} {
+			got := c.Rolloff(threshold)
+			if math32.IsNaN(got.L) || math32.IsNaN(got.C) || got.L > math32.Max(c.L, 1) {
+				t.Errorf("Rolloff(%v) of %+v = %+v", threshold, c, got)
+			}
+		}
+	}
+	if got := (OKLCH{}).Rolloff(-0.5); got != (OKLCH{}) {
+		t.Errorf("black changed by negative threshold: %+v", got)
+	}
+}
@@ -0,0 +1,114 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+)
+
+// Colormap maps a normalized value t in [0,1] to a color. [Gradient] implements Colormap.
+type Colormap interface {
+	At(t float32) color.Color
+}
+
+// ColormapFunc adapts a function to the [Colormap] interface.
+type ColormapFunc func(t float32) color.Color
+
+// At returns fn(t).
+func (fn ColormapFunc) At(t float32) color.Color { return fn(t) }
+
+// ColorBand is a flat color covering normalized values in [Start, End).
+type ColorBand struct {
+	Start, End float32
+	Color      color.Color
+}
+
+// BandColormap maps values within its bands to flat colors and defers to Base elsewhere.
+// Bands are searched in order so earlier bands take precedence where they overlap.
+type BandColormap struct {
+	Bands []ColorBand
+	// Base is used for values outside all bands. If nil values outside all bands are transparent.
+	Base Colormap
+}
+
+// At returns the color of the first band containing t or Base's color.
+func (bm BandColormap) At(t float32) color.Color {
+	for _, b := range bm.Bands {
+		if t >= b.Start && t < b.End {
+			return b.Color
+		}
+	}
+	if bm.Base == nil {
+		return color.Transparent
+	}
+	return bm.Base.At(t)
+}
+
+// Normalization maps a data value v in the range [min,max] to [0,1]. Results outside [0,1] indicate v is out of range.
+type Normalization func(v, min, max float32) float32
+
+// NormLinear maps values linearly.
+func NormLinear(v, min, max float32) float32 {
+	if max == min {
+		return 0.5
+	}
+	return (v - min) / (max - min)
+}
+
+// NormLog maps values logarithmically, i.e: for precipitation or concentrations spanning orders of magnitude.
+// min must be positive. Non-positive values map below 0.
+func NormLog(v, min, max float32) float32 {
+	if v <= 0 {
+		return -1
+	}
+	return NormLinear(math32.Log(v), math32.Log(min), math32.Log(max))
+}
+
+// NormDiverging returns a normalization for diverging colormaps mapping center to 0.5, [min,center] to [0,0.5]
+// and [center,max] to [0.5,1], i.e: temperature anomalies around zero.
+func NormDiverging(center float32) Normalization {
+	return func(v, min, max float32) float32 {
+		if v < center {
+			if center == min {
+				return 0.5
+			}
+			return 0.5 * (v - min) / (center - min)
+		}
+		if max == center {
+			return 0.5
+		}
+		return 0.5 + 0.5*(v-center)/(max-center)
+	}
+}
+
+// Scale maps data values to colors by normalizing them into [0,1] and looking them up in a [Colormap].
+type Scale struct {
+	Colormap Colormap
+	// Min and Max are the data values mapped to the ends of the colormap.
+	Min, Max float32
+	// Norm is the normalization of data values. If nil [NormLinear] is used.
+	Norm Normalization
+	// Under and Over are the colors for values below Min and above Max.
+	// If nil out of range values are clamped to the ends of the colormap.
+	Under, Over color.Color
+}
+
+// Normalize returns the normalized position of data value v. Values out of range are not clamped.
+func (s Scale) Normalize(v float32) float32 {
+	if s.Norm == nil {
+		return NormLinear(v, s.Min, s.Max)
+	}
+	return s.Norm(v, s.Min, s.Max)
+}
+
+// At returns the color of data value v.
+func (s Scale) At(v float32) color.Color {
+	t := s.Normalize(v)
+	switch {
+	case t < 0 && s.Under != nil:
+		return s.Under
+	case t > 1 && s.Over != nil:
+		return s.Over
+	}
+	return s.Colormap.At(math32.Max(math32.Min(t, 1), 0))
+}
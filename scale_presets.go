@@ -0,0 +1,64 @@
+package colorspace
+
+import "image/color"
+
+// TemperatureAnomalyScale returns a diverging blue-white-red scale for temperature anomalies
+// in the range [-limit, limit] centered on zero, i.e: limit=5 for anomalies in °C or K.
+// Both halves have equal lightness ramps so warm and cold anomalies of the same magnitude
+// are equally prominent. Values beyond the limits are clamped.
+func TemperatureAnomalyScale(limit float32) Scale {
+	return Scale{
+		Min:  -limit,
+		Max:  limit,
+		Norm: NormDiverging(0),
+		Colormap: oklchGradient(
+			OKLCH{L: 0.35, C: 0.13, H: 265},
+			OKLCH{L: 0.68, C: 0.10, H: 245},
+			OKLCH{L: 0.97, C: 0, H: 0},
+			OKLCH{L: 0.68, C: 0.13, H: 40},
+			OKLCH{L: 0.35, C: 0.13, H: 25},
+		),
+	}
+}
+
+// NDVIScale returns a scale for the normalized difference vegetation index in [-1,1]:
+// water and snow (negative values) are blue, bare soil and rock (around 0 to 0.2) are tan
+// and vegetation is green getting darker with increasing density.
+func NDVIScale() Scale {
+	stops := []struct {
+		ndvi float32
+		c    OKLCH
+	}{
+		{-1, OKLCH{L: 0.40, C: 0.10, H: 255}},
+		{0, OKLCH{L: 0.88, C: 0.02, H: 240}},
+		{0.05, OKLCH{L: 0.82, C: 0.05, H: 80}},
+		{0.2, OKLCH{L: 0.72, C: 0.09, H: 75}},
+		{0.5, OKLCH{L: 0.70, C: 0.16, H: 130}},
+		{1, OKLCH{L: 0.38, C: 0.11, H: 145}},
+	}
+	g := Gradient{Lerp: LerpOKLCH, Stops: make([]GradientStop, len(stops))}
+	for i, s := range stops {
+		g.Stops[i] = GradientStop{Pos: (s.ndvi + 1) / 2, Color: oklchToSRGB(s.c)}
+	}
+	return Scale{Min: -1, Max: 1, Colormap: g}
+}
+
+// PrecipitationScale returns a logarithmic scale for precipitation amounts in (0, max],
+// i.e: millimeters per hour or accumulated millimeters. Light precipitation of 1/1000th of max
+// is pale blue and colors darken through blue to purple for the heaviest amounts.
+// Amounts below max/1000 are transparent, which includes dry areas (zero or less precipitation)
+// as well as trace amounts. Set Under to a color to show trace amounts and dry areas alike.
+func PrecipitationScale(max float32) Scale {
+	return Scale{
+		Min:  max / 1000,
+		Max:  max,
+		Norm: NormLog,
+		Colormap: oklchGradient(
+			OKLCH{L: 0.95, C: 0.04, H: 210},
+			OKLCH{L: 0.78, C: 0.10, H: 225},
+			OKLCH{L: 0.58, C: 0.15, H: 255},
+			OKLCH{L: 0.40, C: 0.17, H: 295},
+		),
+		Under: color.Transparent,
+	}
+}
@@ -0,0 +1,43 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestScalePresets(t *testing.T) {
+	anomaly := TemperatureAnomalyScale(5)
+	white := ColorToSRGB(anomaly.At(0))
+	if white.R < 0.95 || white.G < 0.95 || white.B < 0.95 {
+		t.Errorf("zero anomaly is %+v, want white", white)
+	}
+	cold, hot := ColorToSRGB(anomaly.At(-3)), ColorToSRGB(anomaly.At(3))
+	if cold.B <= cold.R || hot.R <= hot.B {
+		t.Errorf("cold anomaly %+v should be blue and hot anomaly %+v red", cold, hot)
+	}
+	if dl := colorToOKLCH(anomaly.At(-3)).L - colorToOKLCH(anomaly.At(3)).L; dl > 0.02 || dl < -0.02 {
+		t.Errorf("anomaly scale lightness is not symmetric: difference %v", dl)
+	}
+
+	ndvi := NDVIScale()
+	water, soil, forest := colorToOKLCH(ndvi.At(-0.5)), colorToOKLCH(ndvi.At(0.15)), colorToOKLCH(ndvi.At(0.9))
+	if !(water.H > 200 && water.H < 280) || !(soil.H > 50 && soil.H < 100) || !(forest.H > 120 && forest.H < 160) {
+		t.Errorf("NDVI hues: water %v, soil %v, vegetation %v", water.H, soil.H, forest.H)
+	}
+
+	precip := PrecipitationScale(100)
+	if precip.At(0) != color.Transparent {
+		t.Error("no precipitation should be transparent")
+	}
+	if precip.At(0.05) != color.Transparent {
+		t.Error("trace precipitation below the scale should be transparent")
+	}
+	prev := float32(2)
+	for _, v := range []float32{0.1, 1, 10, 100} {
+		l := colorToOKLCH(precip.At(v)).L
+		if l >= prev {
+			t.Errorf("precipitation %v lightness %v not darker than lighter amounts", v, l)
+		}
+		prev = l
+	}
+}
@@ -0,0 +1,55 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestScale(t *testing.T) {
+	gray := NewGradient(LerpSRGB, color.Black, color.White)
+	s := Scale{Colormap: gray, Min: 10, Max: 20, Over: color.RGBA{R: 255, A: 255}}
+	for _, test := range []struct {
+		v    float32
+		want SRGB
+	}{
+		{v: 10, want: SRGB{}},
+		{v: 15, want: SRGB{R: 0.5, G: 0.5, B: 0.5}},
+		{v: 5, want: SRGB{}},
+		{v: 25, want: SRGB{R: 1}},
+	} {
+		got := ColorToSRGB(s.At(test.v))
+		if sqdist(got.vec(), test.want.vec()) > 1e-4 {
+			t.Errorf("v=%v: got %+v, want %+v", test.v, got, test.want)
+		}
+	}
+	if n := NormLog(100, 1, 10000); math32.Abs(n-0.5) > 1e-5 {
+		t.Errorf("log normalization got %v, want 0.5", n)
+	}
+	div := NormDiverging(0)
+	for _, test := range []struct{ v, want float32 }{{-2, 0}, {0, 0.5}, {-1, 0.25}, {2, 0.75}, {4, 1}} {
+		if got := div(test.v, -2, 4); math32.Abs(got-test.want) > 1e-5 {
+			t.Errorf("diverging normalization of %v got %v, want %v", test.v, got, test.want)
+		}
+	}
+}
+
+func TestFalseColor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	img.SetRGBA(0, 0, color.RGBA{A: 255})
+	img.SetRGBA(1, 0, color.RGBA{R: 102, G: 102, B: 102, A: 255}) // 40% signal level.
+	img.SetRGBA(2, 0, color.RGBA{R: 180, G: 180, B: 180, A: 255})
+	got := FalseColor(img, FalseColorScale())
+	want := []color.RGBA64{
+		{R: 128 * 0x101, B: 200 * 0x101, A: 0xffff},
+		{G: 200 * 0x101, B: 40 * 0x101, A: 0xffff},
+		{R: 180 * 0x101, G: 180 * 0x101, B: 180 * 0x101, A: 0xffff},
+	}
+	for x, w := range want {
+		if c := got.RGBA64At(x, 0); c != w {
+			t.Errorf("pixel %d: got %v, want %v", x, c, w)
+		}
+	}
+}
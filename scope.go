@@ -0,0 +1,98 @@
+package colorspace
+
+import (
+	"image"
+
+	"github.com/chewxy/math32"
+)
+
+// ScopeData is a 2D histogram of pixel counts as displayed by video scopes.
+// Counts is laid out in row-major order with row 0 at the top.
+type ScopeData struct {
+	Width, Height int
+	Counts        []uint32
+}
+
+// Vectorscope returns the vectorscope of img: a size×size histogram of the Rec. 709 chroma (Cb, Cr)
+// of each pixel. Cb increases to the right and Cr upwards with the neutral axis at the center; the
+// edges of the plot correspond to the maximum chroma of ±0.5. Fully transparent pixels are ignored.
+func Vectorscope(img image.Image, size int) *ScopeData {
+	if size <= 0 {
+		panic("vectorscope size must be positive")
+	}
+	scope := newScopeData(size, size)
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			_, cb, cr := rec709YCbCr(c)
+			scope.add(histBin(cb+0.5, size), histBin(0.5-cr, size))
+		}
+	}
+	return scope
+}
+
+// Waveform returns the luma waveform of img: for each image column a histogram of height bins of the
+// Rec. 709 luma Y′ of the pixels in that column. Row 0 corresponds to Y′=1 (white) and the last row to
+// Y′=0 (black). Fully transparent pixels are ignored.
+func Waveform(img image.Image, height int) *ScopeData {
+	if height <= 0 {
+		panic("waveform height must be positive")
+	}
+	bounds := img.Bounds()
+	scope := newScopeData(bounds.Dx(), height)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c, a := unpremultiplied(img.At(x, y))
+			if a == 0 {
+				continue
+			}
+			luma, _, _ := rec709YCbCr(c)
+			scope.add(x-bounds.Min.X, histBin(1-luma, height))
+		}
+	}
+	return scope
+}
+
+// Image renders the scope as a grayscale image. Counts are log-scaled and normalized
+// to the largest count so sparse traces remain visible next to dense ones.
+func (s *ScopeData) Image() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, s.Width, s.Height))
+	var max uint32
+	for _, c := range s.Counts {
+		if c > max {
+			max = c
+		}
+	}
+	if max == 0 {
+		return img
+	}
+	norm := 1 / math32.Log1p(float32(max))
+	for i, c := range s.Counts {
+		img.Pix[(i/s.Width)*img.Stride+i%s.Width] = uint8(math32.Log1p(float32(c))*norm*0xff + 0.5)
+	}
+	return img
+}
+
+// At returns the count at column x and row y.
+func (s *ScopeData) At(x, y int) uint32 {
+	return s.Counts[y*s.Width+x]
+}
+
+func newScopeData(width, height int) *ScopeData {
+	return &ScopeData{Width: width, Height: height, Counts: make([]uint32, width*height)}
+}
+
+func (s *ScopeData) add(x, y int) {
+	s.Counts[y*s.Width+x]++
+}
+
+// rec709YCbCr returns the Rec. 709 luma and color difference components of gamma-encoded c.
+// Luma is in [0,1] and the color differences in [-0.5,0.5].
+func rec709YCbCr(c SRGB) (y, cb, cr float32) {
+	ycc := encodeYCbCr(c.vec(), YCbCrBT709, YCbCrFull)
+	return ycc.Y, ycc.Cb, ycc.Cr
+}
@@ -0,0 +1,41 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestScopes(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.SetRGBA(x, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		img.SetRGBA(x, 1, color.RGBA{R: 255, A: 255})
+	}
+	wf := Waveform(img, 10)
+	if wf.Width != 4 || wf.At(0, 0) != 1 {
+		t.Errorf("white should land in the top waveform row, got %v", wf.Counts)
+	}
+	// Red has a luma of 0.2126.
+	if wf.At(3, 7) != 1 {
+		t.Errorf("red should land in row 7 of the waveform, got %v", wf.Counts)
+	}
+	vs := Vectorscope(img, 11)
+	if vs.At(5, 5) != 4 {
+		t.Errorf("white should land in the vectorscope center, got %d", vs.At(5, 5))
+	}
+	// Red has positive Cr (up) and negative Cb (left).
+	var redX, redY int
+	for i, c := range vs.Counts {
+		if c > 0 && i != 5*11+5 {
+			redX, redY = i%11, i/11
+		}
+	}
+	if redX >= 5 || redY >= 5 {
+		t.Errorf("red should lie in the upper left quadrant, got (%d,%d)", redX, redY)
+	}
+	rendered := vs.Image()
+	if rendered.GrayAt(5, 5).Y != 0xff || rendered.GrayAt(0, 10).Y != 0 {
+		t.Errorf("unexpected vectorscope rendering")
+	}
+}
@@ -0,0 +1,86 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// SCRGB is the extended-range linear-light sRGB color space (scRGB) used for HDR intermediate math.
+// It shares the primaries and white point of [LSRGB] but values outside [0,1] are deliberate:
+// values above 1 are brighter than SDR reference white (1.0 corresponds to 80 nits) and negative
+// values represent colors outside the sRGB gamut. Conversion out of SCRGB requires an explicit [GamutPolicy]
+// so HDR values are never silently clipped.
+type SCRGB struct {
+	R float32 // Red.
+	G float32 // Green.
+	B float32 // Blue.
+}
+
+// GamutPolicy selects how colors outside of a destination gamut are handled on conversion.
+type GamutPolicy uint8
+
+const (
+	// GamutPreserve leaves out of gamut values untouched.
+	GamutPreserve GamutPolicy = iota
+	// GamutClip clamps each channel to [0,1]. Fast but shifts hue of out of gamut colors.
+	GamutClip
+	// GamutNormalize clamps negative channels to zero and divides all channels by the largest one
+	// if it exceeds 1. This preserves the ratio between channels, and thus hue, of bright colors.
+	GamutNormalize
+	// GamutMapOKLCH reduces chroma in [OKLCH] at constant lightness and hue. See [OKLCH.GamutMappedLSRGB].
+	GamutMapOKLCH
+)
+
+// scRGBReferenceWhite is the luminance in nits (cd/m²) of an scRGB value of 1.
+const scRGBReferenceWhite = 80
+
+func (c SCRGB) vec() ms3.Vec       { return ms3.Vec{X: c.R, Y: c.G, Z: c.B} }
+func (c SCRGB) Array() [3]float32  { return c.vec().Array() }
+func (c LSRGB) SCRGB() SCRGB       { return SCRGB(c) }
+func (c CIEXYZ) SCRGB() SCRGB      { return SCRGB(c.LSRGB()) }
+func (c SCRGB) CIEXYZ() CIEXYZ     { return LSRGB(c).CIEXYZ() }
+func (c SCRGB) Luminance() float32 { return c.CIEXYZ().Y }
+
+// Nits returns the absolute luminance of the color in nits (cd/m²) assuming 1 corresponds to 80 nits.
+func (c SCRGB) Nits() float32 { return scRGBReferenceWhite * c.Luminance() }
+
+// InGamut reports whether the color is representable in SDR sRGB, that is, all channels in [0,1].
+func (c SCRGB) InGamut() bool { return LSRGB(c).InGamut() }
+
+// Scale multiplies all channels by f, i.e: to apply exposure in linear light.
+func (c SCRGB) Scale(f float32) SCRGB {
+	return SCRGB{R: f * c.R, G: f * c.G, B: f * c.B}
+}
+
+// LSRGB converts the color to linear sRGB handling values outside [0,1] with the given policy.
+func (c SCRGB) LSRGB(policy GamutPolicy) LSRGB {
+	lin := LSRGB(c)
+	switch policy {
+	case GamutPreserve:
+		return lin
+	case GamutClip:
+		return lin.ClipToGamut()
+	case GamutNormalize:
+		lin = LSRGB{R: math32.Max(lin.R, 0), G: math32.Max(lin.G, 0), B: math32.Max(lin.B, 0)}
+		if max := lin.vec().Max(); max > 1 {
+			lin = LSRGB{R: lin.R / max, G: lin.G / max, B: lin.B / max}
+		}
+		return lin
+	case GamutMapOKLCH:
+		if lin.InGamut() {
+			return lin
+		}
+		return lin.CIEXYZ().OKLAB().OKLCH().GamutMappedLSRGB().OKLAB().CIEXYZ().LSRGB().ClipToGamut()
+	}
+	panic("invalid gamut policy")
+}
+
+// SRGB converts the color to gamma-encoded sRGB handling values outside [0,1] with the given policy.
+// With [GamutPreserve] the sRGB transfer function is extended to negative values by mirroring.
+func (c SCRGB) SRGB(policy GamutPolicy) SRGB {
+	return c.LSRGB(policy).SRGB()
+}
+
+func (from SCRGB) Lerp(to SCRGB, v float32) SCRGB {
+	return SCRGB(LSRGB(from).Lerp(LSRGB(to), v))
+}
@@ -0,0 +1,25 @@
+package colorspace
+
+import "testing"
+
+func TestSCRGB(t *testing.T) {
+	hdr := SCRGB{R: 4, G: 2, B: 1}
+	if hdr.InGamut() {
+		t.Error("expected HDR color to be out of SDR gamut")
+	}
+	if got := hdr.LSRGB(GamutPreserve); got != (LSRGB{R: 4, G: 2, B: 1}) {
+		t.Errorf("expected preserved values, got %v", got)
+	}
+	if got := hdr.LSRGB(GamutNormalize); got != (LSRGB{R: 1, G: 0.5, B: 0.25}) {
+		t.Errorf("expected normalized values, got %v", got)
+	}
+	if got := hdr.LSRGB(GamutClip); got != (LSRGB{R: 1, G: 1, B: 1}) {
+		t.Errorf("expected clipped values, got %v", got)
+	}
+	if got := hdr.LSRGB(GamutMapOKLCH); !got.InGamut() {
+		t.Errorf("expected gamut mapped color in gamut, got %v", got)
+	}
+	if nits := (SCRGB{R: 1, G: 1, B: 1}).Nits(); nits < 79.9 || nits > 80.1 {
+		t.Errorf("expected reference white at 80 nits, got %v", nits)
+	}
+}
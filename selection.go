@@ -0,0 +1,125 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/soypat/geometry/ms1"
+)
+
+// OKLCHRegion is a region of [OKLCH] color space bounded by hue, chroma and lightness
+// ranges with soft (feathered) edges. It is used to select colors for targeted edits
+// with [SelectionMask] or to protect them from global edits.
+type OKLCHRegion struct {
+	// HueStart and HueEnd delimit the hue range in degrees going in increasing hue
+	// direction from HueStart to HueEnd, wrapping around 360 if HueEnd < HueStart.
+	// A range of 360 degrees or more spans all hues.
+	HueStart, HueEnd float32
+	// MinChroma and MaxChroma delimit the chroma range.
+	MinChroma, MaxChroma float32
+	// MinLightness and MaxLightness delimit the lightness range.
+	MinLightness, MaxLightness float32
+	// Feathers are the widths of the soft falloff outside of each range.
+	// A zero feather yields a hard edge.
+	HueFeather, ChromaFeather, LightnessFeather float32
+}
+
+// SkinToneRegion returns a region spanning typical human skin tones across a wide range of
+// complexions. It is intended to protect faces from global saturation and hue edits and can be
+// tuned by modifying the returned value.
+func SkinToneRegion() OKLCHRegion {
+	return OKLCHRegion{
+		HueStart:         25,
+		HueEnd:           85,
+		MinChroma:        0.02,
+		MaxChroma:        0.16,
+		MinLightness:     0.25,
+		MaxLightness:     0.92,
+		HueFeather:       15,
+		ChromaFeather:    0.03,
+		LightnessFeather: 0.08,
+	}
+}
+
+// Weight returns the membership of c in the region in [0,1]. Colors inside all ranges
+// have weight 1 and the weight falls off smoothly to 0 within the feather widths.
+// Achromatic colors have no hue and so only belong to regions spanning all hues.
+func (r OKLCHRegion) Weight(c OKLCH) float32 {
+	const eps = 0.000004
+	hueWeight := float32(1)
+	if c.C < eps {
+		if !r.allHues() {
+			hueWeight = 0
+		}
+	} else {
+		hueWeight = falloffWeight(r.hueDistance(c.H), r.HueFeather)
+	}
+	return rangeWeight(c.L, r.MinLightness, r.MaxLightness, r.LightnessFeather) *
+		rangeWeight(c.C, r.MinChroma, r.MaxChroma, r.ChromaFeather) * hueWeight
+}
+
+// allHues reports whether the region's hue range spans all hues.
+func (r OKLCHRegion) allHues() bool { return r.HueEnd-r.HueStart >= 360 }
+
+// hueDistance returns the angular distance of hue h in degrees to the region's hue range.
+func (r OKLCHRegion) hueDistance(h float32) float32 {
+	if r.allHues() {
+		return 0
+	}
+	h = wrapHue(h)
+	start, end := wrapHue(r.HueStart), wrapHue(r.HueEnd)
+	span := wrapHue(end - start)
+	if wrapHue(h-start) <= span {
+		return 0
+	}
+	dStart := wrapHue(start - h)
+	dEnd := wrapHue(h - end)
+	if dStart < dEnd {
+		return dStart
+	}
+	return dEnd
+}
+
+// rangeWeight returns 1 inside [min,max] and a smooth falloff to 0 within feather outside of it.
+func rangeWeight(v, min, max, feather float32) float32 {
+	switch {
+	case v < min:
+		return falloffWeight(min-v, feather)
+	case v > max:
+		return falloffWeight(v-max, feather)
+	}
+	return 1
+}
+
+// falloffWeight returns a smoothstep falloff from 1 at d=0 to 0 at d>=feather.
+func falloffWeight(d, feather float32) float32 {
+	if d <= 0 {
+		return 1
+	} else if d >= feather {
+		return 0
+	}
+	t := 1 - ms1.Clamp(d/feather, 0, 1)
+	return t * t * (3 - 2*t)
+}
+
+// SelectionMask returns a mask of img where each pixel's alpha is the weight of
+// its color in the region. Use [InvertMask] to obtain a protection mask instead.
+func SelectionMask(img image.Image, r OKLCHRegion) *image.Alpha16 {
+	bounds := img.Bounds()
+	mask := image.NewAlpha16(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			w := r.Weight(colorToOKLCH(img.At(x, y)))
+			mask.SetAlpha16(x, y, color.Alpha16{A: uint16(w*0xffff + 0.5)})
+		}
+	}
+	return mask
+}
+
+// InvertMask inverts the mask in place so that selected pixels become unselected and vice versa.
+func InvertMask(mask *image.Alpha16) {
+	for i := 0; i+1 < len(mask.Pix); i += 2 {
+		mask.Pix[i] = ^mask.Pix[i]
+		mask.Pix[i+1] = ^mask.Pix[i+1]
+	}
+}
@@ -0,0 +1,50 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestSkinToneRegion(t *testing.T) {
+	region := SkinToneRegion()
+	skins := []color.RGBA{
+		{R: 0xf1, G: 0xc2, B: 0x7d}, {R: 0xe0, G: 0xac, B: 0x69},
+		{R: 0xc6, G: 0x86, B: 0x42}, {R: 0x8d, G: 0x55, B: 0x24},
+	}
+	for _, c := range skins {
+		if w := region.Weight(colorToOKLCH(c)); w < 0.99 {
+			t.Errorf("expected skin tone %v to be selected, got weight %v", c, w)
+		}
+	}
+	nonSkins := []color.RGBA{{B: 255}, {G: 255}, {R: 128, G: 128, B: 128}}
+	for _, c := range nonSkins {
+		if w := region.Weight(colorToOKLCH(c)); w > 0.01 {
+			t.Errorf("expected %v not to be selected, got weight %v", c, w)
+		}
+	}
+	wrapping := OKLCHRegion{HueStart: 350, HueEnd: 10, MaxChroma: 1, MaxLightness: 1}
+	if d := wrapping.hueDistance(5); d != 0 {
+		t.Errorf("expected hue inside wrapping range, got distance %v", d)
+	}
+	if d := wrapping.hueDistance(20); d != 10 {
+		t.Errorf("expected hue distance 10, got %v", d)
+	}
+	// Grays have no hue: they are not reds but are neutrals.
+	gray := colorToOKLCH(color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	if w := wrapping.Weight(gray); w != 0 {
+		t.Errorf("expected gray not to be selected as red, got weight %v", w)
+	}
+	if w := wrapping.Weight(OKLCH{L: 0.6, C: 0.2, H: 5}); w != 1 {
+		t.Errorf("expected red to be selected, got weight %v", w)
+	}
+	neutrals := OKLCHRegion{HueEnd: 360, MaxChroma: 0.01, MaxLightness: 1}
+	if w := neutrals.Weight(gray); w != 1 {
+		t.Errorf("expected gray to be selected as neutral, got weight %v", w)
+	}
+	allHues := OKLCHRegion{HueEnd: 360, MaxChroma: 1, MaxLightness: 1}
+	for _, h := range []float32{0, 120, 359} {
+		if w := allHues.Weight(OKLCH{L: 0.5, C: 0.1, H: h}); w != 1 {
+			t.Errorf("expected hue %v to be selected by all hue region, got weight %v", h, w)
+		}
+	}
+}
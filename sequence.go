@@ -0,0 +1,139 @@
+package colorspace
+
+import (
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// SmoothColorOrder returns an ordering of colors that approximately minimizes
+// the total [OKLCH] path length when visiting them in sequence, starting at colors[0].
+// Unlike OKLAB distance, OKLCH path length measures hue changes along the hue circle
+// so large hue swings between saturated colors are penalized.
+// Use it to reorder series or animation keyframes so consecutive colors change gradually
+// instead of reversing hue back and forth. The result is a permutation of the indices of colors.
+func SmoothColorOrder(colors []color.Color) []int {
+	n := len(colors)
+	lchs := make([]OKLCH, n)
+	for i, c := range colors {
+		lchs[i] = colorToOKLCH(c)
+	}
+	order := make([]int, 0, n)
+	if n == 0 {
+		return order
+	}
+	// Nearest neighbor construction.
+	visited := make([]bool, n)
+	current := 0
+	for len(order) < n {
+		order = append(order, current)
+		visited[current] = true
+		next := -1
+		var best float32
+		for j := range lchs {
+			if visited[j] {
+				continue
+			}
+			if d := oklchPathLength(lchs[current], lchs[j]); next < 0 || d < best {
+				next, best = j, d
+			}
+		}
+		current = next
+	}
+	// 2-opt refinement of the open path keeping the first color fixed.
+	dist := func(i, j int) float32 { return oklchPathLength(lchs[order[i]], lchs[order[j]]) }
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				before := dist(i-1, i)
+				after := dist(i-1, j)
+				if j+1 < n {
+					before += dist(j, j+1)
+					after += dist(i, j+1)
+				}
+				if after < before-epsUnit {
+					for a, b := i, j; a < b; a, b = a+1, b-1 {
+						order[a], order[b] = order[b], order[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	return order
+}
+
+// SmoothColorSequence minimally adjusts a sequence of colors, keeping their order, to reduce the
+// total [OKLCH] path length and remove abrupt hue reversals between consecutive colors.
+// Resulting colors are gamut mapped to sRGB and none is further than maxDeltaE
+// (OKLAB Euclidean distance, see [OKLAB.DeltaE]) from its original.
+func SmoothColorSequence(colors []color.Color, maxDeltaE float32) []SRGB {
+	const iterations = 64
+	n := len(colors)
+	orig := make([]OKLAB, n)
+	current := make([]OKLCH, n)
+	result := make([]SRGB, n)
+	for i, c := range colors {
+		result[i] = ColorToSRGB(c)
+		orig[i] = colorToOKLAB(c)
+		current[i] = orig[i].OKLCH()
+	}
+	if maxDeltaE <= 0 {
+		return result
+	}
+	for iter := 0; iter < iterations; iter++ {
+		for i := range current {
+			// Move towards the OKLCH midpoint of neighbors which shortens the path.
+			var target OKLCH
+			switch {
+			case n == 1:
+				target = current[i]
+			case i == 0:
+				target = current[1]
+			case i == n-1:
+				target = current[n-2]
+			default:
+				target = current[i-1].Lerp(current[i+1], 0.5)
+			}
+			moved := current[i].Lerp(target, 0.5).OKLAB().vec()
+			// Project back into the allowed neighborhood of the original color.
+			offset := ms3.Sub(moved, orig[i].vec())
+			if norm := ms3.Norm(offset); norm > maxDeltaE {
+				moved = ms3.Add(orig[i].vec(), ms3.Scale(maxDeltaE/norm, offset))
+			}
+			current[i] = OKLAB{L: moved.X, A: moved.Y, B: moved.Z}.OKLCH()
+		}
+	}
+	for i, c := range current {
+		mapped := oklchToSRGB(c)
+		if mapped.LSRGB().CIEXYZ().OKLAB().DeltaE(orig[i]) <= maxDeltaE {
+			result[i] = mapped
+			continue
+		}
+		// Gamut mapping moved the color too far: bisect towards the original, which is in gamut.
+		offset := ms3.Sub(c.OKLAB().vec(), orig[i].vec())
+		lo, hi := float32(0), float32(1)
+		for k := 0; k < 20; k++ {
+			mid := 0.5 * (lo + hi)
+			v := ms3.Add(orig[i].vec(), ms3.Scale(mid, offset))
+			mapped = oklchToSRGB(OKLAB{L: v.X, A: v.Y, B: v.Z}.OKLCH())
+			if mapped.LSRGB().CIEXYZ().OKLAB().DeltaE(orig[i]) <= maxDeltaE {
+				lo, result[i] = mid, mapped
+			} else {
+				hi = mid
+			}
+		}
+	}
+	return result
+}
+
+// oklchPathLength returns the length of the OKLCH interpolation path between two colors,
+// approximating the hue arc by the geometric mean of their chromas. Hue of achromatic colors
+// does not contribute.
+func oklchPathLength(c1, c2 OKLCH) float32 {
+	dh := math32.Abs(hueDelta(c1.H, c2.H)) * math32.Pi / 180
+	arc := math32.Sqrt(math32.Max(c1.C*c2.C, 0)) * dh
+	return math32.Sqrt(sq(c1.L-c2.L) + sq(c1.C-c2.C) + sq(arc))
+}
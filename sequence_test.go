@@ -0,0 +1,44 @@
+package colorspace
+
+import (
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+func TestSmoothColorOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	shuffled := make([]color.Color, len(jet))
+	copy(shuffled, jet)
+	rng.Shuffle(len(shuffled)-1, func(i, j int) { shuffled[i+1], shuffled[j+1] = shuffled[j+1], shuffled[i+1] })
+	pathLength := func(colors []color.Color) (length float32) {
+		for i := 1; i < len(colors); i++ {
+			length += oklchPathLength(colorToOKLCH(colors[i-1]), colorToOKLCH(colors[i]))
+		}
+		return length
+	}
+	order := SmoothColorOrder(shuffled)
+	reordered := make([]color.Color, len(order))
+	for i, idx := range order {
+		reordered[i] = shuffled[idx]
+	}
+	if order[0] != 0 {
+		t.Errorf("expected first color to remain first")
+	}
+	if before, after := pathLength(shuffled), pathLength(reordered); after >= before {
+		t.Errorf("expected shorter path after reorder, got %v >= %v", after, before)
+	}
+	const maxDeltaE = 0.05
+	smoothed := SmoothColorSequence(shuffled, maxDeltaE)
+	smoothedColors := make([]color.Color, len(smoothed))
+	for i, c := range smoothed {
+		smoothedColors[i] = c
+		orig := ColorToSRGB(shuffled[i]).LSRGB().CIEXYZ().OKLAB()
+		if d := c.LSRGB().CIEXYZ().OKLAB().DeltaE(orig); d > maxDeltaE {
+			t.Errorf("color %d moved %v, more than %v", i, d, maxDeltaE)
+		}
+	}
+	if before, after := pathLength(shuffled), pathLength(smoothedColors); after >= before {
+		t.Errorf("expected shorter path after smoothing, got %v >= %v", after, before)
+	}
+}
@@ -0,0 +1,56 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestColorSignature(t *testing.T) {
+	split := func(c1, c2 color.Color, frac float32) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 20; x++ {
+				c := c1
+				if float32(x) >= frac*20 {
+					c = c2
+				}
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	darkRed := color.RGBA{R: 240, G: 10, A: 255}
+	a := ImageSignature(split(red, blue, 0.5), 4)
+	b := ImageSignature(split(darkRed, blue, 0.55), 4)
+	c := ImageSignature(split(color.White, blue, 0.2), 4)
+	if len(a.Colors) != 2 || a.Weights[0]+a.Weights[1] != 1 {
+		t.Fatalf("unexpected signature %+v", a)
+	}
+	if d := a.Distance(a); d > 1e-3 {
+		t.Errorf("distance to self should be zero, got %v", d)
+	}
+	dab, dac := a.Distance(b), a.Distance(c)
+	if dab >= dac {
+		t.Errorf("similar images should be closer: d(a,b)=%v d(a,c)=%v", dab, dac)
+	}
+	if dba := b.Distance(a); dba-dab > 1e-5 || dab-dba > 1e-5 {
+		t.Errorf("distance not symmetric: %v != %v", dab, dba)
+	}
+
+	data, _ := a.MarshalBinary()
+	if len(data) != 8 {
+		t.Fatalf("expected 4 bytes per color, got %d", len(data))
+	}
+	var decoded ColorSignature
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if d := a.Distance(decoded); d > 0.02 {
+		t.Errorf("decoded signature too far from original: %v", d)
+	}
+	if err := decoded.UnmarshalBinary(data[:3]); err == nil {
+		t.Error("expected error decoding truncated signature")
+	}
+}
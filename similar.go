@@ -0,0 +1,79 @@
+package colorspace
+
+import (
+	"image"
+
+	"github.com/chewxy/math32"
+)
+
+// fingerprintSize is the number of grid cells per side of an [ImageFingerprint].
+const fingerprintSize = 16
+
+// ImageFingerprint is a downsampled [OKLAB] grid of an image for fast near-duplicate detection,
+// i.e: of UI screenshots differing only in compression artifacts, antialiasing or scaling.
+// Compute fingerprints once with [NewImageFingerprint] to compare many images against each other.
+type ImageFingerprint struct {
+	aspect float32
+	cells  [fingerprintSize * fingerprintSize]OKLAB
+}
+
+// NewImageFingerprint returns the fingerprint of img. Each of the 16x16 cells is the average color of
+// the part of the image it covers computed in linear light from at most 8x8 evenly spaced samples.
+func NewImageFingerprint(img image.Image) ImageFingerprint {
+	const samples = 8
+	b := img.Bounds()
+	var f ImageFingerprint
+	if b.Empty() {
+		return f
+	}
+	f.aspect = float32(b.Dx()) / float32(b.Dy())
+	for cy := 0; cy < fingerprintSize; cy++ {
+		y0, y1 := b.Min.Y+cy*b.Dy()/fingerprintSize, b.Min.Y+(cy+1)*b.Dy()/fingerprintSize
+		for cx := 0; cx < fingerprintSize; cx++ {
+			x0, x1 := b.Min.X+cx*b.Dx()/fingerprintSize, b.Min.X+(cx+1)*b.Dx()/fingerprintSize
+			// Cells of images smaller than the grid cover at least one pixel.
+			if y1 == y0 {
+				y1++
+			}
+			if x1 == x0 {
+				x1++
+			}
+			var sum LinearRGBA
+			for sy := 0; sy < samples; sy++ {
+				y := y0 + (2*sy+1)*(y1-y0)/(2*samples)
+				for sx := 0; sx < samples; sx++ {
+					x := x0 + (2*sx+1)*(x1-x0)/(2*samples)
+					c := PremultiplyLinear(img.At(x, y))
+					sum.R += c.R
+					sum.G += c.G
+					sum.B += c.B
+					sum.A += c.A
+				}
+			}
+			avg, _ := sum.Unpremultiply()
+			f.cells[cy*fingerprintSize+cx] = avg.CIEXYZ().OKLAB()
+		}
+	}
+	return f
+}
+
+// Distance returns the mean [OKLAB] Euclidean distance between corresponding cells of the fingerprints.
+// It is +Inf for images whose aspect ratios differ by more than 5%.
+func (f ImageFingerprint) Distance(other ImageFingerprint) float32 {
+	if math32.Abs(f.aspect-other.aspect) > 0.05*math32.Max(f.aspect, other.aspect) {
+		return math32.Inf(1)
+	}
+	var sum float32
+	for i, c := range f.cells {
+		sum += c.DeltaE(other.cells[i])
+	}
+	return sum / float32(len(f.cells))
+}
+
+// Similar reports whether a and b are near-duplicates: images with about the same aspect ratio whose
+// downsampled OKLAB grids differ on average by at most tol. A tol of 0.01 accepts recompressed or rescaled
+// copies of a screenshot while telling apart screenshots with different content.
+// See [ImageFingerprint] to compare many images.
+func Similar(a, b image.Image, tol float32) bool {
+	return NewImageFingerprint(a).Distance(NewImageFingerprint(b)) <= tol
+}
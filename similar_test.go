@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestSimilar(t *testing.T) {
+	screenshot := func(w, h int, button color.Color, noise uint8) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 250 - noise, G: 250, B: 250, A: 255}), image.Point{}, draw.Src)
+		draw.Draw(img, image.Rect(w/10, h/10, w/2, h/4), image.NewUniform(button), image.Point{}, draw.Src)
+		draw.Draw(img, image.Rect(0, h*9/10, w, h), image.NewUniform(color.RGBA{R: 30, G: 30, B: 40, A: 255}), image.Point{}, draw.Src)
+		return img
+	}
+	blue := color.RGBA{R: 30, G: 90, B: 220, A: 255}
+	orig := screenshot(320, 200, blue, 0)
+	const tol = 0.01
+	if !Similar(orig, screenshot(320, 200, blue, 3), tol) {
+		t.Error("slightly different background should be similar")
+	}
+	if !Similar(orig, screenshot(640, 400, blue, 0), tol) {
+		t.Error("rescaled screenshot should be similar")
+	}
+	if Similar(orig, screenshot(320, 200, color.RGBA{R: 220, G: 40, B: 40, A: 255}, 0), tol) {
+		t.Error("different button color should not be similar")
+	}
+	if Similar(orig, screenshot(200, 320, blue, 0), tol) {
+		t.Error("different aspect ratio should not be similar")
+	}
+	f := NewImageFingerprint(orig)
+	if d := f.Distance(f); d != 0 {
+		t.Errorf("self distance %v", d)
+	}
+	// Images smaller than the grid.
+	tiny := screenshot(4, 4, blue, 0)
+	if !Similar(tiny, tiny, 0) {
+		t.Error("tiny image not similar to itself")
+	}
+}
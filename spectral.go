@@ -0,0 +1,285 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// Spectrum is a sampled spectral distribution such as the reflectance of a
+// surface (in [0,1]) or the relative power of an illuminant.
+// Values[i] is the sample at wavelength Start+i*Step in nanometers.
+type Spectrum struct {
+	Start  float32
+	Step   float32
+	Values []float32
+}
+
+// At returns the spectrum value at the given wavelength in nanometers
+// by linear interpolation. Wavelengths outside the sampled range return 0.
+func (s Spectrum) At(wavelength float32) float32 {
+	if len(s.Values) == 0 || s.Step <= 0 {
+		return 0
+	}
+	pos := (wavelength - s.Start) / s.Step
+	last := float32(len(s.Values) - 1)
+	if pos < 0 || pos > last {
+		return 0
+	}
+	i := int(pos)
+	if float32(i) == last {
+		return s.Values[i]
+	}
+	frac := pos - float32(i)
+	return s.Values[i] + frac*(s.Values[i+1]-s.Values[i])
+}
+
+// SpectrumD65 returns the relative spectral power distribution of CIE standard illuminant D65
+// from 380nm to 780nm in 10nm steps.
+func SpectrumD65() Spectrum {
+	return Spectrum{Start: cmfStart, Step: cmfStep, Values: append([]float32{}, spdD65[:]...)}
+}
+
+// SpectrumA returns the relative spectral power distribution of CIE standard illuminant A,
+// representative of incandescent tungsten lighting, from 380nm to 780nm in 10nm steps.
+func SpectrumA() Spectrum {
+	// CIE 15 definition of illuminant A with c2=1.435e7 nm·K.
+	const (
+		c2 = 1.435e7
+		t  = 2848
+	)
+	s := Spectrum{Start: cmfStart, Step: cmfStep, Values: make([]float32, len(cmf1931))}
+	num := math32.Exp(c2/(t*560)) - 1
+	for i := range s.Values {
+		λ := cmfStart + cmfStep*float32(i)
+		s.Values[i] = 100 * math32.Pow(560/λ, 5) * num / (math32.Exp(c2/(t*λ)) - 1)
+	}
+	return s
+}
+
+// SpectrumE returns the equal-energy illuminant E from 380nm to 780nm in 10nm steps.
+func SpectrumE() Spectrum {
+	s := Spectrum{Start: cmfStart, Step: cmfStep, Values: make([]float32, len(cmf1931))}
+	for i := range s.Values {
+		s.Values[i] = 100
+	}
+	return s
+}
+
+// BlackbodySpectrum returns the spectral power distribution of a Planckian radiator
+// at the given temperature in kelvin normalized to 100 at 560nm, from 380nm to 780nm in 10nm steps.
+func BlackbodySpectrum(kelvin float32) Spectrum {
+	const c2 = 1.4388e7 // Second radiation constant in nm·K.
+	s := Spectrum{Start: cmfStart, Step: cmfStep, Values: make([]float32, len(cmf1931))}
+	num := math32.Expm1(c2 / (kelvin * 560))
+	for i := range s.Values {
+		λ := cmfStart + cmfStep*float32(i)
+		s.Values[i] = 100 * math32.Pow(560/λ, 5) * num / math32.Expm1(c2/(kelvin*λ))
+	}
+	return s
+}
+
+// Observer is a CIE standard colorimetric observer: the color matching functions used to compute
+// tristimulus values from spectra.
+type Observer uint8
+
+const (
+	// Observer2 is the CIE 1931 2° standard observer used by most colorimetry and by this package's color spaces.
+	Observer2 Observer = iota
+	// Observer10 is the CIE 1964 10° supplementary standard observer, which better represents
+	// large color fields and is common in textile and paint matching.
+	Observer10
+)
+
+// IlluminantXYZ returns the tristimulus values of an illuminant's spectral power distribution
+// for the CIE 1931 2° standard observer, normalized so that Y=1. See [Observer.IlluminantXYZ].
+func IlluminantXYZ(spd Spectrum) CIEXYZ { return Observer2.IlluminantXYZ(spd) }
+
+// ReflectanceXYZ returns the tristimulus values of a surface with the given spectral reflectance
+// viewed under illuminant for the CIE 1931 2° standard observer. Values are normalized so
+// a perfect reflector has Y=1, i.e: the result is relative to [IlluminantXYZ] of the illuminant.
+// See [Observer.ReflectanceXYZ].
+func ReflectanceXYZ(reflectance, illuminant Spectrum) CIEXYZ {
+	return Observer2.ReflectanceXYZ(reflectance, illuminant)
+}
+
+// IlluminantXYZ returns the tristimulus values of an illuminant's spectral power distribution
+// for the observer, normalized so that Y=1.
+func (o Observer) IlluminantXYZ(spd Spectrum) CIEXYZ {
+	v := o.integrate(func(λ float32) float32 { return spd.At(λ) })
+	if v.Y == 0 {
+		return CIEXYZ{}
+	}
+	return CIEXYZ{X: v.X / v.Y, Y: 1, Z: v.Z / v.Y}
+}
+
+// ReflectanceXYZ returns the tristimulus values of a surface with the given spectral reflectance
+// viewed under illuminant for the observer. Values are normalized so a perfect reflector has Y=1.
+// Only [Observer2] results are suitable for conversion to this package's RGB spaces.
+func (o Observer) ReflectanceXYZ(reflectance, illuminant Spectrum) CIEXYZ {
+	white := o.integrate(func(λ float32) float32 { return illuminant.At(λ) })
+	if white.Y == 0 {
+		return CIEXYZ{}
+	}
+	v := o.integrate(func(λ float32) float32 { return reflectance.At(λ) * illuminant.At(λ) })
+	return CIEXYZ{X: v.X / white.Y, Y: v.Y / white.Y, Z: v.Z / white.Y}
+}
+
+// MetamerismReport is the result of [Metamerism].
+type MetamerismReport struct {
+	// DeltaE is the CIEDE2000 difference between the samples under the reference illuminant.
+	DeltaE float32
+	// MetamerismIndex is the CIEDE2000 difference between the samples under the test illuminant
+	// after correcting for their difference under the reference illuminant.
+	MetamerismIndex float32
+}
+
+// Metamerism compares two spectral reflectances, i.e: a textile or print sample and its target.
+// DeltaE is computed under the reference illuminant and the metamerism index under the test
+// illuminant following the multiplicative correction of CIE 15: sample2's tristimulus values under
+// the test illuminant are scaled per component by the ratio of sample1 to sample2 under the reference illuminant.
+// A pair that matches under the reference illuminant but not under the test illuminant is a metameric pair.
+// CIELAB values are computed relative to the white of each illuminant for the given observer.
+func Metamerism(sample1, sample2, reference, test Spectrum, observer Observer) MetamerismReport {
+	refWhite := observer.IlluminantXYZ(reference).vec()
+	testWhite := observer.IlluminantXYZ(test).vec()
+	r1 := observer.ReflectanceXYZ(sample1, reference)
+	r2 := observer.ReflectanceXYZ(sample2, reference)
+	t1 := observer.ReflectanceXYZ(sample1, test)
+	t2 := observer.ReflectanceXYZ(sample2, test)
+	ratio := func(a, b float32) float32 {
+		if b == 0 {
+			return 1
+		}
+		return a / b
+	}
+	corrected := CIEXYZ{
+		X: t2.X * ratio(r1.X, r2.X),
+		Y: t2.Y * ratio(r1.Y, r2.Y),
+		Z: t2.Z * ratio(r1.Z, r2.Z),
+	}
+	return MetamerismReport{
+		DeltaE:          r1.cielab(refWhite).DeltaE2000(r2.cielab(refWhite)),
+		MetamerismIndex: t1.cielab(testWhite).DeltaE2000(corrected.cielab(testWhite)),
+	}
+}
+
+// integrate sums f weighted by the observer's color matching functions over the tabulated range.
+func (o Observer) integrate(f func(wavelength float32) float32) ms3.Vec {
+	cmf := &cmf1931
+	if o == Observer10 {
+		cmf = &cmf1964
+	}
+	var sum ms3.Vec
+	for i, xyz := range cmf {
+		v := f(cmfStart + cmfStep*float32(i))
+		sum = ms3.Add(sum, ms3.Scale(v, ms3.Vec{X: xyz[0], Y: xyz[1], Z: xyz[2]}))
+	}
+	return sum
+}
+
+const (
+	cmfStart = 380
+	cmfStep  = 10
+)
+
+// cmf1931 are the CIE 1931 2° standard observer color matching functions
+// from 380nm to 780nm in 10nm steps.
+var cmf1931 = [41][3]float32{
+	{0.001368, 0.000039, 0.006450},
+	{0.004243, 0.000120, 0.020050},
+	{0.014310, 0.000396, 0.067850},
+	{0.043510, 0.001210, 0.207400},
+	{0.134380, 0.004000, 0.645600},
+	{0.283900, 0.011600, 1.385600},
+	{0.348280, 0.023000, 1.747060},
+	{0.336200, 0.038000, 1.772110},
+	{0.290800, 0.060000, 1.669200},
+	{0.195360, 0.090980, 1.287640},
+	{0.095640, 0.139020, 0.812950},
+	{0.032010, 0.208020, 0.465180},
+	{0.004900, 0.323000, 0.272000},
+	{0.009300, 0.503000, 0.158200},
+	{0.063270, 0.710000, 0.078250},
+	{0.165500, 0.862000, 0.042160},
+	{0.290400, 0.954000, 0.020300},
+	{0.433450, 0.994950, 0.008750},
+	{0.594500, 0.995000, 0.003900},
+	{0.762100, 0.952000, 0.002100},
+	{0.916300, 0.870000, 0.001650},
+	{1.026300, 0.757000, 0.001100},
+	{1.062200, 0.631000, 0.000800},
+	{1.002600, 0.503000, 0.000340},
+	{0.854450, 0.381000, 0.000190},
+	{0.642400, 0.265000, 0.000050},
+	{0.447900, 0.175000, 0.000020},
+	{0.283500, 0.107000, 0},
+	{0.164900, 0.061000, 0},
+	{0.087400, 0.032000, 0},
+	{0.046770, 0.017000, 0},
+	{0.022700, 0.008210, 0},
+	{0.011359, 0.004102, 0},
+	{0.005790, 0.002091, 0},
+	{0.002899, 0.001047, 0},
+	{0.001440, 0.000520, 0},
+	{0.000690, 0.000249, 0},
+	{0.000332, 0.000120, 0},
+	{0.000166, 0.000060, 0},
+	{0.000083, 0.000030, 0},
+	{0.000042, 0.000015, 0},
+}
+
+// cmf1964 are the CIE 1964 10° supplementary standard observer color matching functions
+// from 380nm to 780nm in 10nm steps.
+var cmf1964 = [41][3]float32{
+	{0.000160, 0.000017, 0.000705},
+	{0.002362, 0.000253, 0.010482},
+	{0.019110, 0.002004, 0.086011},
+	{0.084736, 0.008756, 0.389366},
+	{0.204492, 0.021391, 0.972542},
+	{0.314679, 0.038676, 1.553480},
+	{0.383734, 0.062077, 1.967280},
+	{0.370702, 0.089456, 1.994800},
+	{0.302273, 0.128201, 1.745370},
+	{0.195618, 0.185190, 1.317560},
+	{0.080507, 0.253589, 0.772125},
+	{0.016172, 0.339133, 0.415254},
+	{0.003816, 0.460777, 0.218502},
+	{0.037465, 0.606741, 0.112044},
+	{0.117749, 0.761757, 0.060709},
+	{0.236491, 0.875211, 0.030451},
+	{0.376772, 0.961988, 0.013676},
+	{0.529826, 0.991761, 0.003988},
+	{0.705224, 0.997340, 0},
+	{0.878655, 0.955552, 0},
+	{1.014160, 0.868934, 0},
+	{1.118520, 0.777405, 0},
+	{1.123990, 0.658341, 0},
+	{1.030480, 0.527963, 0},
+	{0.856297, 0.398057, 0},
+	{0.647467, 0.283493, 0},
+	{0.431567, 0.179828, 0},
+	{0.268329, 0.107633, 0},
+	{0.152568, 0.060281, 0},
+	{0.081261, 0.031800, 0},
+	{0.040851, 0.015905, 0},
+	{0.019941, 0.007749, 0},
+	{0.009577, 0.003718, 0},
+	{0.004553, 0.001768, 0},
+	{0.002175, 0.000846, 0},
+	{0.001045, 0.000407, 0},
+	{0.000508, 0.000199, 0},
+	{0.000251, 0.000098, 0},
+	{0.000126, 0.000050, 0},
+	{0.000065, 0.000025, 0},
+	{0.000033, 0.000013, 0},
+}
+
+// spdD65 is the relative spectral power distribution of CIE illuminant D65
+// from 380nm to 780nm in 10nm steps.
+var spdD65 = [41]float32{
+	49.9755, 54.6482, 82.7549, 91.486, 93.4318, 86.6823, 104.865, 117.008, 117.812, 114.861,
+	115.923, 108.811, 109.354, 107.802, 104.79, 107.689, 104.405, 104.046, 100, 96.3342,
+	95.788, 88.6856, 90.0062, 89.5991, 87.6987, 83.2886, 83.6992, 80.0268, 80.2146, 82.2778,
+	78.2842, 69.7213, 71.6091, 74.349, 61.604, 69.8856, 75.087, 63.5927, 46.4182, 66.8054,
+	43.3287,
+}
@@ -0,0 +1,88 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestIlluminantXYZ(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		spd  Spectrum
+		x, y float32
+	}{
+		{name: "D65", spd: SpectrumD65(), x: 0.3127, y: 0.3290},
+		{name: "A", spd: SpectrumA(), x: 0.4476, y: 0.4074},
+		{name: "E", spd: SpectrumE(), x: 1. / 3, y: 1. / 3},
+		{name: "2856K", spd: BlackbodySpectrum(2856), x: 0.4476, y: 0.4074},
+	} {
+		xyz := IlluminantXYZ(test.spd)
+		sum := xyz.X + xyz.Y + xyz.Z
+		x, y := xyz.X/sum, xyz.Y/sum
+		if math32.Abs(x-test.x) > 1e-3 || math32.Abs(y-test.y) > 1e-3 {
+			t.Errorf("%s: got chromaticity (%.4f, %.4f), want (%.4f, %.4f)", test.name, x, y, test.x, test.y)
+		}
+	}
+	// White points for the 10° observer.
+	for _, test := range []struct {
+		name string
+		spd  Spectrum
+		x, y float32
+	}{
+		{name: "D65", spd: SpectrumD65(), x: 0.3138, y: 0.3310},
+		{name: "A", spd: SpectrumA(), x: 0.4512, y: 0.4059},
+	} {
+		xyz := Observer10.IlluminantXYZ(test.spd)
+		sum := xyz.X + xyz.Y + xyz.Z
+		x, y := xyz.X/sum, xyz.Y/sum
+		if math32.Abs(x-test.x) > 1e-3 || math32.Abs(y-test.y) > 1e-3 {
+			t.Errorf("%s 10°: got chromaticity (%.4f, %.4f), want (%.4f, %.4f)", test.name, x, y, test.x, test.y)
+		}
+	}
+}
+
+func TestMetamerism(t *testing.T) {
+	flat := func(v float32) Spectrum {
+		s := SpectrumE()
+		for i := range s.Values {
+			s.Values[i] = v
+		}
+		return s
+	}
+	grey := flat(0.5)
+	white := ReflectanceXYZ(flat(1), SpectrumD65())
+	if math32.Abs(white.Y-1) > 1e-5 {
+		t.Errorf("perfect reflector Y=%v, want 1", white.Y)
+	}
+	report := Metamerism(grey, grey, SpectrumD65(), SpectrumA(), Observer2)
+	if report.DeltaE != 0 || report.MetamerismIndex != 0 {
+		t.Errorf("identical spectra should not differ, got %+v", report)
+	}
+	// Scaled neutrals differ in lightness but the difference is illuminant independent.
+	report = Metamerism(grey, flat(0.4), SpectrumD65(), SpectrumA(), Observer2)
+	if report.DeltaE < 1 || report.MetamerismIndex > 0.01 {
+		t.Errorf("scaled neutral: got %+v", report)
+	}
+	// A spiky spectrum tuned to match grey under D65 shifts under illuminant A.
+	spiky := flat(0)
+	for i := range spiky.Values {
+		λ := spiky.Start + spiky.Step*float32(i)
+		switch {
+		case λ >= 440 && λ <= 460:
+			spiky.Values[i] = 0.9
+		case λ >= 530 && λ <= 550:
+			spiky.Values[i] = 0.9
+		case λ >= 600 && λ <= 620:
+			spiky.Values[i] = 0.9
+		}
+	}
+	report = Metamerism(grey, spiky, SpectrumD65(), SpectrumA(), Observer2)
+	if report.MetamerismIndex <= 0.5 {
+		t.Errorf("spiky spectrum should be metameric, got %+v", report)
+	}
+	// The same pair is seen differently by the 10° observer.
+	if report10 := Metamerism(grey, spiky, SpectrumD65(), SpectrumA(), Observer10); math32.Abs(report10.DeltaE-report.DeltaE) < 0.1 {
+		t.Errorf("observers agree on spiky spectrum difference: %+v and %+v", report, report10)
+	}
+}
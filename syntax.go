@@ -0,0 +1,78 @@
+package colorspace
+
+import (
+	"image/color"
+	"sort"
+)
+
+// SyntaxToken is a named foreground color of a syntax highlighting theme, i.e: the keyword or comment color.
+type SyntaxToken struct {
+	Name  string
+	Color color.Color
+}
+
+// TokenContrast is the contrast of a token color against the theme background.
+type TokenContrast struct {
+	Name string
+	// WCAG is the WCAG 2 contrast ratio. See [ContrastRatio].
+	WCAG float32
+	// APCA is the APCA lightness contrast Lc of the token drawn as text. See [APCAContrast].
+	APCA float32
+}
+
+// TokenConfusion is a pair of token colors that are hard to tell apart.
+type TokenConfusion struct {
+	A, B string // Names of the tokens.
+	// Vision is the type of vision under which the colors are confused.
+	Vision Vision
+	// DeltaE is the OKLAB Euclidean distance between the colors as seen with Vision. See [OKLAB.DeltaE].
+	DeltaE float32
+}
+
+// SyntaxAudit is the result of [AuditSyntaxPalette].
+type SyntaxAudit struct {
+	// Tokens holds the contrast of each token in input order.
+	Tokens []TokenContrast
+	// Confusions holds the confusable token pairs sorted by ascending DeltaE.
+	Confusions []TokenConfusion
+}
+
+// AuditSyntaxPalette reports the contrast of each syntax highlighting token color against the opaque
+// background color of an editor theme and which pairs of tokens are confusable. Two tokens are confusable
+// if their colors differ by less than minDeltaE in OKLAB, i.e: 0.05, with normal vision or with any of the
+// dichromacies simulated by [SimulateCVD]. A pair confusable with normal vision is reported as such,
+// otherwise the vision under which the colors are closest is reported. Tokens sharing a color are reported
+// with a DeltaE of 0. Translucent token colors are composited over the background.
+func AuditSyntaxPalette(background color.Color, tokens []SyntaxToken, minDeltaE float32) SyntaxAudit {
+	bg := ColorToSRGB(background)
+	audit := SyntaxAudit{Tokens: make([]TokenContrast, len(tokens))}
+	var labs [numVisions][]OKLAB
+	for i, tok := range tokens {
+		fg, a := unpremultiplied(tok.Color)
+		c := flattenLinear(fg, a, bg)
+		audit.Tokens[i] = TokenContrast{Name: tok.Name, WCAG: ContrastRatio(c, bg), APCA: APCAContrast(c, bg)}
+		for v := VisionNormal; v < numVisions; v++ {
+			labs[v] = append(labs[v], colorToOKLAB(SimulateCVD(c, v, 1)))
+		}
+	}
+	for i := range tokens {
+		for j := i + 1; j < len(tokens); j++ {
+			worst := TokenConfusion{A: tokens[i].Name, B: tokens[j].Name, DeltaE: labs[VisionNormal][i].DeltaE(labs[VisionNormal][j])}
+			if worst.DeltaE >= minDeltaE {
+				// Colors confused with normal vision are reported as such.
+				for v := VisionNormal + 1; v < numVisions; v++ {
+					if d := labs[v][i].DeltaE(labs[v][j]); d < worst.DeltaE {
+						worst.Vision, worst.DeltaE = v, d
+					}
+				}
+			}
+			if worst.DeltaE < minDeltaE {
+				audit.Confusions = append(audit.Confusions, worst)
+			}
+		}
+	}
+	sort.SliceStable(audit.Confusions, func(i, j int) bool {
+		return audit.Confusions[i].DeltaE < audit.Confusions[j].DeltaE
+	})
+	return audit
+}
@@ -0,0 +1,59 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestAuditSyntaxPalette(t *testing.T) {
+	bg := color.RGBA{R: 0x1e, G: 0x1e, B: 0x1e, A: 0xff}
+	tokens := []SyntaxToken{
+		{Name: "keyword", Color: color.RGBA{R: 0x56, G: 0x9c, B: 0xd6, A: 0xff}},
+		{Name: "string", Color: color.RGBA{R: 0xce, G: 0x91, B: 0x78, A: 0xff}},
+		{Name: "comment", Color: color.RGBA{R: 0x3a, G: 0x3a, B: 0x3a, A: 0xff}},
+		{Name: "error", Color: color.RGBA{R: 0xd0, G: 0x40, B: 0x30, A: 0xff}},
+		{Name: "added", Color: color.RGBA{R: 0x50, G: 0x90, B: 0x20, A: 0xff}},
+		{Name: "type", Color: color.RGBA{R: 0x57, G: 0x9d, B: 0xd6, A: 0xff}},
+	}
+	audit := AuditSyntaxPalette(bg, tokens, 0.05)
+	if len(audit.Tokens) != len(tokens) {
+		t.Fatalf("got %d token contrasts, want %d", len(audit.Tokens), len(tokens))
+	}
+	for i, tc := range audit.Tokens {
+		if tc.Name != tokens[i].Name {
+			t.Errorf("token %d is %q, want %q", i, tc.Name, tokens[i].Name)
+		}
+		if tc.APCA > 0 {
+			t.Errorf("%s: APCA %v of light text on dark background should not be positive", tc.Name, tc.APCA)
+		}
+	}
+	if c := audit.Tokens[2]; c.WCAG > 1.5 || c.APCA < -15 {
+		t.Errorf("comment contrast = %+v, want nearly none", c)
+	}
+	if c := audit.Tokens[0]; c.WCAG < 4.5 {
+		t.Errorf("keyword contrast = %+v, want at least 4.5", c)
+	}
+	want := map[[2]string]Vision{
+		{"keyword", "type"}: VisionNormal,
+		{"error", "added"}:  VisionDeuteranopia,
+	}
+	for pair, vision := range want {
+		found := false
+		for _, c := range audit.Confusions {
+			if c.A == pair[0] && c.B == pair[1] {
+				found = true
+				if c.Vision != vision {
+					t.Errorf("%v confused with %v vision, want %v", pair, c.Vision, vision)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%v not reported as confusable", pair)
+		}
+	}
+	for i := 1; i < len(audit.Confusions); i++ {
+		if audit.Confusions[i].DeltaE < audit.Confusions[i-1].DeltaE {
+			t.Error("confusions not sorted by DeltaE")
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package colorspace
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms1"
+)
+
+var errTerminalTheme = errors.New("cannot satisfy terminal theme contrast and distinguishability constraints for the seed colors")
+
+// Terminal theme constraints.
+const (
+	terminalMinContrast       = 4.5  // WCAG AA contrast of normal and bright colors against the background.
+	terminalMinDimContrast    = 3    // Contrast of bright black, used for comments and hints.
+	terminalMinDistinctDeltaE = 0.08 // OKLAB difference between the chromatic colors.
+	terminalMaxHueShift       = 10   // Largest shift in degrees of ANSI hues towards the seeds.
+)
+
+// ANSI color indices of a [TerminalTheme].
+const (
+	ANSIBlack = iota
+	ANSIRed
+	ANSIGreen
+	ANSIYellow
+	ANSIBlue
+	ANSIMagenta
+	ANSICyan
+	ANSIWhite
+)
+
+// TerminalTheme is a 16 color ANSI terminal color scheme.
+type TerminalTheme struct {
+	Background, Foreground SRGB
+	// Normal and Bright are the 8 normal and 8 bright ANSI colors indexed by ANSIBlack through ANSIWhite.
+	Normal, Bright [8]SRGB
+}
+
+// Palette returns the 16 ANSI colors in terminal order: the normal colors followed by the bright colors.
+func (t TerminalTheme) Palette() color.Palette {
+	p := make(color.Palette, 0, 16)
+	for _, c := range t.Normal {
+		p = append(p, c)
+	}
+	for _, c := range t.Bright {
+		p = append(p, c)
+	}
+	return p
+}
+
+// NewTerminalTheme generates a dark or light terminal theme from a warm and a cool seed color, i.e: a brand
+// orange and a slate blue. The background and foreground are tinted with the cool seed. Red, yellow and
+// magenta hues are shifted towards the warm seed and green, cyan and blue towards the cool one by up
+// to 10° so the theme harmonizes with the seeds while colors keep their conventional meaning.
+// Shifts are reduced where they would make colors hard to tell apart.
+// The chroma of the ANSI colors follows the seeds. If cool is nil the complement of warm, rotated 180° by [HueRotateOp], is used.
+//
+// Tones are chosen in [HCT] so that all normal and bright colors but black reach a WCAG contrast ratio
+// of 4.5 against the background, bright black reaches 3 and the six chromatic colors of each set differ
+// by at least 0.08 in OKLAB. An error is returned if the constraints cannot be met.
+func NewTerminalTheme(warm, cool color.Color, dark bool) (TerminalTheme, error) {
+	w := ColorToSRGB(warm).HCT()
+	if cool == nil {
+		cool = HueRotateOp{Degrees: 180}.Apply(ColorToSRGB(warm))
+	}
+	c := ColorToSRGB(cool).HCT()
+	chroma := ms1.Clamp(0.5*(w.C+c.C), 40, 70)
+	bgTone, fgTone, dir := float32(96), float32(20), float32(-1)
+	if dark {
+		bgTone, fgTone, dir = 10, 90, 1
+	}
+	var theme TerminalTheme
+	theme.Background = HCT{H: c.H, C: 6, T: bgTone}.SRGB()
+	theme.Foreground = HCT{H: c.H, C: 8, T: fgTone}.SRGB()
+	// Conventional HCT hues of the chromatic ANSI colors, shifted towards their seed.
+	hues := [8]float32{ANSIRed: 25, ANSIGreen: 140, ANSIYellow: 95, ANSIBlue: 265, ANSIMagenta: 330, ANSICyan: 200}
+	warmHue := [8]bool{ANSIRed: true, ANSIYellow: true, ANSIMagenta: true}
+	var shifts [8]float32
+	for i := ANSIRed; i <= ANSICyan; i++ {
+		seed := c.H
+		if warmHue[i] {
+			seed = w.H
+		}
+		shifts[i] = ms1.Clamp(hueDelta(hues[i], seed), -terminalMaxHueShift, terminalMaxHueShift)
+	}
+	bg := theme.Background
+	var minTones [8]float32
+	for i := ANSIRed; i <= ANSICyan; i++ {
+		minTones[i] = toneForContrast(hues[i]+shifts[i], chroma, bgTone+dir*55, dir, bg, terminalMinContrast)
+	}
+	var normalTones [8]float32
+	theme.Normal, normalTones = terminalChromatic(hues, shifts, minTones, chroma, dir, bg)
+	for i := ANSIRed; i <= ANSICyan; i++ {
+		// Bright colors are at least 8 tones further from the background than normal colors.
+		minTones[i] = normalTones[i] + dir*8
+	}
+	theme.Bright, _ = terminalChromatic(hues, shifts, minTones, chroma, dir, bg)
+	// Black and white are near-neutral and tinted with the cool seed.
+	theme.Normal[ANSIBlack] = HCT{H: c.H, C: 6, T: bgTone + dir*10}.SRGB()
+	theme.Bright[ANSIBlack] = HCT{H: c.H, C: 6, T: toneForContrast(c.H, 6, bgTone+dir*35, dir, bg, terminalMinDimContrast)}.SRGB()
+	theme.Normal[ANSIWhite] = HCT{H: c.H, C: 6, T: toneForContrast(c.H, 6, fgTone-dir*10, dir, bg, terminalMinContrast)}.SRGB()
+	theme.Bright[ANSIWhite] = HCT{H: c.H, C: 4, T: bgTone + dir*88}.SRGB()
+	for _, set := range [2][8]SRGB{theme.Normal, theme.Bright} {
+		for i := ANSIRed; i <= ANSICyan; i++ {
+			if ContrastRatio(set[i], bg) < terminalMinContrast-1e-3 {
+				return theme, errTerminalTheme
+			}
+			for j := i + 1; j <= ANSICyan; j++ {
+				if colorToOKLAB(set[i]).DeltaE(colorToOKLAB(set[j])) < terminalMinDistinctDeltaE {
+					return theme, errTerminalTheme
+				}
+			}
+		}
+	}
+	return theme, nil
+}
+
+// terminalChromatic returns the red through cyan ANSI colors and their tones maximizing their smallest
+// OKLAB difference. Each color may move up to 24 tones from its minimum tone in direction dir and drop
+// part of its hue shift as long as it keeps its contrast against bg. Smaller departures are preferred once colors are distinguishable.
+func terminalChromatic(hues, shifts, minTones [8]float32, chroma, dir float32, bg SRGB) (colors [8]SRGB, tones [8]float32) {
+	const toneSteps, toneStep = 13, 2
+	shiftScales := [...]float32{1, 0.5, 0}
+	type candidate struct {
+		lab       OKLAB
+		c         SRGB
+		tone      float32
+		deviation float32
+	}
+	var candidates [8][]candidate
+	for i := ANSIRed; i <= ANSICyan; i++ {
+		for k := 0; k < toneSteps; k++ {
+			tone := ms1.Clamp(minTones[i]+dir*toneStep*float32(k), 0, 100)
+			for s, scale := range shiftScales {
+				c := HCT{H: normalizeHue(hues[i] + scale*shifts[i]), C: chroma, T: tone}.SRGB()
+				if len(candidates[i]) > 0 && ContrastRatio(c, bg) < terminalMinContrast {
+					continue
+				}
+				candidates[i] = append(candidates[i], candidate{
+					lab: colorToOKLAB(c), c: c, tone: tone, deviation: float32(k) + 4*float32(s),
+				})
+			}
+		}
+	}
+	var chosen [8]int
+	margin := func() float32 {
+		m := float32(terminalMinDistinctDeltaE)
+		for i := ANSIRed; i <= ANSICyan; i++ {
+			for j := i + 1; j <= ANSICyan; j++ {
+				m = math32.Min(m, candidates[i][chosen[i]].lab.DeltaE(candidates[j][chosen[j]].lab))
+			}
+		}
+		return m
+	}
+	for sweep := 0; sweep < 4 && margin() < terminalMinDistinctDeltaE; sweep++ {
+		for i := ANSIRed; i <= ANSICyan; i++ {
+			best, bestMargin := chosen[i], float32(-1)
+			for k, cand := range candidates[i] {
+				chosen[i] = k
+				m := margin()
+				if m > bestMargin || (m == bestMargin && cand.deviation < candidates[i][best].deviation) {
+					best, bestMargin = k, m
+				}
+			}
+			chosen[i] = best
+		}
+	}
+	for i := ANSIRed; i <= ANSICyan; i++ {
+		colors[i], tones[i] = candidates[i][chosen[i]].c, candidates[i][chosen[i]].tone
+	}
+	return colors, tones
+}
+
+// toneForContrast returns the first tone from start in steps of 1 in direction dir at which the HCT color
+// reaches the contrast ratio minContrast against bg. It returns the last tone tried if none does.
+func toneForContrast(h, c, start, dir float32, bg SRGB, minContrast float32) float32 {
+	tone := ms1.Clamp(start, 0, 100)
+	for ; tone >= 0 && tone <= 100; tone += dir {
+		if ContrastRatio(HCT{H: h, C: c, T: tone}.SRGB(), bg) >= minContrast {
+			return tone
+		}
+	}
+	return ms1.Clamp(tone, 0, 100)
+}
@@ -0,0 +1,42 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestNewTerminalTheme(t *testing.T) {
+	orange, slate := SRGB{R: 0.95, G: 0.5, B: 0.15}, SRGB{R: 0.3, G: 0.4, B: 0.55}
+	for _, dark := range []bool{true, false} {
+		for _, cool := range []color.Color{slate, nil} {
+			theme, err := NewTerminalTheme(orange, cool, dark)
+			if err != nil {
+				t.Fatalf("dark=%v cool=%v: %v", dark, cool, err)
+			}
+			bgY := theme.Background.RelativeLuminance()
+			if dark != (bgY < 0.2) {
+				t.Errorf("dark=%v: background luminance %v", dark, bgY)
+			}
+			if r := ContrastRatio(theme.Foreground, theme.Background); r < 7 {
+				t.Errorf("dark=%v: foreground contrast %v", dark, r)
+			}
+			for i := ANSIRed; i <= ANSIWhite; i++ {
+				for _, c := range [2]SRGB{theme.Normal[i], theme.Bright[i]} {
+					if r := ContrastRatio(c, theme.Background); r < 4.5-1e-3 {
+						t.Errorf("dark=%v: color %d contrast %v", dark, i, r)
+					}
+				}
+			}
+			if r := ContrastRatio(theme.Bright[ANSIBlack], theme.Background); r < 3-1e-3 {
+				t.Errorf("dark=%v: bright black contrast %v", dark, r)
+			}
+			if p := theme.Palette(); len(p) != 16 || p[9] != theme.Bright[ANSIRed] {
+				t.Errorf("palette order wrong")
+			}
+			// Red stays red.
+			if h := theme.Normal[ANSIRed].HCT().H; h > 40 && h < 350 {
+				t.Errorf("dark=%v: red hue %v", dark, h)
+			}
+		}
+	}
+}
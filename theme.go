@@ -0,0 +1,126 @@
+package colorspace
+
+import (
+	"image/color"
+	"strconv"
+)
+
+// SurfaceTintOpacities are the Material Design 3 opacities of the surface tint overlay for
+// elevation levels 0 through 5 (0, 1, 3, 6, 8 and 12dp).
+var SurfaceTintOpacities = [6]float32{0, 0.05, 0.08, 0.11, 0.12, 0.14}
+
+// Mix overlays the color overlay at the given opacity in [0,1] over the opaque color base in linear light
+// and returns the resulting opaque color. The alpha of overlay multiplies opacity. See [Flatten].
+func Mix(base, overlay color.Color, opacity float32) SRGB {
+	fg, a := unpremultiplied(overlay)
+	return flattenLinear(fg, a*clamp01(opacity), ColorToSRGB(base))
+}
+
+// SurfaceTint returns the opaque color of a surface at a Material Design 3 elevation level in [0,5],
+// which is the surface color overlaid with the tint color, usually the primary color, at [SurfaceTintOpacities].
+// Levels outside the range are clamped.
+func SurfaceTint(surface, tint color.Color, level int) SRGB {
+	if level < 0 {
+		level = 0
+	} else if level >= len(SurfaceTintOpacities) {
+		level = len(SurfaceTintOpacities) - 1
+	}
+	return Mix(surface, tint, SurfaceTintOpacities[level])
+}
+
+// SurfaceTints returns the surface colors for all elevation levels. See [SurfaceTint].
+func SurfaceTints(surface, tint color.Color) [6]SRGB {
+	var surfaces [6]SRGB
+	for level := range surfaces {
+		surfaces[level] = SurfaceTint(surface, tint, level)
+	}
+	return surfaces
+}
+
+// InteractionState is the interaction state of a UI component which Material Design indicates
+// by overlaying a translucent state layer of the content color on the container.
+type InteractionState uint8
+
+const (
+	StateEnabled InteractionState = iota
+	StateHover
+	StateFocus
+	StatePressed
+	StateDragged
+	// StateDisabled components are drawn with reduced emphasis. WCAG exempts them from contrast requirements.
+	StateDisabled
+	numInteractionStates
+)
+
+// String returns the name of the state.
+func (s InteractionState) String() string {
+	switch s {
+	case StateEnabled:
+		return "enabled"
+	case StateHover:
+		return "hover"
+	case StateFocus:
+		return "focus"
+	case StatePressed:
+		return "pressed"
+	case StateDragged:
+		return "dragged"
+	case StateDisabled:
+		return "disabled"
+	}
+	return "InteractionState(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Opacity returns the Material Design 3 opacity of the state layer. For [StateDisabled] it is
+// the opacity of the content color used as container; disabled content uses 0.38.
+func (s InteractionState) Opacity() float32 {
+	switch s {
+	case StateHover:
+		return 0.08
+	case StateFocus, StatePressed:
+		return 0.10
+	case StateDragged:
+		return 0.16
+	case StateDisabled:
+		return 0.12
+	}
+	return 0
+}
+
+// StateColors are the opaque container and content colors of a component in an interaction state.
+type StateColors struct {
+	Container SRGB
+	Content   SRGB
+}
+
+// Contrast returns the WCAG 2 [ContrastRatio] between content and container.
+func (s StateColors) Contrast() float32 {
+	return ContrastRatio(s.Content, s.Container)
+}
+
+// StateLayer returns the colors of a component with container color base and content color on,
+// i.e: primary and on-primary, in the given interaction state. The state layer is mixed in linear light with [Mix].
+// Disabled components use the content color over the surface at reduced opacities
+// and ignore base as specified by Material Design.
+func StateLayer(base, on, surface color.Color, state InteractionState) StateColors {
+	if state == StateDisabled {
+		container := Mix(surface, on, state.Opacity())
+		return StateColors{Container: container, Content: Mix(container, on, 0.38)}
+	}
+	return StateColors{
+		Container: Mix(base, on, state.Opacity()),
+		Content:   ColorToSRGB(on),
+	}
+}
+
+// CheckStateContrast returns the interaction states, excluding [StateDisabled], whose content does not
+// reach minContrast against its container, i.e: 4.5 for WCAG AA body text. A nil result means all states pass.
+func CheckStateContrast(base, on color.Color, minContrast float32) []InteractionState {
+	var failed []InteractionState
+	for state := StateEnabled; state < StateDisabled; state++ {
+		if StateLayer(base, on, nil, state).Contrast() < minContrast {
+			failed = append(failed, state)
+		}
+	}
+	return failed
+}
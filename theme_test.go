@@ -0,0 +1,62 @@
+package colorspace
+
+import "testing"
+
+func TestSurfaceTint(t *testing.T) {
+	surface := SRGB{R: 0.11, G: 0.11, B: 0.12}
+	tint := SRGB{R: 0.8, G: 0.74, B: 1}
+	tints := SurfaceTints(surface, tint)
+	if sqdist(tints[0].vec(), surface.vec()) > 1e-8 {
+		t.Errorf("level 0 should be the untinted surface, got %+v", tints[0])
+	}
+	prev := tints[0].LSRGB().CIEXYZ().OKLAB().DeltaE(tint.LSRGB().CIEXYZ().OKLAB())
+	for level := 1; level < len(tints); level++ {
+		d := tints[level].LSRGB().CIEXYZ().OKLAB().DeltaE(tint.LSRGB().CIEXYZ().OKLAB())
+		if d >= prev {
+			t.Errorf("level %d is not closer to the tint than level %d", level, level-1)
+		}
+		prev = d
+	}
+	if SurfaceTint(surface, tint, 99) != tints[5] {
+		t.Error("level not clamped")
+	}
+	// Mixing is done in linear light.
+	mid := Mix(SRGB{}, SRGB{R: 1, G: 1, B: 1}, 0.5)
+	if want := (LSRGB{R: 0.5, G: 0.5, B: 0.5}).SRGB(); sqdist(mid.vec(), want.vec()) > 1e-8 {
+		t.Errorf("Mix got %+v, want %+v", mid, want)
+	}
+}
+
+func TestStateLayer(t *testing.T) {
+	primary := SRGB{R: 0.4, G: 0.31, B: 0.64}
+	onPrimary := SRGB{R: 1, G: 1, B: 1}
+	surface := SRGB{R: 0.99, G: 0.97, B: 1}
+	enabled := StateLayer(primary, onPrimary, surface, StateEnabled)
+	if sqdist(enabled.Container.vec(), primary.vec()) > 1e-8 {
+		t.Errorf("enabled container changed: %+v", enabled.Container)
+	}
+	prevY := enabled.Container.RelativeLuminance()
+	for _, state := range []InteractionState{StateHover, StatePressed, StateDragged} {
+		sc := StateLayer(primary, onPrimary, surface, state)
+		if y := sc.Container.RelativeLuminance(); y <= prevY {
+			t.Errorf("%v: container should get lighter towards on-color, luminance %v <= %v", state, y, prevY)
+		} else {
+			prevY = y
+		}
+	}
+	disabled := StateLayer(primary, onPrimary, SRGB{R: 0.11, G: 0.11, B: 0.11}, StateDisabled)
+	if disabled.Contrast() >= enabled.Contrast() {
+		t.Errorf("disabled contrast %v should be lower than enabled %v", disabled.Contrast(), enabled.Contrast())
+	}
+	if failed := CheckStateContrast(primary, onPrimary, 3); failed != nil {
+		t.Errorf("unexpected contrast failures %v", failed)
+	}
+	// State layers in linear light lighten the container noticeably, reducing contrast.
+	failed := CheckStateContrast(primary, onPrimary, 4.5)
+	if len(failed) == 0 || failed[0] == StateEnabled || failed[len(failed)-1] != StateDragged {
+		t.Errorf("expected dragged but not enabled state to fail AA contrast, got %v", failed)
+	}
+	if StateFocus.String() != "focus" {
+		t.Errorf("unexpected state name %q", StateFocus.String())
+	}
+}
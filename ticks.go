@@ -0,0 +1,67 @@
+package colorspace
+
+// PerceptualTicks returns n legend tick values from s.Min to s.Max whose colors are evenly spaced
+// along the colormap in [OKLAB], rather than evenly spaced in value. For colormaps whose colors change
+// faster in some ranges than others ticks are placed closer together where colors change quickly,
+// so equal legend intervals read as equal color differences. The colormap is sampled in 256 steps
+// and the scale's normalization is inverted numerically, so it must be monotonic.
+func (s Scale) PerceptualTicks(n int) []float32 {
+	if n <= 0 {
+		return nil
+	} else if n == 1 {
+		return []float32{s.Min}
+	}
+	const samples = 256
+	var arc [samples + 1]float32 // Cumulative OKLAB distance along the colormap.
+	prev := colorToOKLAB(s.Colormap.At(0))
+	for i := 1; i <= samples; i++ {
+		lab := colorToOKLAB(s.Colormap.At(float32(i) / samples))
+		arc[i] = arc[i-1] + prev.DeltaE(lab)
+		prev = lab
+	}
+	ticks := make([]float32, n)
+	total := arc[samples]
+	j := 0
+	for k := range ticks {
+		var t float32
+		switch {
+		case k == n-1:
+			t = 1
+		case total == 0:
+			t = float32(k) / float32(n-1)
+		default:
+			target := total * float32(k) / float32(n-1)
+			for j < samples-1 && arc[j+1] < target {
+				j++
+			}
+			seg := arc[j+1] - arc[j]
+			var frac float32
+			if seg > 0 {
+				frac = (target - arc[j]) / seg
+			}
+			t = (float32(j) + clamp01(frac)) / samples
+		}
+		ticks[k] = s.invertNormalize(t)
+	}
+	return ticks
+}
+
+// invertNormalize returns the data value in [Min,Max] whose normalized position is t by bisection.
+func (s Scale) invertNormalize(t float32) float32 {
+	lo, hi := s.Min, s.Max
+	if t <= 0 {
+		return lo
+	} else if t >= 1 {
+		return hi
+	}
+	increasing := s.Normalize(hi) >= s.Normalize(lo)
+	for i := 0; i < 48; i++ {
+		mid := 0.5 * (lo + hi)
+		if (s.Normalize(mid) < t) == increasing {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return 0.5 * (lo + hi)
+}
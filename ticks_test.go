@@ -0,0 +1,44 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestPerceptualTicks(t *testing.T) {
+	// Colors change three times faster in the first quarter of the colormap.
+	g := Gradient{Lerp: LerpOKLAB, Stops: []GradientStop{
+		{Pos: 0, Color: color.Black},
+		{Pos: 0.25, Color: SRGB{R: 0.6, G: 0.6, B: 0.6}},
+		{Pos: 1, Color: color.White},
+	}}
+	s := Scale{Colormap: g, Min: 0, Max: 100}
+	ticks := s.PerceptualTicks(5)
+	if len(ticks) != 5 || ticks[0] != 0 || ticks[4] != 100 {
+		t.Fatalf("ticks %v should span the scale", ticks)
+	}
+	var dists []float32
+	for i := 1; i < len(ticks); i++ {
+		if ticks[i] <= ticks[i-1] {
+			t.Fatalf("ticks not increasing: %v", ticks)
+		}
+		dists = append(dists, colorToOKLAB(s.At(ticks[i-1])).DeltaE(colorToOKLAB(s.At(ticks[i]))))
+	}
+	for _, d := range dists {
+		if math32.Abs(d-dists[0]) > 0.01 {
+			t.Errorf("tick color differences not even: %v", dists)
+			break
+		}
+	}
+	if ticks[1] >= 25 {
+		t.Errorf("second tick %v should be in the fast changing first quarter", ticks[1])
+	}
+	// Logarithmic normalization is inverted.
+	logScale := Scale{Colormap: NewGradient(LerpOKLAB, color.Black, color.White), Min: 1, Max: 10000, Norm: NormLog}
+	mid := logScale.PerceptualTicks(3)[1]
+	if n := logScale.Normalize(mid); math32.Abs(n-0.5) > 0.02 {
+		t.Errorf("log scale middle tick %v normalizes to %v, want about 0.5", mid, n)
+	}
+}
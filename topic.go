@@ -0,0 +1,106 @@
+package colorspace
+
+import (
+	"hash/fnv"
+	"image/color"
+	"sync"
+
+	"github.com/chewxy/math32"
+)
+
+// ColorStore persists the key to color assignments of a [TopicColors] across program runs,
+// i.e: in a file, database or user preferences.
+type ColorStore interface {
+	// Load returns the color stored for key. ok is false if key has no stored color.
+	Load(key string) (c SRGB, ok bool, err error)
+	// Store saves the color assigned to key.
+	Store(key string, c SRGB) error
+}
+
+// MapColorStore is an in-memory [ColorStore]. It is not safe for concurrent use on its own.
+type MapColorStore map[string]SRGB
+
+// Load implements [ColorStore].
+func (m MapColorStore) Load(key string) (SRGB, bool, error) {
+	c, ok := m[key]
+	return c, ok, nil
+}
+
+// Store implements [ColorStore].
+func (m MapColorStore) Store(key string, c SRGB) error {
+	m[key] = c
+	return nil
+}
+
+// TopicColors assigns colors to keys such as chat channels, tags or metric names so each key keeps its
+// color across program runs while new keys get the palette color most distinct from the colors of the
+// currently active keys. Create it with [NewTopicColors]. It is safe for concurrent use.
+type TopicColors struct {
+	mu      sync.Mutex
+	store   ColorStore
+	palette []SRGB
+	labs    []OKLAB
+	active  map[string]OKLAB
+}
+
+// NewTopicColors returns a TopicColors persisting assignments to store and choosing new colors from palette.
+// If palette is empty a palette of 32 medium lightness and chroma colors is used. See [ExtendPalette].
+func NewTopicColors(store ColorStore, palette []color.Color) *TopicColors {
+	tc := &TopicColors{store: store, active: make(map[string]OKLAB)}
+	for _, c := range palette {
+		tc.palette = append(tc.palette, ColorToSRGB(c))
+	}
+	if len(tc.palette) == 0 {
+		tc.palette = ExtendPalette(nil, 32)
+	}
+	tc.labs = make([]OKLAB, len(tc.palette))
+	for i, c := range tc.palette {
+		tc.labs[i] = c.LSRGB().CIEXYZ().OKLAB()
+	}
+	return tc
+}
+
+// Color returns the color of key and marks the key active. A key with a stored color always gets it back.
+// Otherwise the palette color furthest (OKLAB Euclidean distance) from the colors of the active keys is
+// assigned and stored. Ties are broken by a hash of the key so assignments do not depend on the order
+// keys are first seen when none are active.
+func (tc *TopicColors) Color(key string) (SRGB, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	c, ok, err := tc.store.Load(key)
+	if err != nil {
+		return SRGB{}, err
+	}
+	if ok {
+		tc.active[key] = c.LSRGB().CIEXYZ().OKLAB()
+		return c, nil
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	start := int(h.Sum32() % uint32(len(tc.palette)))
+	best, bestDist := start, float32(-1)
+	for n := range tc.labs {
+		i := (start + n) % len(tc.labs)
+		dist := math32.Inf(1)
+		for _, a := range tc.active {
+			dist = math32.Min(dist, tc.labs[i].DeltaE(a))
+		}
+		if dist > bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	c = tc.palette[best]
+	if err := tc.store.Store(key, c); err != nil {
+		return SRGB{}, err
+	}
+	tc.active[key] = tc.labs[best]
+	return c, nil
+}
+
+// Release marks key inactive so its color no longer constrains the colors of new keys.
+// Its stored color is kept and returned again by Color.
+func (tc *TopicColors) Release(key string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	delete(tc.active, key)
+}
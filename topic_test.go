@@ -0,0 +1,41 @@
+package colorspace
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+type failingStore struct{}
+
+func (failingStore) Load(string) (SRGB, bool, error) { return SRGB{}, false, nil }
+func (failingStore) Store(string, SRGB) error        { return errors.New("store failed") }
+
+func TestTopicColors(t *testing.T) {
+	palette := []color.Color{SRGB{R: 1}, SRGB{R: 0.9, G: 0.1}, SRGB{B: 1}, SRGB{G: 0.8}}
+	store := MapColorStore{}
+	tc := NewTopicColors(store, palette)
+	first, err := tc.Color("alerts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := tc.Color("builds")
+	if colorToOKLAB(first).DeltaE(colorToOKLAB(second)) < 0.1 {
+		t.Errorf("active keys got similar colors %+v and %+v", first, second)
+	}
+	if again, _ := tc.Color("alerts"); again != first {
+		t.Errorf("key changed color from %+v to %+v", first, again)
+	}
+	// A new program run with the same store keeps the assignments.
+	tc = NewTopicColors(store, palette)
+	tc.Release("alerts")
+	if got, _ := tc.Color("builds"); got != second {
+		t.Errorf("persisted color %+v, want %+v", got, second)
+	}
+	if len(store) != 2 {
+		t.Errorf("store has %d entries, want 2", len(store))
+	}
+	if _, err := NewTopicColors(failingStore{}, nil).Color("x"); err == nil {
+		t.Error("expected store error")
+	}
+}
@@ -0,0 +1,192 @@
+package colorspace
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/chewxy/math32"
+)
+
+// TransferFunction converts a single channel between its non-linear encoded
+// value and linear light, i.e: the sRGB gamma curve or a measured monitor response.
+type TransferFunction struct {
+	// ToLinear decodes an encoded value into linear light (EOTF).
+	ToLinear func(encoded float32) float32
+	// FromLinear encodes a linear light value (inverse EOTF).
+	FromLinear func(linear float32) float32
+}
+
+var (
+	// TransferSRGB is the piecewise sRGB transfer function defined by IEC 61966-2-1.
+	TransferSRGB = TransferFunction{ToLinear: transferFunc, FromLinear: invTransferFunc}
+	// TransferLinear is the identity transfer function.
+	TransferLinear = TransferFunction{
+		ToLinear:   func(v float32) float32 { return v },
+		FromLinear: func(v float32) float32 { return v },
+	}
+)
+
+// TransferGamma returns a pure power-law transfer function with the given gamma exponent,
+// i.e: 2.2 for a typical monitor. Negative values are mirrored.
+func TransferGamma(gamma float32) TransferFunction {
+	inv := 1 / gamma
+	return TransferFunction{
+		ToLinear: func(v float32) float32 {
+			return math32.Copysign(math32.Pow(math32.Abs(v), gamma), v)
+		},
+		FromLinear: func(v float32) float32 {
+			return math32.Copysign(math32.Pow(math32.Abs(v), inv), v)
+		},
+	}
+}
+
+var errToneRamp = errors.New("tone ramp requires at least 2 samples of increasing encoded value and increasing response")
+
+// FitGamma estimates the gamma exponent of a display channel from a measured ramp.
+// encoded are the input values in [0,1] sent to the display and measured are the measured
+// luminances (any unit). The measured black level is subtracted and the ramp normalized
+// to its maximum before fitting response = encoded^gamma by least squares in log-log space.
+func FitGamma(encoded, measured []float32) (float32, error) {
+	norm, err := normalizeRamp(encoded, measured)
+	if err != nil {
+		return 0, err
+	}
+	var sxy, sxx float32
+	for i, v := range encoded {
+		if v <= 0 || v >= 1 || norm[i] <= 0 {
+			continue // Uninformative in log space.
+		}
+		x, y := math32.Log(v), math32.Log(norm[i])
+		sxy += x * y
+		sxx += x * x
+	}
+	if sxx == 0 {
+		return 0, errToneRamp
+	}
+	return sxy / sxx, nil
+}
+
+// FitToneCurve fits a smooth monotonic tone response curve to a measured display ramp
+// using monotone cubic (Fritsch-Carlson) interpolation. See [FitGamma] for a description of the arguments.
+// The returned TransferFunction maps encoded values to normalized linear light and back.
+// Use it to characterize real monitors whose response does not follow a pure power law.
+func FitToneCurve(encoded, measured []float32) (TransferFunction, error) {
+	norm, err := normalizeRamp(encoded, measured)
+	if err != nil {
+		return TransferFunction{}, err
+	}
+	x := append([]float32{}, encoded...)
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return encoded[idx[i]] < encoded[idx[j]] })
+	y := make([]float32, len(x))
+	for i, j := range idx {
+		x[i] = encoded[j]
+		y[i] = norm[j]
+	}
+	// Enforce monotonicity of noisy measurements.
+	for i := 1; i < len(y); i++ {
+		y[i] = math32.Max(y[i], y[i-1])
+	}
+	spline := newMonotoneSpline(x, y)
+	return TransferFunction{
+		ToLinear: spline.eval,
+		FromLinear: func(linear float32) float32 {
+			// Bisect since the curve is monotonic.
+			lo, hi := x[0], x[len(x)-1]
+			if linear <= y[0] {
+				return lo
+			} else if linear >= y[len(y)-1] {
+				return hi
+			}
+			for i := 0; i < 32; i++ {
+				mid := 0.5 * (lo + hi)
+				if spline.eval(mid) < linear {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			return 0.5 * (lo + hi)
+		},
+	}, nil
+}
+
+// normalizeRamp subtracts the black level of the measured ramp and normalizes it to [0,1].
+func normalizeRamp(encoded, measured []float32) ([]float32, error) {
+	if len(encoded) != len(measured) {
+		panic("encoded and measured length mismatch")
+	} else if len(encoded) < 2 {
+		return nil, errToneRamp
+	}
+	min, max := measured[0], measured[0]
+	for _, m := range measured {
+		min = math32.Min(min, m)
+		max = math32.Max(max, m)
+	}
+	if max <= min {
+		return nil, errToneRamp
+	}
+	norm := make([]float32, len(measured))
+	for i, m := range measured {
+		norm[i] = (m - min) / (max - min)
+	}
+	return norm, nil
+}
+
+// monotoneSpline is a monotone cubic Hermite interpolant.
+type monotoneSpline struct {
+	x, y, m []float32
+}
+
+func newMonotoneSpline(x, y []float32) monotoneSpline {
+	n := len(x)
+	delta := make([]float32, n-1)
+	for i := range delta {
+		if dx := x[i+1] - x[i]; dx > 0 {
+			delta[i] = (y[i+1] - y[i]) / dx
+		}
+	}
+	m := make([]float32, n)
+	m[0], m[n-1] = delta[0], delta[n-2]
+	for i := 1; i < n-1; i++ {
+		if delta[i-1]*delta[i] <= 0 {
+			m[i] = 0
+		} else {
+			m[i] = 0.5 * (delta[i-1] + delta[i])
+		}
+	}
+	// Fritsch-Carlson limiter to preserve monotonicity.
+	for i, d := range delta {
+		if d == 0 {
+			m[i], m[i+1] = 0, 0
+			continue
+		}
+		a, b := m[i]/d, m[i+1]/d
+		if s := a*a + b*b; s > 9 {
+			t := 3 / math32.Sqrt(s)
+			m[i] = t * a * d
+			m[i+1] = t * b * d
+		}
+	}
+	return monotoneSpline{x: x, y: y, m: m}
+}
+
+func (s monotoneSpline) eval(v float32) float32 {
+	n := len(s.x)
+	if v <= s.x[0] {
+		return s.y[0]
+	} else if v >= s.x[n-1] {
+		return s.y[n-1]
+	}
+	i := sort.Search(n, func(i int) bool { return s.x[i] > v }) - 1
+	h := s.x[i+1] - s.x[i]
+	if h == 0 {
+		return s.y[i]
+	}
+	t := (v - s.x[i]) / h
+	t2, t3 := t*t, t*t*t
+	return (2*t3-3*t2+1)*s.y[i] + (t3-2*t2+t)*h*s.m[i] + (-2*t3+3*t2)*s.y[i+1] + (t3-t2)*h*s.m[i+1]
+}
@@ -0,0 +1,41 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestFitToneCurve(t *testing.T) {
+	const gamma = 2.4
+	var encoded, measured []float32
+	for i := 0; i <= 16; i++ {
+		v := float32(i) / 16
+		encoded = append(encoded, v)
+		measured = append(measured, 0.5+120*math32.Pow(v, gamma)) // Black level and peak luminance in nits.
+	}
+	got, err := FitGamma(encoded, measured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math32.Abs(got-gamma) > 1e-3 {
+		t.Errorf("expected gamma %v, got %v", gamma, got)
+	}
+	curve, err := FitToneCurve(encoded, measured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v := float32(0); v <= 1; v += 0.01 {
+		want := math32.Pow(v, gamma)
+		lin := curve.ToLinear(v)
+		if math32.Abs(lin-want) > 5e-3 {
+			t.Errorf("ToLinear(%v) = %v, want %v", v, lin, want)
+		}
+		if back := curve.FromLinear(lin); math32.Abs(back-v) > 1e-3 {
+			t.Errorf("FromLinear(ToLinear(%v)) = %v", v, back)
+		}
+	}
+	if _, err := FitGamma([]float32{0.5}, []float32{1}); err == nil {
+		t.Error("expected error for too short ramp")
+	}
+}
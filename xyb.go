@@ -0,0 +1,54 @@
+package colorspace
+
+import (
+	"github.com/chewxy/math32"
+	"github.com/soypat/geometry/ms3"
+)
+
+// xybBias is the opsin absorbance bias of JPEG XL which keeps the cube root away from its infinite slope at 0.
+const xybBias = 0.0037930732552754493
+
+var (
+	// Linear sRGB to LMS opsin absorbance of JPEG XL.
+	linSRGBToXYBOpsin = ms3.NewMat3([]float32{
+		0.30, 0.622, 0.078,
+		0.23, 0.692, 0.078,
+		0.24342268924547819, 0.20476744424496821, 0.55180986650955360,
+	})
+	xybOpsinToLinSRGB = linSRGBToXYBOpsin.Inverse()
+	xybBiasCbrt       = math32.Cbrt(xybBias)
+)
+
+// XYB is the color space of the JPEG XL image codec, modelled on the response of the cones of the eye.
+// Linear light is mixed into long, medium and short wavelength cone responses, biased and compressed
+// with a cube root. X is the difference between the long and medium responses, Y their mean and B the
+// short wavelength response. Y and B are about 0.845 for sRGB white and X is near 0 for neutral colors.
+// libjxl often shows B-Y in place of B for visualization; this type holds B as encoded.
+type XYB struct {
+	X float32 // Red-green opponent channel.
+	Y float32 // Luminance-like channel.
+	B float32 // Blue channel.
+}
+
+func (c XYB) vec() ms3.Vec      { return ms3.Vec{X: c.X, Y: c.Y, Z: c.B} }
+func (c XYB) Array() [3]float32 { return c.vec().Array() }
+
+// XYB converts the linear sRGB color to XYB. Negative mixed cone responses, which only arise far
+// outside the sRGB gamut, are compressed with a signed cube root.
+func (c LSRGB) XYB() XYB {
+	v := ms3.MulMatVec(linSRGBToXYBOpsin, c.vec())
+	l := math32.Cbrt(v.X+xybBias) - xybBiasCbrt
+	m := math32.Cbrt(v.Y+xybBias) - xybBiasCbrt
+	s := math32.Cbrt(v.Z+xybBias) - xybBiasCbrt
+	return XYB{X: 0.5 * (l - m), Y: 0.5 * (l + m), B: s}
+}
+
+// LSRGB converts the XYB color to linear sRGB. The result may be out of gamut.
+func (c XYB) LSRGB() LSRGB {
+	mix := func(v float32) float32 {
+		v += xybBiasCbrt
+		return v*v*v - xybBias
+	}
+	v := ms3.MulMatVec(xybOpsinToLinSRGB, ms3.Vec{X: mix(c.Y + c.X), Y: mix(c.Y - c.X), Z: mix(c.B)})
+	return LSRGB{R: v.X, G: v.Y, B: v.Z}
+}
@@ -0,0 +1,31 @@
+package colorspace
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestXYB(t *testing.T) {
+	white := LSRGB{R: 1, G: 1, B: 1}.XYB()
+	wantY := math32.Cbrt(1+xybBias) - math32.Cbrt(xybBias)
+	if math32.Abs(white.X) > 1e-6 || math32.Abs(white.Y-wantY) > 1e-5 || math32.Abs(white.B-wantY) > 1e-5 {
+		t.Errorf("white = %v, want (0, %v, %v)", white, wantY, wantY)
+	}
+	if black := (LSRGB{}).XYB(); sqdist(black.vec(), XYB{}.vec()) > 1e-12 {
+		t.Errorf("black = %v, want zero", black)
+	}
+	for _, c := range []LSRGB{{R: 1}, {G: 1}, {B: 1}, {R: 0.2, G: 0.5, B: 0.9}, {R: 0.01, G: 0.002, B: 0.03}} {
+		xyb := c.XYB()
+		if got := xyb.LSRGB(); sqdist(got.vec(), c.vec()) > 1e-9 {
+			t.Errorf("round trip of %v = %v", c, got)
+		}
+	}
+	// Red has positive X and blue has more B than Y.
+	if red := (LSRGB{R: 1}).XYB(); red.X <= 0 {
+		t.Errorf("red = %v, want positive X", red)
+	}
+	if blue := (LSRGB{B: 1}).XYB(); blue.B <= blue.Y {
+		t.Errorf("blue = %v, want B > Y", blue)
+	}
+}
@@ -0,0 +1,119 @@
+package colorspace
+
+import "github.com/soypat/geometry/ms3"
+
+// YCbCrMatrix selects the luma coefficients of a [YCbCr] encoding.
+type YCbCrMatrix uint8
+
+const (
+	// YCbCrBT601 is the ITU-R BT.601 matrix of standard definition video and JPEG.
+	YCbCrBT601 YCbCrMatrix = iota
+	// YCbCrBT709 is the ITU-R BT.709 matrix of high definition video.
+	YCbCrBT709
+	// YCbCrBT2020 is the ITU-R BT.2020 non-constant luminance matrix of UHD video. Its R'G'B'
+	// values are encoded in the [Rec2020] color space instead of sRGB.
+	YCbCrBT2020
+)
+
+// YCbCrRange selects the quantization range of a [YCbCr] encoding.
+type YCbCrRange uint8
+
+const (
+	// YCbCrFull uses the whole code range: Y in [0,1] and Cb, Cr in [-0.5,0.5]. Used by JPEG.
+	YCbCrFull YCbCrRange = iota
+	// YCbCrLimited is the video (studio swing) range with headroom and footroom:
+	// Y in [16/255,235/255] and Cb, Cr in [-112/255,112/255] as in 8-bit video.
+	YCbCrLimited
+)
+
+// YCbCr is a luma and blue and red color difference encoding of gamma-encoded RGB used by video
+// codecs and JPEG. The same color has different values depending on the [YCbCrMatrix] and [YCbCrRange]
+// used, which must be passed to every conversion. Cb and Cr are signed without the offset
+// of integer code values: the 8-bit code values are 255*Y, 128+255*Cb and 128+255*Cr.
+// Unlike [color.YCbCr] conversions are in floating point for every standard and range.
+type YCbCr struct {
+	Y  float32 // Luma.
+	Cb float32 // Blue difference.
+	Cr float32 // Red difference.
+}
+
+func (c YCbCr) vec() ms3.Vec      { return ms3.Vec{X: c.Y, Y: c.Cb, Z: c.Cr} }
+func (c YCbCr) Array() [3]float32 { return c.vec().Array() }
+
+// coefficients returns the red and blue luma coefficients.
+func (m YCbCrMatrix) coefficients() (kr, kb float32) {
+	switch m {
+	case YCbCrBT601:
+		return 0.299, 0.114
+	case YCbCrBT709:
+		return 0.2126, 0.0722
+	case YCbCrBT2020:
+		return 0.2627, 0.0593
+	}
+	panic("invalid YCbCr matrix")
+}
+
+// YCbCr encodes the sRGB color. With [YCbCrBT2020] the color is first converted to [Rec2020].
+func (c SRGB) YCbCr(m YCbCrMatrix, r YCbCrRange) YCbCr {
+	if m == YCbCrBT2020 {
+		return c.LSRGB().YCbCr(m, r)
+	}
+	return encodeYCbCr(c.vec(), m, r)
+}
+
+// SRGB decodes the YCbCr color. The result may be out of gamut.
+func (c YCbCr) SRGB(m YCbCrMatrix, r YCbCrRange) SRGB {
+	if m == YCbCrBT2020 {
+		return c.LSRGB(m, r).SRGB()
+	}
+	v := c.decode(m, r)
+	return SRGB{R: v.X, G: v.Y, B: v.Z}
+}
+
+// YCbCr encodes the linear sRGB color. With [YCbCrBT2020] the color is first converted to [Rec2020].
+func (c LSRGB) YCbCr(m YCbCrMatrix, r YCbCrRange) YCbCr {
+	if m == YCbCrBT2020 {
+		return encodeYCbCr(c.CIEXYZ().Rec2020().vec(), m, r)
+	}
+	return c.SRGB().YCbCr(m, r)
+}
+
+// LSRGB decodes the YCbCr color into linear sRGB. The result may be out of gamut.
+func (c YCbCr) LSRGB(m YCbCrMatrix, r YCbCrRange) LSRGB {
+	if m == YCbCrBT2020 {
+		v := c.decode(m, r)
+		return Rec2020{R: v.X, G: v.Y, B: v.Z}.CIEXYZ().LSRGB()
+	}
+	return c.SRGB(m, r).LSRGB()
+}
+
+// encodeYCbCr encodes gamma-encoded R'G'B'.
+func encodeYCbCr(rgb ms3.Vec, m YCbCrMatrix, r YCbCrRange) YCbCr {
+	kr, kb := m.coefficients()
+	y := kr*rgb.X + (1-kr-kb)*rgb.Y + kb*rgb.Z
+	c := YCbCr{
+		Y:  y,
+		Cb: 0.5 * (rgb.Z - y) / (1 - kb),
+		Cr: 0.5 * (rgb.X - y) / (1 - kr),
+	}
+	if r == YCbCrLimited {
+		c.Y = (16 + 219*c.Y) / 255
+		c.Cb *= 224. / 255
+		c.Cr *= 224. / 255
+	}
+	return c
+}
+
+// decode returns the gamma-encoded R'G'B' of c.
+func (c YCbCr) decode(m YCbCrMatrix, r YCbCrRange) ms3.Vec {
+	if r == YCbCrLimited {
+		c.Y = (255*c.Y - 16) / 219
+		c.Cb *= 255. / 224
+		c.Cr *= 255. / 224
+	}
+	kr, kb := m.coefficients()
+	red := c.Y + 2*(1-kr)*c.Cr
+	blue := c.Y + 2*(1-kb)*c.Cb
+	green := (c.Y - kr*red - kb*blue) / (1 - kr - kb)
+	return ms3.Vec{X: red, Y: green, Z: blue}
+}
@@ -0,0 +1,45 @@
+package colorspace
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+func TestYCbCr(t *testing.T) {
+	colors := []SRGB{{R: 1}, {G: 1}, {B: 1}, {R: 1, G: 1, B: 1}, {}, {R: 0.2, G: 0.5, B: 0.8}, {R: 0.9, G: 0.4, B: 0.1}}
+	for _, m := range []YCbCrMatrix{YCbCrBT601, YCbCrBT709, YCbCrBT2020} {
+		for _, r := range []YCbCrRange{YCbCrFull, YCbCrLimited} {
+			for _, c := range colors {
+				ycc := c.YCbCr(m, r)
+				if got := ycc.SRGB(m, r); sqdist(got.vec(), c.vec()) > 1e-9 {
+					t.Errorf("matrix %d range %d: round trip of %+v via %+v gave %+v", m, r, c, ycc, got)
+				}
+				lin := c.LSRGB()
+				if got := lin.YCbCr(m, r).LSRGB(m, r); sqdist(got.vec(), lin.vec()) > 1e-9 {
+					t.Errorf("matrix %d range %d: linear round trip of %+v gave %+v", m, r, lin, got)
+				}
+			}
+		}
+	}
+	// Matches the integer JPEG conversion of the standard library.
+	for _, c := range colors {
+		r8, g8, b8 := uint8(c.R*255+0.5), uint8(c.G*255+0.5), uint8(c.B*255+0.5)
+		y, cb, cr := color.RGBToYCbCr(r8, g8, b8)
+		got := SRGB{R: float32(r8) / 255, G: float32(g8) / 255, B: float32(b8) / 255}.YCbCr(YCbCrBT601, YCbCrFull)
+		want := YCbCr{Y: float32(y) / 255, Cb: (float32(cb) - 128) / 255, Cr: (float32(cr) - 128) / 255}
+		if sqdist(got.vec(), want.vec()) > 3*sq(1.5/255) {
+			t.Errorf("BT.601 YCbCr of %+v = %+v, want %+v", c, got, want)
+		}
+	}
+	// Limited range video levels.
+	white := SRGB{R: 1, G: 1, B: 1}.YCbCr(YCbCrBT709, YCbCrLimited)
+	black := SRGB{}.YCbCr(YCbCrBT709, YCbCrLimited)
+	if math32.Abs(white.Y*255-235) > 1e-4 || math32.Abs(black.Y*255-16) > 1e-4 {
+		t.Errorf("limited range white %+v, black %+v", white, black)
+	}
+	if red := (SRGB{R: 1}).YCbCr(YCbCrBT709, YCbCrLimited); math32.Abs(red.Cr*255-112) > 1e-4 {
+		t.Errorf("limited range red Cr %v, want 112/255", red.Cr)
+	}
+}
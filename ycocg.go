@@ -0,0 +1,51 @@
+package colorspace
+
+import "github.com/soypat/geometry/ms3"
+
+// YCoCg is the luma, orange chroma and green chroma transform of gamma-encoded sRGB. It decorrelates
+// RGB almost as well as [YCbCr] using only additions and shifts, which makes it popular in image
+// codecs and texture compression. Y is in [0,1] and Co, Cg are in [-0.5,0.5].
+// See [RGBToYCoCgR] for the lossless integer variant.
+type YCoCg struct {
+	Y  float32 // Luma.
+	Co float32 // Orange chroma.
+	Cg float32 // Green chroma.
+}
+
+func (c YCoCg) vec() ms3.Vec      { return ms3.Vec{X: c.Y, Y: c.Co, Z: c.Cg} }
+func (c YCoCg) Array() [3]float32 { return c.vec().Array() }
+
+// YCoCg converts the sRGB color to YCoCg.
+func (c SRGB) YCoCg() YCoCg {
+	return YCoCg{
+		Y:  0.25*c.R + 0.5*c.G + 0.25*c.B,
+		Co: 0.5*c.R - 0.5*c.B,
+		Cg: -0.25*c.R + 0.5*c.G - 0.25*c.B,
+	}
+}
+
+// SRGB converts the YCoCg color to sRGB. The result may be out of gamut.
+func (c YCoCg) SRGB() SRGB {
+	t := c.Y - c.Cg
+	return SRGB{R: t + c.Co, G: c.Y + c.Cg, B: t - c.Co}
+}
+
+// RGBToYCoCgR converts integer RGB of any bit depth to YCoCg-R, the reversible lifting form of [YCoCg].
+// [YCoCgRToRGB] recovers r, g, b exactly. Y has the bit depth of the input while Co and Cg are signed
+// and need one extra bit: for 8-bit input Y is in [0,255] and Co, Cg in [-255,255].
+func RGBToYCoCgR(r, g, b int32) (y, co, cg int32) {
+	co = r - b
+	t := b + co>>1
+	cg = g - t
+	y = t + cg>>1
+	return y, co, cg
+}
+
+// YCoCgRToRGB converts YCoCg-R to integer RGB. It is the exact inverse of [RGBToYCoCgR].
+func YCoCgRToRGB(y, co, cg int32) (r, g, b int32) {
+	t := y - cg>>1
+	g = cg + t
+	b = t - co>>1
+	r = b + co
+	return r, g, b
+}
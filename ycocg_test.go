@@ -0,0 +1,30 @@
+package colorspace
+
+import "testing"
+
+func TestYCoCg(t *testing.T) {
+	colors := []SRGB{{R: 1}, {G: 1}, {B: 1}, {R: 1, G: 1, B: 1}, {}, {R: 0.2, G: 0.5, B: 0.8}}
+	for _, c := range colors {
+		ycc := c.YCoCg()
+		if got := ycc.SRGB(); sqdist(got.vec(), c.vec()) > 1e-12 {
+			t.Errorf("YCoCg round trip of %+v via %+v gave %+v", c, ycc, got)
+		}
+	}
+	if gray := (SRGB{R: 0.4, G: 0.4, B: 0.4}).YCoCg(); gray.Y != 0.4 || gray.Co != 0 || gray.Cg != 0 {
+		t.Errorf("gray YCoCg %+v", gray)
+	}
+	// YCoCg-R is lossless for every 8-bit color.
+	for r := int32(0); r < 256; r += 5 {
+		for g := int32(0); g < 256; g += 3 {
+			for b := int32(0); b < 256; b += 7 {
+				y, co, cg := RGBToYCoCgR(r, g, b)
+				if y < 0 || y > 255 || co < -255 || co > 255 || cg < -255 || cg > 255 {
+					t.Fatalf("YCoCg-R of %d,%d,%d out of range: %d,%d,%d", r, g, b, y, co, cg)
+				}
+				if r2, g2, b2 := YCoCgRToRGB(y, co, cg); r2 != r || g2 != g || b2 != b {
+					t.Fatalf("YCoCg-R round trip of %d,%d,%d gave %d,%d,%d", r, g, b, r2, g2, b2)
+				}
+			}
+		}
+	}
+}
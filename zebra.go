@@ -0,0 +1,94 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// OverlayMark reports whether a pixel of straight (non-premultiplied) sRGB color c should be marked by [ZebraOverlay].
+type OverlayMark func(c SRGB) bool
+
+// MarkLumaAbove marks pixels whose Rec. 709 luma signal level is at or above level in [0,1],
+// i.e: 0.95 for classic 95 IRE zebras warning of blown highlights.
+func MarkLumaAbove(level float32) OverlayMark {
+	return func(c SRGB) bool {
+		luma, _, _ := rec709YCbCr(c)
+		return luma >= level
+	}
+}
+
+// MarkOutOfGamut marks pixels whose color lies outside a target gamut, i.e: that will clip when converted
+// for a narrower display or print process. inGamut reports whether a D65 relative XYZ color is
+// within the target gamut. For sRGB pass
+//
+//	func(c CIEXYZ) bool { return c.LSRGB().InGamut() }
+func MarkOutOfGamut(inGamut func(c CIEXYZ) bool) OverlayMark {
+	return func(c SRGB) bool {
+		return !inGamut(c.LSRGB().CIEXYZ())
+	}
+}
+
+// OverlayPattern is the pattern drawn over marked pixels by [ZebraOverlay].
+type OverlayPattern uint8
+
+const (
+	// PatternZebra draws diagonal stripes.
+	PatternZebra OverlayPattern = iota
+	// PatternChecker draws a checkerboard.
+	PatternChecker
+	// PatternSolid fills marked pixels.
+	PatternSolid
+)
+
+// ZebraOverlay returns an overlay the size of img which is transparent except for marked pixels, which
+// are painted with ink in the given pattern of period pixels. Draw the overlay over the image
+// with [draw.Over] to preview clipping, see [Zebra]. Fully transparent pixels are never marked.
+func ZebraOverlay(img image.Image, mark OverlayMark, pattern OverlayPattern, ink color.Color, period int) *image.RGBA {
+	if period <= 1 {
+		period = 2
+	}
+	bounds := img.Bounds()
+	overlay := image.NewRGBA(bounds)
+	inkRGBA := color.RGBAModel.Convert(ink).(color.RGBA)
+	half := period / 2
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			var on bool
+			switch pattern {
+			case PatternZebra:
+				on = mod(x+y, period) < half
+			case PatternChecker:
+				on = (mod(x, period) < half) == (mod(y, period) < half)
+			default:
+				on = true
+			}
+			if !on {
+				continue
+			}
+			c, a := unpremultiplied(img.At(x, y))
+			if a > 0 && mark(c) {
+				overlay.SetRGBA(x, y, inkRGBA)
+			}
+		}
+	}
+	return overlay
+}
+
+// Zebra returns a copy of img with the [ZebraOverlay] drawn over it.
+func Zebra(img image.Image, mark OverlayMark, pattern OverlayPattern, ink color.Color, period int) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, img, bounds.Min, draw.Src)
+	draw.Draw(dst, bounds, ZebraOverlay(img, mark, pattern, ink, period), bounds.Min, draw.Over)
+	return dst
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int) int {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
@@ -0,0 +1,48 @@
+package colorspace
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestZebraOverlay(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			c := color.RGBA{R: 100, G: 100, B: 100, A: 255}
+			if x >= 4 {
+				c = color.RGBA{R: 250, G: 250, B: 250, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	ink := color.RGBA{R: 255, A: 255}
+	for _, pattern := range []OverlayPattern{PatternZebra, PatternChecker, PatternSolid} {
+		overlay := ZebraOverlay(img, MarkLumaAbove(0.95), pattern, ink, 4)
+		var marked, wrong int
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 8; x++ {
+				if overlay.RGBAAt(x, y) == ink {
+					marked++
+					if x < 4 {
+						wrong++
+					}
+				}
+			}
+		}
+		want := 16
+		if pattern == PatternSolid {
+			want = 32
+		}
+		if marked != want || wrong != 0 {
+			t.Errorf("pattern %d: marked %d pixels (%d outside highlights), want %d", pattern, marked, wrong, want)
+		}
+	}
+	// Every sRGB pixel lies within the sRGB gamut.
+	inSRGB := func(c CIEXYZ) bool { return c.LSRGB().InGamut() }
+	out := Zebra(img, MarkOutOfGamut(inSRGB), PatternSolid, ink, 4)
+	if out.RGBAAt(1, 1) != img.RGBAAt(1, 1) {
+		t.Errorf("in gamut pixel was marked")
+	}
+}